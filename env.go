@@ -0,0 +1,240 @@
+package observability
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// Environment variables understood by ConfigFromEnv in addition to the
+// OTEL_* variables read by tracing.ConfigFromEnv.
+const (
+	// EnvSDKDisabled disables tracing, log export and OTLP metric export when
+	// "true", per the OpenTelemetry specification. It takes precedence over
+	// EnvTracingEnabled, EnvLoggingEnabled and EnvOTLPMetricsEnabled.
+	EnvSDKDisabled = "OTEL_SDK_DISABLED"
+
+	EnvMetricsEnabled = "TXOVA_METRICS_ENABLED"
+	EnvTracingEnabled = "TXOVA_TRACING_ENABLED"
+	EnvHealthEnabled  = "TXOVA_HEALTH_ENABLED"
+	EnvLoggingEnabled = "TXOVA_LOGGING_ENABLED"
+
+	// EnvOTLPMetricsEnabled exports the metrics registry via OTLP to the
+	// tracing endpoint; EnvOTLPMetricsInterval sets the export interval.
+	EnvOTLPMetricsEnabled  = "TXOVA_OTLP_METRICS_ENABLED"
+	EnvOTLPMetricsInterval = "TXOVA_OTLP_METRICS_INTERVAL"
+
+	EnvMetricsNamespace = "TXOVA_METRICS_NAMESPACE"
+	EnvMetricsSubsystem = "TXOVA_METRICS_SUBSYSTEM"
+
+	// Health durations use Go duration syntax (e.g., "5s", "500ms").
+	EnvHealthTimeout            = "TXOVA_HEALTH_TIMEOUT"
+	EnvHealthCacheTTL           = "TXOVA_HEALTH_CACHE_TTL"
+	EnvHealthBackgroundInterval = "TXOVA_HEALTH_BACKGROUND_INTERVAL"
+	EnvHealthFailureThreshold   = "TXOVA_HEALTH_FAILURE_THRESHOLD"
+
+	// EnvPushgatewayURL enables pushing metrics to the given Pushgateway.
+	EnvPushgatewayURL      = "TXOVA_PUSHGATEWAY_URL"
+	EnvPushgatewayJob      = "TXOVA_PUSHGATEWAY_JOB"
+	EnvPushgatewayInterval = "TXOVA_PUSHGATEWAY_INTERVAL"
+
+	// EnvRemoteWriteURL enables sending metrics to the given remote-write endpoint.
+	EnvRemoteWriteURL       = "TXOVA_REMOTE_WRITE_URL"
+	EnvRemoteWriteInterval  = "TXOVA_REMOTE_WRITE_INTERVAL"
+	EnvRemoteWriteBufferDir = "TXOVA_REMOTE_WRITE_BUFFER_DIR"
+
+	// EnvStatsDAddress enables sending collector values to the given StatsD
+	// address ("host:port" or "unix:///path").
+	EnvStatsDAddress = "TXOVA_STATSD_ADDRESS"
+	EnvStatsDPrefix  = "TXOVA_STATSD_PREFIX"
+)
+
+// ConfigFromEnv returns DefaultConfig overlaid with environment variables.
+// Tracing settings are loaded with tracing.ConfigFromEnv; see its documentation
+// for the OTEL_* variables and their precedence. Programmatic changes made to
+// the returned Config take precedence over the environment.
+//
+// Invalid values return an error naming the offending variable.
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	tracingCfg, err := tracing.ConfigFromEnv()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Tracing = tracingCfg
+
+	if err := applyEnabledEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := applyHealthEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := applyPushEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := applyOTLPMetricsEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if err := applyRemoteWriteEnv(&cfg); err != nil {
+		return Config{}, err
+	}
+	if v := getEnv(EnvStatsDAddress); v != "" {
+		cfg.StatsD.Address = v
+		cfg.StatsDEnabled = true
+	}
+	if v := getEnv(EnvStatsDPrefix); v != "" {
+		cfg.StatsD.Prefix = v
+	}
+
+	if v := getEnv(EnvMetricsNamespace); v != "" {
+		cfg.Metrics.Namespace = v
+	}
+	if v := getEnv(EnvMetricsSubsystem); v != "" {
+		cfg.Metrics.Subsystem = v
+	}
+
+	return cfg, nil
+}
+
+// getEnv returns the trimmed value of an environment variable.
+func getEnv(key string) string {
+	return strings.TrimSpace(os.Getenv(key))
+}
+
+// applyEnabledEnv reads the subsystem enabled flags.
+func applyEnabledEnv(cfg *Config) error {
+	flags := []struct {
+		key string
+		dst *bool
+	}{
+		{EnvMetricsEnabled, &cfg.MetricsEnabled},
+		{EnvTracingEnabled, &cfg.TracingEnabled},
+		{EnvHealthEnabled, &cfg.HealthEnabled},
+		{EnvLoggingEnabled, &cfg.LoggingEnabled},
+		{EnvOTLPMetricsEnabled, &cfg.OTLPMetricsEnabled},
+	}
+	for _, f := range flags {
+		if v := getEnv(f.key); v != "" {
+			enabled, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", f.key, err)
+			}
+			*f.dst = enabled
+		}
+	}
+
+	if v := getEnv(EnvSDKDisabled); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvSDKDisabled, err)
+		}
+		if disabled {
+			cfg.TracingEnabled = false
+			cfg.LoggingEnabled = false
+			cfg.OTLPMetricsEnabled = false
+		}
+	}
+
+	return nil
+}
+
+// applyHealthEnv reads the health manager timeouts and failure threshold.
+func applyHealthEnv(cfg *Config) error {
+	durations := []struct {
+		key string
+		dst *time.Duration
+	}{
+		{EnvHealthTimeout, &cfg.Health.Timeout},
+		{EnvHealthCacheTTL, &cfg.Health.CacheTTL},
+		{EnvHealthBackgroundInterval, &cfg.Health.BackgroundInterval},
+	}
+	for _, d := range durations {
+		if v := getEnv(d.key); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", d.key, err)
+			}
+			if parsed <= 0 {
+				return fmt.Errorf("invalid %s: must be positive, got %s", d.key, v)
+			}
+			*d.dst = parsed
+		}
+	}
+
+	if v := getEnv(EnvHealthFailureThreshold); v != "" {
+		threshold, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvHealthFailureThreshold, err)
+		}
+		if threshold < 1 {
+			return fmt.Errorf("invalid %s: must be at least 1, got %d", EnvHealthFailureThreshold, threshold)
+		}
+		cfg.Health.FailureThreshold = threshold
+	}
+
+	return nil
+}
+
+// applyPushEnv reads the Pushgateway settings.
+func applyPushEnv(cfg *Config) error {
+	if v := getEnv(EnvPushgatewayURL); v != "" {
+		cfg.Push.URL = v
+		cfg.PushEnabled = true
+	}
+	if v := getEnv(EnvPushgatewayJob); v != "" {
+		cfg.Push.Job = v
+	}
+	if v := getEnv(EnvPushgatewayInterval); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvPushgatewayInterval, err)
+		}
+		if interval < 0 {
+			return fmt.Errorf("invalid %s: must not be negative, got %s", EnvPushgatewayInterval, v)
+		}
+		cfg.Push.Interval = interval
+	}
+	return nil
+}
+
+// applyOTLPMetricsEnv reads the OTLP metric export interval.
+func applyOTLPMetricsEnv(cfg *Config) error {
+	if v := getEnv(EnvOTLPMetricsInterval); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvOTLPMetricsInterval, err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("invalid %s: must be positive, got %s", EnvOTLPMetricsInterval, v)
+		}
+		cfg.OTLPMetrics.Interval = interval
+	}
+	return nil
+}
+
+// applyRemoteWriteEnv reads the remote-write settings.
+func applyRemoteWriteEnv(cfg *Config) error {
+	if v := getEnv(EnvRemoteWriteURL); v != "" {
+		cfg.RemoteWrite.URL = v
+		cfg.RemoteWriteEnabled = true
+	}
+	if v := getEnv(EnvRemoteWriteInterval); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvRemoteWriteInterval, err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("invalid %s: must be positive, got %s", EnvRemoteWriteInterval, v)
+		}
+		cfg.RemoteWrite.Interval = interval
+	}
+	if v := getEnv(EnvRemoteWriteBufferDir); v != "" {
+		cfg.RemoteWrite.BufferDir = v
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package observability
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(tracing.EnvServiceName, "ride-service")
+	t.Setenv(EnvHealthEnabled, "false")
+	t.Setenv(EnvMetricsNamespace, "txova_edge")
+	t.Setenv(EnvMetricsSubsystem, "rides")
+	t.Setenv(EnvHealthTimeout, "750ms")
+	t.Setenv(EnvHealthCacheTTL, "10s")
+	t.Setenv(EnvHealthBackgroundInterval, "1m")
+	t.Setenv(EnvHealthFailureThreshold, "5")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+
+	if cfg.Tracing.ServiceName != "ride-service" {
+		t.Errorf("Tracing.ServiceName = %v, want ride-service", cfg.Tracing.ServiceName)
+	}
+	if cfg.HealthEnabled {
+		t.Error("HealthEnabled should be false")
+	}
+	if !cfg.MetricsEnabled || !cfg.TracingEnabled {
+		t.Error("MetricsEnabled and TracingEnabled should keep their defaults")
+	}
+	if cfg.Metrics.Namespace != "txova_edge" || cfg.Metrics.Subsystem != "rides" {
+		t.Errorf("Metrics = %+v", cfg.Metrics)
+	}
+	if cfg.Health.Timeout != 750*time.Millisecond {
+		t.Errorf("Health.Timeout = %v, want 750ms", cfg.Health.Timeout)
+	}
+	if cfg.Health.CacheTTL != 10*time.Second {
+		t.Errorf("Health.CacheTTL = %v, want 10s", cfg.Health.CacheTTL)
+	}
+	if cfg.Health.BackgroundInterval != time.Minute {
+		t.Errorf("Health.BackgroundInterval = %v, want 1m", cfg.Health.BackgroundInterval)
+	}
+	if cfg.Health.FailureThreshold != 5 {
+		t.Errorf("Health.FailureThreshold = %d, want 5", cfg.Health.FailureThreshold)
+	}
+}
+
+func TestConfigFromEnv_SDKDisabled(t *testing.T) {
+	t.Setenv(EnvTracingEnabled, "true")
+	t.Setenv(EnvLoggingEnabled, "true")
+	t.Setenv(EnvOTLPMetricsEnabled, "true")
+	t.Setenv(EnvSDKDisabled, "true")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.TracingEnabled {
+		t.Error("OTEL_SDK_DISABLED should take precedence over TXOVA_TRACING_ENABLED")
+	}
+	if cfg.LoggingEnabled {
+		t.Error("OTEL_SDK_DISABLED should take precedence over TXOVA_LOGGING_ENABLED")
+	}
+	if cfg.OTLPMetricsEnabled {
+		t.Error("OTEL_SDK_DISABLED should take precedence over TXOVA_OTLP_METRICS_ENABLED")
+	}
+}
+
+func TestConfigFromEnv_LoggingEnabled(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.LoggingEnabled {
+		t.Error("LoggingEnabled should be false by default")
+	}
+
+	t.Setenv(EnvLoggingEnabled, "true")
+	cfg, err = ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if !cfg.LoggingEnabled {
+		t.Error("LoggingEnabled should be true")
+	}
+}
+
+func TestConfigFromEnv_Pushgateway(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.PushEnabled {
+		t.Error("PushEnabled should be false by default")
+	}
+
+	t.Setenv(EnvPushgatewayURL, "http://pushgateway:9091")
+	t.Setenv(EnvPushgatewayJob, "backfill")
+	t.Setenv(EnvPushgatewayInterval, "15s")
+	cfg, err = ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if !cfg.PushEnabled {
+		t.Error("PushEnabled should be true when the Pushgateway URL is set")
+	}
+	if cfg.Push.URL != "http://pushgateway:9091" || cfg.Push.Job != "backfill" || cfg.Push.Interval != 15*time.Second {
+		t.Errorf("Push = %+v", cfg.Push)
+	}
+}
+
+func TestConfigFromEnv_OTLPMetrics(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.OTLPMetricsEnabled {
+		t.Error("OTLPMetricsEnabled should be false by default")
+	}
+
+	t.Setenv(EnvOTLPMetricsEnabled, "true")
+	t.Setenv(EnvOTLPMetricsInterval, "15s")
+	cfg, err = ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if !cfg.OTLPMetricsEnabled {
+		t.Error("OTLPMetricsEnabled should be true")
+	}
+	if cfg.OTLPMetrics.Interval != 15*time.Second {
+		t.Errorf("OTLPMetrics.Interval = %v, want 15s", cfg.OTLPMetrics.Interval)
+	}
+}
+
+func TestConfigFromEnv_RemoteWrite(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.RemoteWriteEnabled {
+		t.Error("RemoteWriteEnabled should be false by default")
+	}
+
+	t.Setenv(EnvRemoteWriteURL, "http://prometheus:9090/api/v1/write")
+	t.Setenv(EnvRemoteWriteInterval, "30s")
+	t.Setenv(EnvRemoteWriteBufferDir, "/var/lib/txova/remote-write")
+	cfg, err = ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if !cfg.RemoteWriteEnabled {
+		t.Error("RemoteWriteEnabled should be true when the remote-write URL is set")
+	}
+	if cfg.RemoteWrite.URL != "http://prometheus:9090/api/v1/write" || cfg.RemoteWrite.Interval != 30*time.Second ||
+		cfg.RemoteWrite.BufferDir != "/var/lib/txova/remote-write" {
+		t.Errorf("RemoteWrite = %+v", cfg.RemoteWrite)
+	}
+}
+
+func TestConfigFromEnv_StatsD(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.StatsDEnabled {
+		t.Error("StatsDEnabled should be false by default")
+	}
+
+	t.Setenv(EnvStatsDAddress, "unix:///var/run/datadog/dsd.socket")
+	t.Setenv(EnvStatsDPrefix, "partner.")
+	cfg, err = ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if !cfg.StatsDEnabled {
+		t.Error("StatsDEnabled should be true when the StatsD address is set")
+	}
+	if cfg.StatsD.Address != "unix:///var/run/datadog/dsd.socket" || cfg.StatsD.Prefix != "partner." {
+		t.Errorf("StatsD = %+v", cfg.StatsD)
+	}
+}
+
+func TestConfigFromEnv_Errors(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{EnvMetricsEnabled, "yes please"},
+		{EnvSDKDisabled, "nope"},
+		{EnvHealthTimeout, "5"},
+		{EnvHealthCacheTTL, "-1s"},
+		{EnvHealthFailureThreshold, "0"},
+		{EnvHealthFailureThreshold, "three"},
+		{EnvPushgatewayInterval, "soon"},
+		{EnvPushgatewayInterval, "-1m"},
+		{EnvOTLPMetricsEnabled, "maybe"},
+		{EnvOTLPMetricsInterval, "0s"},
+		{EnvRemoteWriteInterval, "later"},
+		{EnvRemoteWriteInterval, "-5s"},
+		{tracing.EnvTracesSampler, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+
+			_, err := ConfigFromEnv()
+			if err == nil {
+				t.Fatalf("ConfigFromEnv() error = nil, want error for %s=%s", tt.key, tt.value)
+			}
+			if !strings.Contains(err.Error(), tt.key) {
+				t.Errorf("error %q does not name %s", err, tt.key)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package observability
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// GRPCOption configures the gRPC server and dial options.
+type GRPCOption func(*grpcOptions)
+
+// grpcOptions holds the gRPC options.
+type grpcOptions struct {
+	filter tracing.GRPCFilter
+}
+
+// newGRPCOptions applies the options over the defaults.
+func newGRPCOptions(opts []GRPCOption) grpcOptions {
+	options := grpcOptions{filter: tracing.DefaultGRPCFilter}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// instrumented reports whether calls to fullMethod are instrumented.
+func (o grpcOptions) instrumented(fullMethod string) bool {
+	return o.filter == nil || o.filter(fullMethod)
+}
+
+// WithGRPCFilter sets the filter deciding which calls are traced and recorded
+// in gRPC metrics. It replaces tracing.DefaultGRPCFilter, which skips the
+// health checking service; nil instruments every call.
+func WithGRPCFilter(filter tracing.GRPCFilter) GRPCOption {
+	return func(o *grpcOptions) {
+		o.filter = filter
+	}
+}
+
+// GRPCServerOptions returns server options installing unary and stream
+// interceptors that add tracing and metrics to incoming calls:
+//
+//	srv := grpc.NewServer(obs.GRPCServerOptions()...)
+func (o *Observability) GRPCServerOptions(opts ...GRPCOption) []grpc.ServerOption {
+	options := newGRPCOptions(opts)
+
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+
+	// Tracing runs first so the metrics interceptors see the span context.
+	if o.Tracer != nil {
+		tracingOpt := tracing.WithGRPCFilter(options.filter)
+		unary = append(unary, tracing.UnaryServerInterceptor(o.Tracer, tracingOpt))
+		stream = append(stream, tracing.StreamServerInterceptor(o.Tracer, tracingOpt))
+	}
+	if o.GRPCCollector != nil {
+		unary = append(unary, o.unaryServerMetrics(options))
+		stream = append(stream, o.streamServerMetrics(options))
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// GRPCDialOptions returns dial options installing unary and stream
+// interceptors that add tracing and metrics to outgoing calls and propagate
+// the trace context and request ID:
+//
+//	conn, err := grpc.NewClient(target, append(obs.GRPCDialOptions(), creds)...)
+func (o *Observability) GRPCDialOptions(opts ...GRPCOption) []grpc.DialOption {
+	options := newGRPCOptions(opts)
+
+	var unary []grpc.UnaryClientInterceptor
+	var stream []grpc.StreamClientInterceptor
+
+	if o.Tracer != nil {
+		tracingOpt := tracing.WithGRPCFilter(options.filter)
+		unary = append(unary, tracing.UnaryClientInterceptor(o.Tracer, tracingOpt))
+		stream = append(stream, tracing.StreamClientInterceptor(o.Tracer, tracingOpt))
+	}
+	if o.GRPCCollector != nil {
+		unary = append(unary, o.unaryClientMetrics(options))
+		stream = append(stream, o.streamClientMetrics(options))
+	}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unary...),
+		grpc.WithChainStreamInterceptor(stream...),
+	}
+}
+
+// unaryServerMetrics records incoming unary calls accepted by the filter.
+func (o *Observability) unaryServerMetrics(options grpcOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !options.instrumented(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		service, method := tracing.SplitGRPCMethod(info.FullMethod)
+		o.GRPCCollector.RecordServerHandled(service, method, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// streamServerMetrics records incoming streams accepted by the filter.
+func (o *Observability) streamServerMetrics(options grpcOptions) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !options.instrumented(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		start := time.Now()
+		err := handler(srv, ss)
+		service, method := tracing.SplitGRPCMethod(info.FullMethod)
+		o.GRPCCollector.RecordServerHandled(service, method, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+// unaryClientMetrics records outgoing unary calls accepted by the filter.
+func (o *Observability) unaryClientMetrics(options grpcOptions) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, fullMethod string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		if !options.instrumented(fullMethod) {
+			return invoker(ctx, fullMethod, req, reply, cc, callOpts...)
+		}
+
+		start := time.Now()
+		err := invoker(ctx, fullMethod, req, reply, cc, callOpts...)
+		service, method := tracing.SplitGRPCMethod(fullMethod)
+		o.GRPCCollector.RecordClientHandled(service, method, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+// streamClientMetrics records outgoing streams accepted by the filter when
+// they complete.
+func (o *Observability) streamClientMetrics(options grpcOptions) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, fullMethod string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if !options.instrumented(fullMethod) {
+			return streamer(ctx, desc, cc, fullMethod, callOpts...)
+		}
+
+		start := time.Now()
+		service, method := tracing.SplitGRPCMethod(fullMethod)
+		finish := func(err error) {
+			o.GRPCCollector.RecordClientHandled(service, method, status.Code(err).String(), time.Since(start))
+		}
+
+		cs, err := streamer(ctx, desc, cc, fullMethod, callOpts...)
+		if err != nil {
+			finish(err)
+			return nil, err
+		}
+		return tracing.NewClientStream(ctx, cs, desc, finish), nil
+	}
+}
@@ -0,0 +1,277 @@
+package observability
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/health"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// startGRPCHealth serves the standard health service with the server options
+// of obs and returns a client using its dial options.
+func startGRPCHealth(t *testing.T, obs *Observability, opts ...GRPCOption) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(obs.GRPCServerOptions(opts...)...)
+	hs := grpchealth.NewServer()
+	hs.SetServingStatus("rides", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	dialOpts := append(obs.GRPCDialOptions(opts...),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+// grpcHandled returns the handled_total value of a method and code.
+func grpcHandled(t *testing.T, registry *prometheus.Registry, name, method, code string) float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, mf := range families {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			labels := make(map[string]string)
+			for _, lp := range m.GetLabel() {
+				labels[lp.GetName()] = lp.GetValue()
+			}
+			if labels["service"] == "grpc.health.v1.Health" && labels["method"] == method && labels["code"] == code {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestObservability_GRPC(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	cfg := &Config{
+		Metrics: metrics.DefaultConfig().WithRegistry(registry).WithSubsystem("test_grpc"),
+		Tracing: tracing.Config{
+			ServiceName: "test-service",
+			Exporter:    tracing.ExporterNone,
+		},
+		MetricsEnabled: true,
+		TracingEnabled: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer obs.Close(ctx)
+
+	client := startGRPCHealth(t, obs, WithGRPCFilter(nil))
+
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "rides"}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("Check(unknown) error = %v, want NotFound", err)
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	stream, err := client.Watch(watchCtx, &healthpb.HealthCheckRequest{Service: "rides"})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Fatalf("Recv() after cancel error = %v, want Canceled", err)
+	}
+
+	const (
+		serverHandled = "txova_test_grpc_grpc_server_handled_total"
+		clientHandled = "txova_test_grpc_grpc_client_handled_total"
+	)
+	tests := []struct {
+		name   string
+		metric string
+		method string
+		code   string
+		want   float64
+	}{
+		{"server OK", serverHandled, "Check", "OK", 1},
+		{"server NotFound", serverHandled, "Check", "NotFound", 1},
+		{"client OK", clientHandled, "Check", "OK", 1},
+		{"client NotFound", clientHandled, "Check", "NotFound", 1},
+		{"client stream Canceled", clientHandled, "Watch", "Canceled", 1},
+	}
+	for _, tt := range tests {
+		if got := grpcHandled(t, registry, tt.metric, tt.method, tt.code); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestObservability_GRPC_StreamCanceledWithoutRecv(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	cfg := &Config{
+		Metrics:        metrics.DefaultConfig().WithRegistry(registry).WithSubsystem("test_grpc_cancel"),
+		MetricsEnabled: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer obs.Close(ctx)
+
+	client := startGRPCHealth(t, obs, WithGRPCFilter(nil))
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	stream, err := client.Watch(watchCtx, &healthpb.HealthCheckRequest{Service: "rides"})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+
+	// The caller abandons the stream without calling Recv again.
+	cancel()
+
+	const clientHandled = "txova_test_grpc_cancel_grpc_client_handled_total"
+	deadline := time.Now().Add(2 * time.Second)
+	for grpcHandled(t, registry, clientHandled, "Watch", "Canceled") != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("canceled stream was not recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestObservability_GRPC_DefaultFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	cfg := &Config{
+		Metrics:        metrics.DefaultConfig().WithRegistry(registry).WithSubsystem("test_grpc_filter"),
+		MetricsEnabled: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	client := startGRPCHealth(t, obs)
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "rides"}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	n, err := testutil.GatherAndCount(registry, "txova_test_grpc_filter_grpc_server_handled_total")
+	if err != nil {
+		t.Fatalf("GatherAndCount() error = %v", err)
+	}
+	if n != 0 {
+		t.Errorf("recorded %d health check series, want 0", n)
+	}
+}
+
+func TestObservability_GRPC_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	obs, err := New(ctx, &Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	client := startGRPCHealth(t, obs, WithGRPCFilter(nil))
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "rides"}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+}
+
+func TestObservability_HealthGRPCServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	obs, err := New(ctx, &Config{
+		Health:        health.DefaultManagerConfig(),
+		HealthEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	obs.RegisterHealthChecker(health.NewFuncChecker("postgres", func(context.Context) error { return nil }, true))
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	obs.HealthGRPCServer.Register(srv)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "postgres"})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Check() = %v, want SERVING", resp.GetStatus())
+	}
+
+	// Close reports NOT_SERVING so load balancers drain the server.
+	if err := obs.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	resp, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Check() after Close = %v, want NOT_SERVING", resp.GetStatus())
+	}
+}
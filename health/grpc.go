@@ -0,0 +1,142 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// minWatchInterval bounds how often Watch re-runs checks when the cache TTL is shorter.
+const minWatchInterval = time.Second
+
+// GRPCServer implements the gRPC health checking protocol (grpc.health.v1)
+// from Manager reports. The empty service name reports the overall status,
+// like the readiness endpoint, and each checker name reports that
+// component. Healthy and degraded map to SERVING, unhealthy to NOT_SERVING.
+type GRPCServer struct {
+	healthpb.UnimplementedHealthServer
+
+	manager      *Manager
+	shuttingDown atomic.Bool
+	shutdownCh   chan struct{}
+	shutdownOnce sync.Once
+}
+
+var _ healthpb.HealthServer = (*GRPCServer)(nil)
+
+// NewGRPCServer creates a gRPC health server for the manager.
+func NewGRPCServer(manager *Manager) *GRPCServer {
+	return &GRPCServer{
+		manager:    manager,
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// Register registers the health service on a gRPC server.
+func (s *GRPCServer) Register(registrar grpc.ServiceRegistrar) {
+	healthpb.RegisterHealthServer(registrar, s)
+}
+
+// Shutdown reports every service as NOT_SERVING from now on, so clients and
+// load balancers stop sending traffic while the server drains.
+func (s *GRPCServer) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		s.shuttingDown.Store(true)
+		close(s.shutdownCh)
+	})
+}
+
+// Check returns the status of a service, or NotFound for unknown services.
+func (s *GRPCServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus := s.servingStatus(s.manager.Check(ctx), req.GetService())
+	if servingStatus == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// List returns the status of the overall service and every checker.
+func (s *GRPCServer) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	report := s.manager.Check(ctx)
+
+	statuses := make(map[string]*healthpb.HealthCheckResponse, len(report.Checks)+1)
+	statuses[""] = &healthpb.HealthCheckResponse{Status: s.servingStatus(report, "")}
+	for name := range report.Checks {
+		statuses[name] = &healthpb.HealthCheckResponse{Status: s.servingStatus(report, name)}
+	}
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+// Watch sends the status of a service, then every change until the client
+// cancels. Unknown services are reported as SERVICE_UNKNOWN, as they may be
+// registered later. The status is re-evaluated whenever the manager caches
+// a new report and at least every CacheTTL, so it also changes without
+// background checks.
+func (s *GRPCServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+
+	ticker := time.NewTicker(max(s.manager.config.CacheTTL, minWatchInterval))
+	defer ticker.Stop()
+
+	shutdown := s.shutdownCh
+	refresh := true
+	var last healthpb.HealthCheckResponse_ServingStatus = -1
+	for {
+		// Subscribe before reading the report so no update is missed in between.
+		updated := s.manager.reportUpdated()
+
+		// Run checks on the first pass and on ticks only; on updates, the new
+		// report is already cached.
+		report, ok := s.manager.latestReport()
+		if refresh || !ok {
+			report = s.manager.Check(ctx)
+		}
+		refresh = false
+
+		servingStatus := s.servingStatus(report, req.GetService())
+		if servingStatus != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return status.Error(codes.Canceled, "stream has ended")
+			}
+			last = servingStatus
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case <-updated:
+		case <-ticker.C:
+			refresh = true
+		case <-shutdown:
+			shutdown = nil
+		}
+	}
+}
+
+// servingStatus returns the status of service in report.
+func (s *GRPCServer) servingStatus(report Report, service string) healthpb.HealthCheckResponse_ServingStatus {
+	st := report.Status
+	if service != "" {
+		result, ok := report.Checks[service]
+		if !ok {
+			return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		st = result.Status
+	}
+
+	if s.shuttingDown.Load() {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	switch st {
+	case StatusHealthy, StatusDegraded:
+		return healthpb.HealthCheckResponse_SERVING
+	default:
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+}
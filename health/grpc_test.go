@@ -0,0 +1,245 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// grpcTest holds a manager with a required "postgres" and an optional
+// "redis" checker whose health can be toggled, served over gRPC.
+type grpcTest struct {
+	manager       *Manager
+	server        *GRPCServer
+	client        healthpb.HealthClient
+	postgresError atomic.Bool
+	redisError    atomic.Bool
+}
+
+func newGRPCTest(t *testing.T) *grpcTest {
+	t.Helper()
+
+	tt := &grpcTest{}
+	tt.manager = NewManager(DefaultManagerConfig().WithCacheTTL(0).WithFailureThreshold(1))
+	tt.manager.Register(NewFuncChecker("postgres", func(context.Context) error {
+		if tt.postgresError.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	}, true))
+	tt.manager.Register(NewFuncChecker("redis", func(context.Context) error {
+		if tt.redisError.Load() {
+			return errors.New("timeout")
+		}
+		return nil
+	}, false))
+	tt.server = NewGRPCServer(tt.manager)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	tt.server.Register(srv)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	tt.client = healthpb.NewHealthClient(conn)
+	return tt
+}
+
+// watch starts watching service and returns the received statuses.
+func (tt *grpcTest) watch(t *testing.T, service string) <-chan healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stream, err := tt.client.Watch(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	ch := make(chan healthpb.HealthCheckResponse_ServingStatus, 10)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			ch <- resp.GetStatus()
+		}
+	}()
+	return ch
+}
+
+// next returns the next watched status.
+func next(t *testing.T, ch <-chan healthpb.HealthCheckResponse_ServingStatus) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	select {
+	case s := <-ch:
+		return s
+	case <-time.After(2 * time.Second):
+		t.Fatal("no status received")
+		return healthpb.HealthCheckResponse_UNKNOWN
+	}
+}
+
+func TestGRPCServer_Check(t *testing.T) {
+	t.Parallel()
+
+	const (
+		serving    = healthpb.HealthCheckResponse_SERVING
+		notServing = healthpb.HealthCheckResponse_NOT_SERVING
+	)
+	tests := []struct {
+		name          string
+		postgresError bool
+		redisError    bool
+		service       string
+		want          healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"overall healthy", false, false, "", serving},
+		{"checker healthy", false, false, "postgres", serving},
+		{"required checker failing", true, false, "", notServing},
+		{"failing checker", true, false, "postgres", notServing},
+		{"optional checker failing", false, true, "", serving},
+		{"failing optional checker", false, true, "redis", notServing},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt := newGRPCTest(t)
+			tt.postgresError.Store(tc.postgresError)
+			tt.redisError.Store(tc.redisError)
+
+			resp, err := tt.client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tc.service})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if resp.GetStatus() != tc.want {
+				t.Errorf("Check(%q) = %v, want %v", tc.service, resp.GetStatus(), tc.want)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_CheckUnknown(t *testing.T) {
+	t.Parallel()
+
+	tt := newGRPCTest(t)
+
+	_, err := tt.client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "kafka"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Check(kafka) error = %v, want NotFound", err)
+	}
+}
+
+func TestGRPCServer_List(t *testing.T) {
+	t.Parallel()
+
+	tt := newGRPCTest(t)
+	tt.redisError.Store(true)
+
+	resp, err := tt.client.List(context.Background(), &healthpb.HealthListRequest{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	want := map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":         healthpb.HealthCheckResponse_SERVING,
+		"postgres": healthpb.HealthCheckResponse_SERVING,
+		"redis":    healthpb.HealthCheckResponse_NOT_SERVING,
+	}
+	if len(resp.GetStatuses()) != len(want) {
+		t.Errorf("List() returned %d services, want %d", len(resp.GetStatuses()), len(want))
+	}
+	for service, s := range want {
+		if got := resp.GetStatuses()[service].GetStatus(); got != s {
+			t.Errorf("List()[%q] = %v, want %v", service, got, s)
+		}
+	}
+}
+
+func TestGRPCServer_Watch(t *testing.T) {
+	t.Parallel()
+
+	tt := newGRPCTest(t)
+	ctx := context.Background()
+	statuses := tt.watch(t, "")
+
+	if s := next(t, statuses); s != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("initial status = %v, want SERVING", s)
+	}
+
+	// A new report with the same status sends nothing.
+	tt.redisError.Store(true)
+	tt.manager.Check(ctx)
+
+	tt.postgresError.Store(true)
+	tt.manager.Check(ctx)
+	if s := next(t, statuses); s != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("status after failure = %v, want NOT_SERVING", s)
+	}
+
+	tt.postgresError.Store(false)
+	tt.manager.Check(ctx)
+	if s := next(t, statuses); s != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("status after recovery = %v, want SERVING", s)
+	}
+}
+
+func TestGRPCServer_WatchUnknown(t *testing.T) {
+	t.Parallel()
+
+	tt := newGRPCTest(t)
+	statuses := tt.watch(t, "kafka")
+
+	if s := next(t, statuses); s != healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		t.Errorf("status = %v, want SERVICE_UNKNOWN", s)
+	}
+}
+
+func TestGRPCServer_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	tt := newGRPCTest(t)
+	statuses := tt.watch(t, "postgres")
+
+	if s := next(t, statuses); s != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("initial status = %v, want SERVING", s)
+	}
+
+	tt.server.Shutdown()
+	tt.server.Shutdown()
+
+	if s := next(t, statuses); s != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status after Shutdown = %v, want NOT_SERVING", s)
+	}
+	resp, err := tt.client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Check() after Shutdown = %v, want NOT_SERVING", resp.GetStatus())
+	}
+}
@@ -5,6 +5,20 @@ import (
 	"net/http"
 )
 
+// Health endpoint paths registered by Handler.
+const (
+	PathLive    = "/health/live"
+	PathReady   = "/health/ready"
+	PathStartup = "/health/startup"
+	PathFull    = "/health"
+)
+
+// Paths returns the paths of all health endpoints, e.g. to exclude them from
+// tracing and request metrics.
+func Paths() []string {
+	return []string{PathLive, PathReady, PathStartup, PathFull}
+}
+
 // Handler provides HTTP handlers for health check endpoints.
 type Handler struct {
 	manager *Manager
@@ -79,18 +93,18 @@ func (h *Handler) writeJSON(w http.ResponseWriter, statusCode int, data any) {
 
 // RegisterRoutes registers health check routes on an http.ServeMux.
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /health/live", h.LiveHandler)
-	mux.HandleFunc("GET /health/ready", h.ReadyHandler)
-	mux.HandleFunc("GET /health/startup", h.StartupHandler)
-	mux.HandleFunc("GET /health", h.FullHandler)
+	mux.HandleFunc("GET "+PathLive, h.LiveHandler)
+	mux.HandleFunc("GET "+PathReady, h.ReadyHandler)
+	mux.HandleFunc("GET "+PathStartup, h.StartupHandler)
+	mux.HandleFunc("GET "+PathFull, h.FullHandler)
 }
 
 // Routes returns a map of routes to handlers for custom routers.
 func (h *Handler) Routes() map[string]http.HandlerFunc {
 	return map[string]http.HandlerFunc{
-		"/health/live":    h.LiveHandler,
-		"/health/ready":   h.ReadyHandler,
-		"/health/startup": h.StartupHandler,
-		"/health":         h.FullHandler,
+		PathLive:    h.LiveHandler,
+		PathReady:   h.ReadyHandler,
+		PathStartup: h.StartupHandler,
+		PathFull:    h.FullHandler,
 	}
 }
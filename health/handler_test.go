@@ -243,3 +243,20 @@ func TestHandler_ReadyHandler_Degraded(t *testing.T) {
 		t.Errorf("Status = %v, want degraded", response.Status)
 	}
 }
+
+func TestPaths(t *testing.T) {
+	t.Parallel()
+
+	handler := NewHandler(NewManager(DefaultManagerConfig()))
+	routes := handler.Routes()
+
+	paths := Paths()
+	if len(paths) != len(routes) {
+		t.Fatalf("Paths() has %d entries, Routes() has %d", len(paths), len(routes))
+	}
+	for _, p := range paths {
+		if _, ok := routes[p]; !ok {
+			t.Errorf("Paths() entry %s is not a registered route", p)
+		}
+	}
+}
@@ -9,16 +9,17 @@ import (
 
 // ManagerConfig holds configuration for the health check manager.
 type ManagerConfig struct {
-	// Timeout is the default timeout for health checks.
+	// Timeout is the default timeout for health checks; see WithCheckTimeout.
 	Timeout time.Duration
 
 	// CacheTTL is how long to cache health check results.
 	CacheTTL time.Duration
 
-	// BackgroundInterval is how often to run background checks.
+	// BackgroundInterval is how often to run background checks; see WithCheckInterval.
 	BackgroundInterval time.Duration
 
-	// FailureThreshold is how many consecutive failures before marking unhealthy.
+	// FailureThreshold is how many consecutive failures before marking unhealthy;
+	// see WithCheckFailureThreshold.
 	FailureThreshold int
 
 	// Logger is the logger to use for health check events.
@@ -66,21 +67,108 @@ func (c ManagerConfig) WithLogger(logger *slog.Logger) ManagerConfig {
 	return c
 }
 
-// Manager manages health checks for multiple components.
+// CheckerOption configures how the Manager runs one checker.
+type CheckerOption func(*checkerConfig)
+
+// checkerConfig holds the per-checker settings. Zero values fall back to
+// the ManagerConfig settings.
+type checkerConfig struct {
+	timeout          time.Duration
+	interval         time.Duration
+	failureThreshold int
+	initialDelay     time.Duration
+	required         *bool
+}
+
+// WithCheckTimeout sets the timeout of each run of the checker, instead of
+// ManagerConfig.Timeout.
+func WithCheckTimeout(timeout time.Duration) CheckerOption {
+	return func(c *checkerConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithCheckInterval sets how often the checker runs in the background,
+// instead of ManagerConfig.BackgroundInterval. Its result is also reused by
+// Check until the interval has passed, instead of for ManagerConfig.CacheTTL.
+func WithCheckInterval(interval time.Duration) CheckerOption {
+	return func(c *checkerConfig) {
+		c.interval = interval
+	}
+}
+
+// WithCheckFailureThreshold sets how many consecutive failures mark the
+// checker unhealthy, instead of ManagerConfig.FailureThreshold.
+func WithCheckFailureThreshold(threshold int) CheckerOption {
+	return func(c *checkerConfig) {
+		c.failureThreshold = threshold
+	}
+}
+
+// WithCheckInitialDelay delays the first run of the checker after
+// registration, e.g. while a dependency warms up. Until then, the checker
+// is reported as unhealthy with a pending error, so a required checker
+// keeps the service not ready.
+func WithCheckInitialDelay(delay time.Duration) CheckerOption {
+	return func(c *checkerConfig) {
+		c.initialDelay = delay
+	}
+}
+
+// WithCheckRequired overrides Checker.Required: an unhealthy required
+// checker makes the service unhealthy, an optional one degraded.
+func WithCheckRequired(required bool) CheckerOption {
+	return func(c *checkerConfig) {
+		c.required = &required
+	}
+}
+
+// registration is a registered checker and its latest state. The state is
+// guarded by Manager.mu.
+type registration struct {
+	checker    Checker
+	config     checkerConfig
+	required   bool
+	registered time.Time
+
+	// removed is closed when the checker is replaced.
+	removed chan struct{}
+
+	// result is the latest result after applying the failure threshold.
+	result   Result
+	lastRun  time.Time
+	failures int
+
+	// running is closed when the run in flight completes; nil when idle.
+	running chan struct{}
+}
+
+// Manager manages health checks for multiple components. Each checker runs
+// with its own timeout, interval and failure threshold; see CheckerOption.
 type Manager struct {
-	config         ManagerConfig
-	checkers       []Checker
-	requiredChecks map[string]bool
+	config ManagerConfig
 
 	mu            sync.RWMutex
+	registrations []*registration
 	cachedReport  *Report
 	cacheTime     time.Time
-	failureCounts map[string]int
 
-	backgroundMu      sync.Mutex
-	backgroundRunning bool
-	stopCh            chan struct{}
-	doneCh            chan struct{}
+	// pendingUntil is when the first checker still in its initial delay
+	// becomes due; the cached report expires then. Zero when none is pending.
+	pendingUntil time.Time
+
+	// updated is closed and replaced each time a new report is cached.
+	updated chan struct{}
+
+	backgroundMu sync.Mutex
+	background   *background
+}
+
+// background holds the state of running background checks.
+type background struct {
+	ctx  context.Context
+	stop chan struct{}
+	wg   sync.WaitGroup
 }
 
 // NewManager creates a new health check manager.
@@ -89,27 +177,58 @@ func NewManager(config ManagerConfig) *Manager {
 		config.Logger = slog.Default()
 	}
 	return &Manager{
-		config:         config,
-		checkers:       make([]Checker, 0),
-		requiredChecks: make(map[string]bool),
-		failureCounts:  make(map[string]int),
+		config:  config,
+		updated: make(chan struct{}),
 	}
 }
 
-// Register registers a health checker.
-func (m *Manager) Register(checker Checker) {
+// Register registers a health checker. Registering a checker with the name
+// of a registered one replaces it, dropping its results and stopping its
+// background schedule. Options override the ManagerConfig settings for
+// this checker.
+func (m *Manager) Register(checker Checker, opts ...CheckerOption) {
+	r := &registration{
+		checker:    checker,
+		required:   checker.Required(),
+		registered: time.Now(),
+		removed:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&r.config)
+	}
+	if r.config.required != nil {
+		r.required = *r.config.required
+	}
+
+	m.backgroundMu.Lock()
+	defer m.backgroundMu.Unlock()
+
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	replaced := false
+	for i, existing := range m.registrations {
+		if existing.checker.Name() == checker.Name() {
+			close(existing.removed)
+			m.registrations[i] = r
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		m.registrations = append(m.registrations, r)
+	}
+	m.mu.Unlock()
 
-	m.checkers = append(m.checkers, checker)
-	m.requiredChecks[checker.Name()] = checker.Required()
+	if m.background != nil {
+		m.startSchedule(m.background, r)
+	}
 }
 
 // Check performs all health checks and returns a report.
 func (m *Manager) Check(ctx context.Context) Report {
 	// Check if we have a valid cached report.
 	m.mu.RLock()
-	if m.cachedReport != nil && time.Since(m.cacheTime) < m.config.CacheTTL {
+	if m.cachedReport != nil && time.Since(m.cacheTime) < m.config.CacheTTL &&
+		(m.pendingUntil.IsZero() || time.Now().Before(m.pendingUntil)) {
 		report := *m.cachedReport
 		m.mu.RUnlock()
 		return report
@@ -120,130 +239,230 @@ func (m *Manager) Check(ctx context.Context) Report {
 	return m.runChecks(ctx)
 }
 
-// runChecks performs all health checks.
+// runChecks runs the checkers whose result is older than their interval
+// (or CacheTTL) and returns the updated report. Checkers still in their
+// initial delay are skipped.
 func (m *Manager) runChecks(ctx context.Context) Report {
+	now := time.Now()
+
 	m.mu.RLock()
-	checkers := make([]Checker, len(m.checkers))
-	copy(checkers, m.checkers)
-	requiredChecks := make(map[string]bool)
-	for k, v := range m.requiredChecks {
-		requiredChecks[k] = v
+	due := make([]*registration, 0, len(m.registrations))
+	for _, r := range m.registrations {
+		if now.Before(r.registered.Add(r.config.initialDelay)) {
+			continue
+		}
+		if r.lastRun.IsZero() || now.Sub(r.lastRun) >= m.freshness(r) {
+			due = append(due, r)
+		}
 	}
 	m.mu.RUnlock()
 
-	results := make(map[string]Result)
 	var wg sync.WaitGroup
-
-	resultCh := make(chan struct {
-		name   string
-		result Result
-	}, len(checkers))
-
-	for _, checker := range checkers {
+	for _, r := range due {
 		wg.Add(1)
-		go func(c Checker) {
+		go func(r *registration) {
 			defer wg.Done()
+			m.runCheck(ctx, r)
+		}(r)
+	}
+	wg.Wait()
 
-			// Create a timeout context for this check.
-			checkCtx, cancel := context.WithTimeout(ctx, m.config.Timeout)
-			defer cancel()
-
-			result := c.Check(checkCtx)
+	return m.updateReport()
+}
 
-			// Log failures.
-			if result.Status != StatusHealthy {
-				m.config.Logger.Warn("health check failed",
-					"component", c.Name(),
-					"status", result.Status,
-					"error", result.Error,
-					"duration_ms", result.DurationMS,
-				)
-			}
+// runCheck runs one checker with its timeout and records the result. When
+// a run of the checker is already in flight, it waits for that run instead.
+func (m *Manager) runCheck(ctx context.Context, r *registration) {
+	m.mu.Lock()
+	if running := r.running; running != nil {
+		m.mu.Unlock()
+		select {
+		case <-running:
+		case <-ctx.Done():
+		}
+		return
+	}
+	running := make(chan struct{})
+	r.running = running
+	m.mu.Unlock()
 
-			resultCh <- struct {
-				name   string
-				result Result
-			}{
-				name:   c.Name(),
-				result: result,
-			}
-		}(checker)
+	// Create a timeout context for this check.
+	checkCtx, cancel := context.WithTimeout(ctx, m.timeout(r))
+	result := r.checker.Check(checkCtx)
+	cancel()
+
+	// Log failures.
+	if result.Status != StatusHealthy {
+		m.config.Logger.Warn("health check failed",
+			"component", r.checker.Name(),
+			"status", result.Status,
+			"error", result.Error,
+			"duration_ms", result.DurationMS,
+		)
 	}
 
-	// Wait for all checks to complete.
-	go func() {
-		wg.Wait()
-		close(resultCh)
-	}()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	// Collect results and apply failure threshold.
-	for r := range resultCh {
-		// Update failure counts first.
-		m.mu.Lock()
-		if r.result.Status != StatusHealthy {
-			m.failureCounts[r.name]++
-		} else {
-			m.failureCounts[r.name] = 0
+	if result.Status != StatusHealthy {
+		r.failures++
+	} else {
+		r.failures = 0
+	}
+
+	// Apply failure threshold: only report as unhealthy if consecutive
+	// failures have reached the threshold.
+	effectiveResult := result
+	if result.Status == StatusUnhealthy && r.failures < m.failureThreshold(r) {
+		// Not enough consecutive failures yet, report as healthy.
+		effectiveResult = Result{
+			Status:     StatusHealthy,
+			DurationMS: result.DurationMS,
+			Timestamp:  result.Timestamp,
+			Details:    result.Details,
 		}
-		failureCount := m.failureCounts[r.name]
-		m.mu.Unlock()
+	}
 
-		// Apply failure threshold: only report as unhealthy if consecutive
-		// failures have reached the threshold.
-		effectiveResult := r.result
-		if r.result.Status == StatusUnhealthy && failureCount < m.config.FailureThreshold {
-			// Not enough consecutive failures yet, report as healthy.
-			effectiveResult = Result{
-				Status:     StatusHealthy,
-				DurationMS: r.result.DurationMS,
-				Timestamp:  r.result.Timestamp,
-				Details:    r.result.Details,
+	r.result = effectiveResult
+	r.lastRun = time.Now()
+	r.running = nil
+	close(running)
+}
+
+// updateReport builds a report from the latest results, caches it and
+// notifies watchers. Checkers that have not run yet are reported as
+// unhealthy, so the service is not ready before its required checkers
+// have passed once.
+func (m *Manager) updateReport() Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var pendingUntil time.Time
+	results := make(map[string]Result, len(m.registrations))
+	requiredChecks := make(map[string]bool, len(m.registrations))
+	for _, r := range m.registrations {
+		result := r.result
+		if r.lastRun.IsZero() {
+			result = Result{Status: StatusUnhealthy, Error: "pending initial check", Timestamp: now}
+			if due := r.registered.Add(r.config.initialDelay); pendingUntil.IsZero() || due.Before(pendingUntil) {
+				pendingUntil = due
 			}
 		}
-
-		results[r.name] = effectiveResult
+		results[r.checker.Name()] = result
+		requiredChecks[r.checker.Name()] = r.required
 	}
-
 	report := NewReport(results, requiredChecks)
 
 	// Cache the report.
-	m.mu.Lock()
 	m.cachedReport = &report
-	m.cacheTime = time.Now()
-	m.mu.Unlock()
+	m.cacheTime = now
+	m.pendingUntil = pendingUntil
+	close(m.updated)
+	m.updated = make(chan struct{})
 
 	return report
 }
 
-// StartBackground starts background health checks.
+// timeout returns the run timeout of a checker.
+func (m *Manager) timeout(r *registration) time.Duration {
+	if r.config.timeout > 0 {
+		return r.config.timeout
+	}
+	return m.config.Timeout
+}
+
+// interval returns the background interval of a checker.
+func (m *Manager) interval(r *registration) time.Duration {
+	if r.config.interval > 0 {
+		return r.config.interval
+	}
+	if m.config.BackgroundInterval > 0 {
+		return m.config.BackgroundInterval
+	}
+	return DefaultManagerConfig().BackgroundInterval
+}
+
+// freshness returns how long Check reuses the result of a checker.
+func (m *Manager) freshness(r *registration) time.Duration {
+	if r.config.interval > 0 {
+		return r.config.interval
+	}
+	return m.config.CacheTTL
+}
+
+// failureThreshold returns the consecutive failures that mark a checker unhealthy.
+func (m *Manager) failureThreshold(r *registration) int {
+	if r.config.failureThreshold > 0 {
+		return r.config.failureThreshold
+	}
+	return m.config.FailureThreshold
+}
+
+// reportUpdated returns a channel that is closed when the next report is cached.
+func (m *Manager) reportUpdated() <-chan struct{} {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.updated
+}
+
+// latestReport returns the last report, even if its cache TTL has expired.
+func (m *Manager) latestReport() (Report, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.cachedReport == nil {
+		return Report{}, false
+	}
+	return *m.cachedReport, true
+}
+
+// StartBackground starts background health checks. Each checker runs after
+// its initial delay and then at its own interval, and every run updates the
+// cached report.
 func (m *Manager) StartBackground(ctx context.Context) {
 	m.backgroundMu.Lock()
-	if m.backgroundRunning {
-		m.backgroundMu.Unlock()
+	defer m.backgroundMu.Unlock()
+	if m.background != nil {
 		return
 	}
-	m.backgroundRunning = true
-	m.stopCh = make(chan struct{})
-	m.doneCh = make(chan struct{})
-	m.backgroundMu.Unlock()
 
+	bg := &background{ctx: ctx, stop: make(chan struct{})}
+	m.background = bg
+
+	m.mu.RLock()
+	registrations := make([]*registration, len(m.registrations))
+	copy(registrations, m.registrations)
+	m.mu.RUnlock()
+
+	for _, r := range registrations {
+		m.startSchedule(bg, r)
+	}
+}
+
+// startSchedule runs a checker in the background until the background
+// checks stop or the checker is replaced. The caller must hold backgroundMu.
+func (m *Manager) startSchedule(bg *background, r *registration) {
+	bg.wg.Add(1)
 	go func() {
-		ticker := time.NewTicker(m.config.BackgroundInterval)
-		defer ticker.Stop()
-		defer close(m.doneCh)
+		defer bg.wg.Done()
 
-		// Run initial check.
-		m.runChecks(ctx)
+		timer := time.NewTimer(time.Until(r.registered.Add(r.config.initialDelay)))
+		defer timer.Stop()
 
 		for {
 			select {
-			case <-ticker.C:
-				m.runChecks(ctx)
-			case <-m.stopCh:
+			case <-timer.C:
+			case <-bg.stop:
+				return
+			case <-bg.ctx.Done():
 				return
-			case <-ctx.Done():
+			case <-r.removed:
 				return
 			}
+
+			m.runCheck(bg.ctx, r)
+			m.updateReport()
+			timer.Reset(m.interval(r))
 		}
 	}()
 }
@@ -251,22 +470,27 @@ func (m *Manager) StartBackground(ctx context.Context) {
 // StopBackground stops background health checks.
 func (m *Manager) StopBackground() {
 	m.backgroundMu.Lock()
-	if !m.backgroundRunning {
-		m.backgroundMu.Unlock()
-		return
-	}
-	m.backgroundRunning = false
+	bg := m.background
+	m.background = nil
 	m.backgroundMu.Unlock()
 
-	close(m.stopCh)
-	<-m.doneCh
+	if bg == nil {
+		return
+	}
+	close(bg.stop)
+	bg.wg.Wait()
 }
 
 // GetFailureCount returns the consecutive failure count for a component.
 func (m *Manager) GetFailureCount(name string) int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.failureCounts[name]
+	for _, r := range m.registrations {
+		if r.checker.Name() == name {
+			return r.failures
+		}
+	}
+	return 0
 }
 
 // IsReady returns true if the service is ready to accept traffic.
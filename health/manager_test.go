@@ -3,6 +3,7 @@ package health
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -277,3 +278,198 @@ func TestManager_BackgroundChecks(t *testing.T) {
 		t.Errorf("Call count = %d, want at least 3", callCount)
 	}
 }
+
+func TestManager_CheckTimeout(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(DefaultManagerConfig().WithTimeout(time.Minute).WithFailureThreshold(1))
+	manager.Register(NewFuncChecker("kafka", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, true), WithCheckTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	report := manager.Check(context.Background())
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Check() took %v, want the checker timeout", elapsed)
+	}
+	if report.Checks["kafka"].Status != StatusUnhealthy {
+		t.Errorf("kafka status = %v, want %v", report.Checks["kafka"].Status, StatusUnhealthy)
+	}
+}
+
+func TestManager_CheckFailureThreshold(t *testing.T) {
+	t.Parallel()
+
+	failing := func(ctx context.Context) error { return errors.New("failed") }
+	manager := NewManager(DefaultManagerConfig().WithCacheTTL(0).WithFailureThreshold(3))
+	manager.Register(NewFuncChecker("redis", failing, true), WithCheckFailureThreshold(1))
+	manager.Register(NewFuncChecker("postgres", failing, true))
+
+	report := manager.Check(context.Background())
+
+	if report.Checks["redis"].Status != StatusUnhealthy {
+		t.Errorf("redis status = %v, want %v", report.Checks["redis"].Status, StatusUnhealthy)
+	}
+	if report.Checks["postgres"].Status != StatusHealthy {
+		t.Errorf("postgres status = %v, want %v below the manager threshold", report.Checks["postgres"].Status, StatusHealthy)
+	}
+}
+
+func TestManager_CheckInterval(t *testing.T) {
+	t.Parallel()
+
+	var slowCalls, fastCalls atomic.Int32
+	manager := NewManager(DefaultManagerConfig().WithCacheTTL(0))
+	manager.Register(NewFuncChecker("kafka", func(ctx context.Context) error {
+		slowCalls.Add(1)
+		return nil
+	}, true), WithCheckInterval(time.Hour))
+	manager.Register(NewFuncChecker("redis", func(ctx context.Context) error {
+		fastCalls.Add(1)
+		return nil
+	}, true))
+
+	manager.Check(context.Background())
+	report := manager.Check(context.Background())
+
+	// The kafka result is reused until its interval has passed.
+	if n := slowCalls.Load(); n != 1 {
+		t.Errorf("kafka calls = %d, want 1", n)
+	}
+	if n := fastCalls.Load(); n != 2 {
+		t.Errorf("redis calls = %d, want 2", n)
+	}
+	if len(report.Checks) != 2 {
+		t.Errorf("Checks count = %d, want 2", len(report.Checks))
+	}
+}
+
+func TestManager_CheckInitialDelay(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	manager := NewManager(DefaultManagerConfig().WithCacheTTL(0))
+	manager.Register(NewFuncChecker("kafka", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}, true), WithCheckInitialDelay(100*time.Millisecond))
+
+	report := manager.Check(context.Background())
+	if got := report.Checks["kafka"].Status; got != StatusUnhealthy {
+		t.Errorf("kafka status during initial delay = %v, want %v", got, StatusUnhealthy)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("calls during initial delay = %d, want 0", n)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	report = manager.Check(context.Background())
+	if got := report.Checks["kafka"].Status; got != StatusHealthy {
+		t.Errorf("kafka status after initial delay = %v, want %v", got, StatusHealthy)
+	}
+}
+
+func TestManager_IsReadyDuringInitialDelay(t *testing.T) {
+	t.Parallel()
+
+	// The cache TTL outlasts the delay: the pending report must still expire.
+	manager := NewManager(DefaultManagerConfig())
+	manager.Register(NewFuncChecker("kafka", func(ctx context.Context) error {
+		return nil
+	}, true), WithCheckInitialDelay(100*time.Millisecond))
+
+	if manager.IsReady(context.Background()) {
+		t.Error("IsReady() = true before the required checker has run")
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	if !manager.IsReady(context.Background()) {
+		t.Error("IsReady() = false after the required checker has passed")
+	}
+}
+
+func TestManager_CheckRequired(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(DefaultManagerConfig().WithFailureThreshold(1))
+	manager.Register(NewFuncChecker("cache", func(ctx context.Context) error {
+		return errors.New("failed")
+	}, true), WithCheckRequired(false))
+
+	report := manager.Check(context.Background())
+
+	if report.Status != StatusDegraded {
+		t.Errorf("Status = %v, want %v for an optional checker", report.Status, StatusDegraded)
+	}
+}
+
+func TestManager_RegisterReplaces(t *testing.T) {
+	t.Parallel()
+
+	manager := NewManager(DefaultManagerConfig().WithFailureThreshold(1))
+	manager.Register(NewFuncChecker("postgres", func(ctx context.Context) error {
+		return nil
+	}, true))
+	manager.Register(NewFuncChecker("postgres", func(ctx context.Context) error {
+		return errors.New("failed")
+	}, true))
+
+	report := manager.Check(context.Background())
+
+	if len(report.Checks) != 1 {
+		t.Errorf("Checks count = %d, want 1", len(report.Checks))
+	}
+	if report.Checks["postgres"].Status != StatusUnhealthy {
+		t.Errorf("postgres status = %v, want the replacing checker's %v", report.Checks["postgres"].Status, StatusUnhealthy)
+	}
+}
+
+func TestManager_BackgroundCheckIntervals(t *testing.T) {
+	t.Parallel()
+
+	var fastCalls, slowCalls, lateCalls atomic.Int32
+	manager := NewManager(DefaultManagerConfig().WithBackgroundInterval(time.Hour))
+	manager.Register(NewFuncChecker("redis", func(ctx context.Context) error {
+		fastCalls.Add(1)
+		return nil
+	}, true), WithCheckInterval(20*time.Millisecond))
+	manager.Register(NewFuncChecker("kafka", func(ctx context.Context) error {
+		slowCalls.Add(1)
+		return nil
+	}, true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	manager.StartBackground(ctx)
+
+	// Checkers registered while running are scheduled too.
+	manager.Register(NewFuncChecker("postgres", func(ctx context.Context) error {
+		lateCalls.Add(1)
+		return nil
+	}, true), WithCheckInterval(20*time.Millisecond))
+
+	time.Sleep(150 * time.Millisecond)
+	manager.StopBackground()
+
+	if n := fastCalls.Load(); n < 4 {
+		t.Errorf("redis calls = %d, want at least 4", n)
+	}
+	if n := slowCalls.Load(); n != 1 {
+		t.Errorf("kafka calls = %d, want 1", n)
+	}
+	if n := lateCalls.Load(); n < 4 {
+		t.Errorf("postgres calls = %d, want at least 4", n)
+	}
+
+	// Stopped checkers no longer run.
+	stopped := fastCalls.Load()
+	time.Sleep(50 * time.Millisecond)
+	if n := fastCalls.Load(); n != stopped {
+		t.Errorf("redis calls after StopBackground = %d, want %d", n, stopped)
+	}
+}
@@ -0,0 +1,50 @@
+// Package slogattr flattens slog attributes for the log sinks that do not
+// support nesting, so span events and OpenTelemetry log records share the
+// same keys and value conversions.
+package slogattr
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+)
+
+// Prefix returns the key prefix for attributes in the given groups.
+func Prefix(groups []string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	return strings.Join(groups, ".") + "."
+}
+
+// Walk resolves a, flattens groups into dot-separated keys under prefix and
+// calls emit for each resulting attribute. The value passed to emit is of
+// kind String, Int64, Float64 or Bool: unsigned integers become Int64
+// (saturating at MaxInt64), durations, times and other values become
+// strings.
+func Walk(prefix string, a slog.Attr, emit func(key string, v slog.Value)) {
+	v := a.Value.Resolve()
+	key := prefix + a.Key
+
+	switch v.Kind() {
+	case slog.KindGroup:
+		if a.Key != "" {
+			prefix = key + "."
+		}
+		for _, ga := range v.Group() {
+			Walk(prefix, ga, emit)
+		}
+	case slog.KindString, slog.KindInt64, slog.KindFloat64, slog.KindBool:
+		emit(key, v)
+	case slog.KindUint64:
+		emit(key, slog.Int64Value(int64(min(v.Uint64(), math.MaxInt64)))) //nolint:gosec // clamped to MaxInt64
+	case slog.KindDuration:
+		emit(key, slog.StringValue(v.Duration().String()))
+	case slog.KindTime:
+		emit(key, slog.StringValue(v.Time().Format(time.RFC3339Nano)))
+	default:
+		emit(key, slog.StringValue(fmt.Sprint(v.Any())))
+	}
+}
@@ -0,0 +1,59 @@
+package slogattr
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+)
+
+// logValuer resolves to a group, like a struct implementing slog.LogValuer.
+type logValuer struct{}
+
+func (logValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("id", "ride-1"))
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want []string
+	}{
+		{"string", slog.String("method", "GET"), []string{"http.method=String:GET"}},
+		{"int", slog.Int("status", 200), []string{"http.status=Int64:200"}},
+		{"uint saturates", slog.Uint64("big", math.MaxUint64), []string{fmt.Sprintf("http.big=Int64:%d", int64(math.MaxInt64))}},
+		{"float", slog.Float64("ratio", 0.5), []string{"http.ratio=Float64:0.5"}},
+		{"bool", slog.Bool("ok", true), []string{"http.ok=Bool:true"}},
+		{"duration", slog.Duration("took", 1500*time.Millisecond), []string{"http.took=String:1.5s"}},
+		{"time", slog.Time("at", at), []string{"http.at=String:2026-01-02T03:04:05Z"}},
+		{"any", slog.Any("tags", []string{"a"}), []string{"http.tags=String:[a]"}},
+		{"group", slog.Group("req", slog.String("id", "1"), slog.Group("", slog.Int("n", 2))), []string{"http.req.id=String:1", "http.req.n=Int64:2"}},
+		{"log valuer", slog.Any("ride", logValuer{}), []string{"http.ride.id=String:ride-1"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		Walk(Prefix([]string{"http"}), tt.attr, func(key string, v slog.Value) {
+			got = append(got, fmt.Sprintf("%s=%s:%v", key, v.Kind(), v.Any()))
+		})
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: Walk() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	t.Parallel()
+
+	if got := Prefix(nil); got != "" {
+		t.Errorf("Prefix(nil) = %q, want empty", got)
+	}
+	if got := Prefix([]string{"http", "response"}); got != "http.response." {
+		t.Errorf("Prefix() = %q, want http.response.", got)
+	}
+}
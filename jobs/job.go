@@ -0,0 +1,218 @@
+// Package jobs instruments scheduled and batch jobs with a root span per run,
+// run metrics, overlap prevention and a staleness health check.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/health"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// AttrJobName is the span attribute holding the job name.
+const AttrJobName = "job.name"
+
+// ErrAlreadyRunning is returned by Run when the previous run has not finished.
+var ErrAlreadyRunning = errors.New("job already running")
+
+// Func is the work performed by a job run.
+type Func func(ctx context.Context) error
+
+// Config holds configuration for a job.
+type Config struct {
+	// Tracer starts a root span per run. If nil, runs are not traced.
+	Tracer *tracing.Tracer
+
+	// Metrics records job_runs_total, job_duration_seconds and
+	// job_last_success_timestamp_seconds. If nil, runs are not recorded.
+	Metrics *metrics.JobCollector
+
+	// Interval is how often the job is expected to succeed. The health check
+	// turns degraded when no run has succeeded for longer than Interval.
+	// Zero disables the staleness check.
+	Interval time.Duration
+
+	// Timeout bounds each run. Zero means no timeout.
+	Timeout time.Duration
+
+	// Logger logs failed and skipped runs. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// Job wraps a scheduled or batch job with tracing, metrics and a health check.
+// It is safe for concurrent use; overlapping runs are skipped.
+type Job struct {
+	name    string
+	fn      Func
+	config  Config
+	created time.Time
+	running atomic.Bool
+
+	mu          sync.RWMutex
+	lastRun     time.Time
+	lastSuccess time.Time
+	lastErr     error
+}
+
+// New creates a job named name running fn.
+func New(name string, fn Func, cfg Config) (*Job, error) { //nolint:gocritic // cfg passed by value for API simplicity
+	if name == "" {
+		return nil, fmt.Errorf("job name is required")
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("job %q: function is required", name)
+	}
+	if cfg.Interval < 0 || cfg.Timeout < 0 {
+		return nil, fmt.Errorf("job %q: interval and timeout must not be negative", name)
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+
+	return &Job{
+		name:    name,
+		fn:      fn,
+		config:  cfg,
+		created: time.Now(),
+	}, nil
+}
+
+// Name returns the job name.
+func (j *Job) Name() string {
+	return j.name
+}
+
+// Run performs one run of the job in a new trace. It returns
+// ErrAlreadyRunning without running fn if the previous run is in progress.
+func (j *Job) Run(ctx context.Context) error {
+	if !j.running.CompareAndSwap(false, true) {
+		if j.config.Metrics != nil {
+			j.config.Metrics.RecordSkipped(j.name)
+		}
+		j.config.Logger.WarnContext(ctx, "job run skipped, previous run still in progress", "job", j.name)
+		return ErrAlreadyRunning
+	}
+	defer j.running.Store(false)
+
+	if j.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.config.Timeout)
+		defer cancel()
+	}
+
+	if j.config.Tracer != nil {
+		var span trace.Span
+		ctx, span = j.config.Tracer.Start(ctx, "job "+j.name,
+			trace.WithNewRoot(),
+			trace.WithAttributes(attribute.String(AttrJobName, j.name)),
+		)
+		defer span.End()
+	}
+
+	start := time.Now()
+	err := j.fn(ctx)
+	j.finish(ctx, start, err)
+	return err
+}
+
+// finish records the outcome of a run.
+func (j *Job) finish(ctx context.Context, start time.Time, err error) {
+	end := time.Now()
+	status := metrics.JobStatusSuccess
+	if err != nil {
+		status = metrics.JobStatusFailure
+		if j.config.Tracer != nil {
+			j.config.Tracer.RecordError(ctx, err)
+		}
+		j.config.Logger.ErrorContext(ctx, "job run failed", "job", j.name, "error", err)
+	}
+
+	j.mu.Lock()
+	j.lastRun = end
+	j.lastErr = err
+	if err == nil {
+		j.lastSuccess = end
+	}
+	j.mu.Unlock()
+
+	if j.config.Metrics != nil {
+		j.config.Metrics.RecordRun(j.name, status, end.Sub(start))
+		if err == nil {
+			j.config.Metrics.SetLastSuccess(j.name, end)
+		}
+	}
+}
+
+// LastSuccess returns when the job last succeeded, or the zero time.
+func (j *Job) LastSuccess() time.Time {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.lastSuccess
+}
+
+// Checker returns a health checker named "job:<name>" that is degraded when
+// the job has not succeeded within its interval. Before the first success the
+// interval is measured from when the job was created.
+func (j *Job) Checker() health.Checker {
+	return &checker{job: j}
+}
+
+// checker reports job staleness.
+type checker struct {
+	job *Job
+}
+
+// Name returns the name of the checker.
+func (c *checker) Name() string {
+	return "job:" + c.job.name
+}
+
+// Check performs the health check.
+func (c *checker) Check(_ context.Context) health.Result {
+	start := time.Now()
+	j := c.job
+
+	j.mu.RLock()
+	lastRun, lastSuccess, lastErr := j.lastRun, j.lastSuccess, j.lastErr
+	j.mu.RUnlock()
+
+	details := map[string]any{
+		"running":  j.running.Load(),
+		"interval": j.config.Interval.String(),
+	}
+	if !lastRun.IsZero() {
+		details["last_run"] = lastRun.Format(time.RFC3339)
+	}
+	if !lastSuccess.IsZero() {
+		details["last_success"] = lastSuccess.Format(time.RFC3339)
+	}
+	if lastErr != nil {
+		details["last_error"] = lastErr.Error()
+	}
+
+	since := lastSuccess
+	if since.IsZero() {
+		since = j.created
+	}
+	if j.config.Interval > 0 && start.Sub(since) > j.config.Interval {
+		msg := fmt.Sprintf("no successful run in the last %s", j.config.Interval)
+		return health.NewDegradedResult(time.Since(start), msg).WithDetails(details)
+	}
+	return health.NewHealthyResult(time.Since(start)).WithDetails(details)
+}
+
+// Required returns false: a stale job degrades the service but does not make
+// it unhealthy.
+func (c *checker) Required() bool {
+	return false
+}
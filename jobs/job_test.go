@@ -0,0 +1,289 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/health"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// testConfig returns a job configuration with a recording tracer and a
+// collector on a private registry.
+func testConfig(t *testing.T) (Config, *tracetest.SpanRecorder, *prometheus.Registry) {
+	t.Helper()
+
+	ctx := context.Background()
+	tracer, err := tracing.New(ctx, tracing.Config{
+		ServiceName:              "test-service",
+		Exporter:                 tracing.ExporterNone,
+		SampleRate:               1.0,
+		DisableResourceDetection: true,
+		DisableGlobalPropagator:  true,
+	})
+	if err != nil {
+		t.Fatalf("tracing.New() error = %v", err)
+	}
+	t.Cleanup(func() { tracer.Shutdown(ctx) })
+
+	spans := tracetest.NewSpanRecorder()
+	tracer.Provider().RegisterSpanProcessor(spans)
+
+	registry := prometheus.NewRegistry()
+	collector, err := metrics.NewJobCollector(metrics.DefaultConfig().WithRegistry(registry))
+	if err != nil {
+		t.Fatalf("NewJobCollector() error = %v", err)
+	}
+
+	return Config{
+		Tracer:  tracer,
+		Metrics: collector,
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}, spans, registry
+}
+
+// runs returns the job_runs_total value for the job and status.
+func runs(t *testing.T, registry *prometheus.Registry, job, status string) float64 {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "txova_job_runs_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["job"] == job && labels["status"] == status {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestNew_Invalid(t *testing.T) {
+	t.Parallel()
+
+	noop := func(context.Context) error { return nil }
+	tests := []struct {
+		name    string
+		jobName string
+		fn      Func
+		cfg     Config
+	}{
+		{"missing name", "", noop, Config{}},
+		{"missing func", "payouts", nil, Config{}},
+		{"negative interval", "payouts", noop, Config{Interval: -time.Minute}},
+		{"negative timeout", "payouts", noop, Config{Timeout: -time.Minute}},
+	}
+	for _, tt := range tests {
+		if _, err := New(tt.jobName, tt.fn, tt.cfg); err == nil {
+			t.Errorf("%s: New() should fail", tt.name)
+		}
+	}
+}
+
+func TestJob_Run(t *testing.T) {
+	t.Parallel()
+
+	cfg, spans, registry := testConfig(t)
+	ctx, parent := cfg.Tracer.Start(context.Background(), "scheduler")
+	defer parent.End()
+
+	job, err := New("payouts", func(context.Context) error { return nil }, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := job.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("spans = %d, want 1", len(ended))
+	}
+	span := ended[0]
+	if span.Name() != "job payouts" {
+		t.Errorf("span name = %q, want %q", span.Name(), "job payouts")
+	}
+	if span.Parent().IsValid() {
+		t.Error("job span should be a root span")
+	}
+	if !hasAttribute(span.Attributes(), attribute.String(AttrJobName, "payouts")) {
+		t.Errorf("span attributes = %v, want job.name", span.Attributes())
+	}
+
+	if got := runs(t, registry, "payouts", metrics.JobStatusSuccess); got != 1 {
+		t.Errorf("success runs = %v, want 1", got)
+	}
+	if job.LastSuccess().IsZero() {
+		t.Error("LastSuccess() not set after a successful run")
+	}
+	if n, err := testutil.GatherAndCount(registry, "txova_job_last_success_timestamp_seconds"); err != nil || n != 1 {
+		t.Errorf("last success series = %d (err %v), want 1", n, err)
+	}
+}
+
+func TestJob_RunFailure(t *testing.T) {
+	t.Parallel()
+
+	cfg, spans, registry := testConfig(t)
+	errBankDown := errors.New("bank unavailable")
+
+	job, err := New("payouts", func(context.Context) error { return errBankDown }, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := job.Run(context.Background()); !errors.Is(err, errBankDown) {
+		t.Fatalf("Run() error = %v, want %v", err, errBankDown)
+	}
+
+	if got := runs(t, registry, "payouts", metrics.JobStatusFailure); got != 1 {
+		t.Errorf("failure runs = %v, want 1", got)
+	}
+	if !job.LastSuccess().IsZero() {
+		t.Error("LastSuccess() set after a failed run")
+	}
+	if status := spans.Ended()[0].Status(); status.Code != codes.Error {
+		t.Errorf("span status = %v, want Error", status.Code)
+	}
+}
+
+func TestJob_RunOverlap(t *testing.T) {
+	t.Parallel()
+
+	cfg, _, registry := testConfig(t)
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	job, err := New("reconciliation", func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- job.Run(context.Background()) }()
+	<-started
+
+	if err := job.Run(context.Background()); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("overlapping Run() error = %v, want %v", err, ErrAlreadyRunning)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("first Run() error = %v", err)
+	}
+
+	if got := runs(t, registry, "reconciliation", metrics.JobStatusSkipped); got != 1 {
+		t.Errorf("skipped runs = %v, want 1", got)
+	}
+	if got := runs(t, registry, "reconciliation", metrics.JobStatusSuccess); got != 1 {
+		t.Errorf("success runs = %v, want 1", got)
+	}
+}
+
+func TestJob_RunTimeout(t *testing.T) {
+	t.Parallel()
+
+	job, err := New("cleanup", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, Config{Timeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := job.Run(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestJob_Checker(t *testing.T) {
+	t.Parallel()
+
+	fail := true
+	job, err := New("payouts", func(context.Context) error {
+		if fail {
+			return errors.New("bank unavailable")
+		}
+		return nil
+	}, Config{Interval: time.Hour, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	checker := job.Checker()
+	if checker.Name() != "job:payouts" {
+		t.Errorf("Name() = %q, want job:payouts", checker.Name())
+	}
+	if checker.Required() {
+		t.Error("Required() = true, want false")
+	}
+
+	ctx := context.Background()
+	if result := checker.Check(ctx); result.Status != health.StatusHealthy {
+		t.Errorf("status within the first interval = %s, want healthy", result.Status)
+	}
+
+	job.created = time.Now().Add(-2 * time.Hour)
+	_ = job.Run(ctx)
+	result := checker.Check(ctx)
+	if result.Status != health.StatusDegraded {
+		t.Errorf("status after a missed interval = %s, want degraded", result.Status)
+	}
+	if !strings.Contains(result.Error, "1h0m0s") || result.Details["last_error"] != "bank unavailable" {
+		t.Errorf("result = %+v, want interval message and last error", result)
+	}
+
+	fail = false
+	if err := job.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result := checker.Check(ctx); result.Status != health.StatusHealthy {
+		t.Errorf("status after a success = %s, want healthy", result.Status)
+	}
+}
+
+func TestJob_CheckerWithoutInterval(t *testing.T) {
+	t.Parallel()
+
+	job, err := New("cleanup", func(context.Context) error { return nil }, Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	job.created = time.Now().Add(-24 * time.Hour)
+
+	if result := job.Checker().Check(context.Background()); result.Status != health.StatusHealthy {
+		t.Errorf("status = %s, want healthy when no interval is set", result.Status)
+	}
+}
+
+// hasAttribute reports whether attrs contains want.
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv == want {
+			return true
+		}
+	}
+	return false
+}
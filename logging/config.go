@@ -0,0 +1,153 @@
+// Package logging exports structured logs through OpenTelemetry for the Txova
+// platform. It shares the resource and exporter settings of tracing.Config, so
+// log records carry the same service, deployment and Kubernetes attributes as
+// spans, and bridges log/slog to the OpenTelemetry log pipeline.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// ExporterType defines the log exporter type.
+type ExporterType string
+
+const (
+	// ExporterOTLPHTTP exports logs via OTLP over HTTP.
+	ExporterOTLPHTTP ExporterType = "otlp-http"
+	// ExporterOTLPGRPC exports logs via OTLP over gRPC.
+	ExporterOTLPGRPC ExporterType = "otlp-grpc"
+	// ExporterStdout writes logs as JSON to Config.Writer (for local development).
+	ExporterStdout ExporterType = "stdout"
+	// ExporterNone disables log exporting (for testing).
+	ExporterNone ExporterType = "none"
+)
+
+// DefaultURLPath is the OTLP HTTP path for logs.
+const DefaultURLPath = "/v1/logs"
+
+// BatchConfig tunes the batch log processor. Zero values use the SDK defaults.
+type BatchConfig struct {
+	// MaxQueueSize is the maximum number of records buffered before new records
+	// are dropped. SDK default: 2048.
+	MaxQueueSize int
+
+	// MaxExportBatchSize is the maximum number of records sent in a single export.
+	// Must not exceed MaxQueueSize. SDK default: 512.
+	MaxExportBatchSize int
+
+	// ExportInterval is the maximum delay between two consecutive exports.
+	// SDK default: 1s.
+	ExportInterval time.Duration
+
+	// ExportTimeout is the maximum time the processor waits for an export to finish.
+	// SDK default: 30s.
+	ExportTimeout time.Duration
+}
+
+// validate checks that the batch settings are consistent.
+func (b BatchConfig) validate() error {
+	if b.MaxQueueSize < 0 || b.MaxExportBatchSize < 0 {
+		return fmt.Errorf("batch queue and export batch sizes must not be negative")
+	}
+	if b.ExportInterval < 0 || b.ExportTimeout < 0 {
+		return fmt.Errorf("batch export interval and export timeout must not be negative")
+	}
+	if b.MaxQueueSize > 0 && b.MaxExportBatchSize > b.MaxQueueSize {
+		return fmt.Errorf("max export batch size %d exceeds max queue size %d", b.MaxExportBatchSize, b.MaxQueueSize)
+	}
+	return nil
+}
+
+// Config holds configuration for the log pipeline. Endpoint, headers, TLS,
+// compression, timeout and retry settings are taken from the tracing.Config
+// passed to New.
+type Config struct {
+	// Exporter defines the log exporter type.
+	// Empty uses the exporter of the tracing configuration.
+	Exporter ExporterType
+
+	// URLPath overrides the URL path for the OTLP HTTP exporter.
+	// Empty uses DefaultURLPath under the prefix of the tracing URLPath,
+	// e.g. "/otlp/v1/traces" gives "/otlp/v1/logs".
+	URLPath string
+
+	// Writer receives records for ExporterStdout. If nil, os.Stdout is used.
+	Writer io.Writer
+
+	// Level is the minimum level exported through the slog bridge.
+	// If nil, slog.LevelInfo is used.
+	Level slog.Leveler
+
+	// Batch configures the batch log processor.
+	Batch BatchConfig
+
+	// Resource describes the service. If nil, it is created from the tracing
+	// configuration with tracing.NewResource. Observability passes the
+	// tracer's resource so logs and spans share the same instance ID.
+	Resource *resource.Resource
+}
+
+// DefaultConfig returns a Config with default values.
+func DefaultConfig() Config {
+	return Config{
+		Level: slog.LevelInfo,
+	}
+}
+
+// WithExporter sets the log exporter type.
+func (c *Config) WithExporter(exporter ExporterType) *Config {
+	c.Exporter = exporter
+	return c
+}
+
+// WithURLPath sets the URL path for the OTLP HTTP exporter.
+func (c *Config) WithURLPath(path string) *Config {
+	c.URLPath = path
+	return c
+}
+
+// WithWriter sets the writer for the stdout exporter.
+func (c *Config) WithWriter(w io.Writer) *Config {
+	c.Writer = w
+	return c
+}
+
+// WithLevel sets the minimum level exported through the slog bridge.
+func (c *Config) WithLevel(level slog.Leveler) *Config {
+	c.Level = level
+	return c
+}
+
+// WithBatch sets the batch log processor settings.
+func (c *Config) WithBatch(batch BatchConfig) *Config {
+	c.Batch = batch
+	return c
+}
+
+// WithResource sets the resource attached to log records.
+func (c *Config) WithResource(res *resource.Resource) *Config {
+	c.Resource = res
+	return c
+}
+
+// Validate checks that the configuration is valid.
+func (c *Config) Validate() error {
+	switch c.Exporter {
+	case ExporterOTLPHTTP, ExporterOTLPGRPC, ExporterStdout, ExporterNone:
+		// Valid
+	case "":
+		// Default to the tracing exporter
+	default:
+		return fmt.Errorf("invalid log exporter type: %s", c.Exporter)
+	}
+
+	if err := c.Batch.validate(); err != nil {
+		return fmt.Errorf("invalid log batch config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	if cfg.Exporter != "" {
+		t.Errorf("Exporter = %q, want empty (tracing exporter)", cfg.Exporter)
+	}
+	if cfg.Level == nil || cfg.Level.Level() != slog.LevelInfo {
+		t.Errorf("Level = %v, want INFO", cfg.Level)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestConfig_Setters(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	res := resource.Empty()
+	batch := BatchConfig{MaxQueueSize: 100, ExportInterval: time.Second}
+
+	cfg := DefaultConfig()
+	cfg.WithExporter(ExporterStdout).
+		WithURLPath("/custom/logs").
+		WithWriter(&buf).
+		WithLevel(slog.LevelWarn).
+		WithBatch(batch).
+		WithResource(res)
+
+	if cfg.Exporter != ExporterStdout {
+		t.Errorf("Exporter = %q, want %q", cfg.Exporter, ExporterStdout)
+	}
+	if cfg.URLPath != "/custom/logs" {
+		t.Errorf("URLPath = %q, want /custom/logs", cfg.URLPath)
+	}
+	if cfg.Writer != &buf {
+		t.Error("Writer not set")
+	}
+	if cfg.Level.Level() != slog.LevelWarn {
+		t.Errorf("Level = %v, want WARN", cfg.Level)
+	}
+	if cfg.Batch != batch {
+		t.Errorf("Batch = %+v, want %+v", cfg.Batch, batch)
+	}
+	if cfg.Resource != res {
+		t.Error("Resource not set")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{"empty", Config{}, ""},
+		{"otlp http", Config{Exporter: ExporterOTLPHTTP}, ""},
+		{"otlp grpc", Config{Exporter: ExporterOTLPGRPC}, ""},
+		{"stdout", Config{Exporter: ExporterStdout}, ""},
+		{"none", Config{Exporter: ExporterNone}, ""},
+		{"invalid exporter", Config{Exporter: "kafka"}, "invalid log exporter type"},
+		{"negative queue", Config{Batch: BatchConfig{MaxQueueSize: -1}}, "must not be negative"},
+		{"negative interval", Config{Batch: BatchConfig{ExportInterval: -time.Second}}, "must not be negative"},
+		{"batch exceeds queue", Config{Batch: BatchConfig{MaxQueueSize: 10, MaxExportBatchSize: 20}}, "exceeds max queue size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/log"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/internal/slogattr"
+)
+
+// Handler is a slog.Handler that emits records to an OpenTelemetry logger.
+// The trace and span IDs of the record's context are attached by the SDK, so
+// records should be logged with a context (e.g., slog.InfoContext).
+//
+// Attributes in groups are flattened into dot-separated keys, matching the
+// span events recorded by tracing.LogHandler.
+type Handler struct {
+	logger log.Logger
+	level  slog.Leveler
+	groups []string
+	attrs  []log.KeyValue
+}
+
+// NewHandler creates a handler emitting records at or above level to logger.
+// If level is nil, slog.LevelInfo is used.
+func NewHandler(logger log.Logger, level slog.Leveler) *Handler {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &Handler{logger: logger, level: level}
+}
+
+// Enabled implements slog.Handler.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if level < h.level.Level() {
+		return false
+	}
+	return h.logger.Enabled(ctx, log.EnabledParameters{Severity: severity(level)})
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	var record log.Record
+	record.SetTimestamp(r.Time)
+	record.SetBody(log.StringValue(r.Message))
+	record.SetSeverity(severity(r.Level))
+	record.SetSeverityText(r.Level.String())
+	record.AddAttributes(h.attrs...)
+
+	attrs := make([]log.KeyValue, 0, r.NumAttrs())
+	prefix := slogattr.Prefix(h.groups)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendKeyValues(attrs, prefix, a)
+		return true
+	})
+	record.AddAttributes(attrs...)
+
+	h.logger.Emit(ctx, record)
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = h.attrs[:len(h.attrs):len(h.attrs)]
+	prefix := slogattr.Prefix(h.groups)
+	for _, a := range attrs {
+		clone.attrs = appendKeyValues(clone.attrs, prefix, a)
+	}
+	return &clone
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	return &clone
+}
+
+// severity maps a slog level to an OpenTelemetry severity. slog levels are
+// spaced four apart like the OpenTelemetry ranges, so DEBUG, INFO, WARN and
+// ERROR map to the first severity of their range and levels in between keep
+// their offset.
+func severity(level slog.Level) log.Severity {
+	s := int(log.SeverityInfo) + int(level)
+	switch {
+	case s < int(log.SeverityTrace1):
+		return log.SeverityTrace1
+	case s > int(log.SeverityFatal4):
+		return log.SeverityFatal4
+	default:
+		return log.Severity(s) //nolint:gosec // clamped to the severity range above
+	}
+}
+
+// appendKeyValues appends a slog attribute to out as log attributes,
+// flattening groups into dot-separated keys.
+func appendKeyValues(out []log.KeyValue, prefix string, a slog.Attr) []log.KeyValue {
+	slogattr.Walk(prefix, a, func(key string, v slog.Value) {
+		switch v.Kind() {
+		case slog.KindInt64:
+			out = append(out, log.Int64(key, v.Int64()))
+		case slog.KindFloat64:
+			out = append(out, log.Float64(key, v.Float64()))
+		case slog.KindBool:
+			out = append(out, log.Bool(key, v.Bool()))
+		default:
+			out = append(out, log.String(key, v.String()))
+		}
+	})
+	return out
+}
@@ -0,0 +1,171 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recordingProcessor keeps emitted records in memory.
+type recordingProcessor struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (p *recordingProcessor) OnEmit(_ context.Context, r *sdklog.Record) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.records = append(p.records, r.Clone())
+	return nil
+}
+
+func (p *recordingProcessor) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+func (p *recordingProcessor) Shutdown(context.Context) error                         { return nil }
+func (p *recordingProcessor) ForceFlush(context.Context) error                       { return nil }
+
+func (p *recordingProcessor) Records() []sdklog.Record {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]sdklog.Record(nil), p.records...)
+}
+
+// newTestHandler returns a handler backed by an in-memory processor.
+func newTestHandler(t *testing.T, level slog.Leveler) (*Handler, *recordingProcessor) {
+	t.Helper()
+
+	processor := &recordingProcessor{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return NewHandler(provider.Logger("test"), level), processor
+}
+
+// recordAttributes returns the attributes of r keyed by name.
+func recordAttributes(r *sdklog.Record) map[string]log.Value {
+	attrs := make(map[string]log.Value)
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestHandler_Record(t *testing.T) {
+	t.Parallel()
+
+	h, processor := newTestHandler(t, nil)
+	logger := slog.New(h).With("service", "dispatch").WithGroup("ride")
+	logger.WarnContext(context.Background(), "no drivers nearby",
+		"radius", 2.5,
+		"count", 3,
+		"accepted", false,
+		"wait", 2*time.Second,
+		slog.Group("pickup", "zone", "baixa"),
+	)
+
+	records := processor.Records()
+	if len(records) != 1 {
+		t.Fatalf("records = %d, want 1", len(records))
+	}
+	r := records[0]
+
+	if got := r.Body().AsString(); got != "no drivers nearby" {
+		t.Errorf("body = %q, want %q", got, "no drivers nearby")
+	}
+	if r.Severity() != log.SeverityWarn {
+		t.Errorf("severity = %v, want %v", r.Severity(), log.SeverityWarn)
+	}
+	if r.SeverityText() != "WARN" {
+		t.Errorf("severity text = %q, want WARN", r.SeverityText())
+	}
+
+	attrs := recordAttributes(&r)
+	want := map[string]log.Value{
+		"service":          log.StringValue("dispatch"),
+		"ride.radius":      log.Float64Value(2.5),
+		"ride.count":       log.Int64Value(3),
+		"ride.accepted":    log.BoolValue(false),
+		"ride.wait":        log.StringValue("2s"),
+		"ride.pickup.zone": log.StringValue("baixa"),
+	}
+	for key, value := range want {
+		if got, ok := attrs[key]; !ok || !got.Equal(value) {
+			t.Errorf("%s = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestHandler_Level(t *testing.T) {
+	t.Parallel()
+
+	h, processor := newTestHandler(t, slog.LevelWarn)
+	ctx := context.Background()
+
+	if h.Enabled(ctx, slog.LevelInfo) {
+		t.Error("Enabled(INFO) = true, want false")
+	}
+	if !h.Enabled(ctx, slog.LevelError) {
+		t.Error("Enabled(ERROR) = false, want true")
+	}
+
+	logger := slog.New(h)
+	logger.InfoContext(ctx, "dropped")
+	logger.ErrorContext(ctx, "kept")
+
+	records := processor.Records()
+	if len(records) != 1 || records[0].Body().AsString() != "kept" {
+		t.Errorf("records = %v, want only %q", records, "kept")
+	}
+}
+
+func TestHandler_TraceContext(t *testing.T) {
+	t.Parallel()
+
+	tp := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+	ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+
+	h, processor := newTestHandler(t, nil)
+	slog.New(h).InfoContext(ctx, "handled")
+
+	records := processor.Records()
+	if len(records) != 1 {
+		t.Fatalf("records = %d, want 1", len(records))
+	}
+	sc := span.SpanContext()
+	if records[0].TraceID() != sc.TraceID() {
+		t.Errorf("trace ID = %s, want %s", records[0].TraceID(), sc.TraceID())
+	}
+	if records[0].SpanID() != sc.SpanID() {
+		t.Errorf("span ID = %s, want %s", records[0].SpanID(), sc.SpanID())
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level slog.Level
+		want  log.Severity
+	}{
+		{slog.LevelDebug, log.SeverityDebug},
+		{slog.LevelInfo, log.SeverityInfo},
+		{slog.LevelWarn, log.SeverityWarn},
+		{slog.LevelError, log.SeverityError},
+		{slog.LevelInfo + 1, log.SeverityInfo2},
+		{slog.LevelError + 4, log.SeverityFatal},
+		{slog.Level(-100), log.SeverityTrace1},
+		{slog.Level(100), log.SeverityFatal4},
+	}
+	for _, tt := range tests {
+		if got := severity(tt.level); got != tt.want {
+			t.Errorf("severity(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
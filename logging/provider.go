@@ -0,0 +1,210 @@
+package logging
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// Provider wraps an OpenTelemetry logger provider with a slog bridge and
+// lifecycle management.
+type Provider struct {
+	provider *sdklog.LoggerProvider
+	handler  *Handler
+	config   Config
+}
+
+// New creates a log provider and installs it as the global logger provider.
+// The exporter endpoint, headers, TLS, compression, timeout and retry settings
+// come from tracingCfg, as does the resource unless cfg.Resource is set.
+func New(ctx context.Context, tracingCfg tracing.Config, cfg Config) (*Provider, error) { //nolint:gocritic // cfg passed by value for API simplicity
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := tracingCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tracing config: %w", err)
+	}
+
+	applyConfigDefaults(&cfg, &tracingCfg)
+
+	res := cfg.Resource
+	if res == nil {
+		var err error
+		res, err = tracing.NewResource(ctx, tracingCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []sdklog.LoggerProviderOption{sdklog.WithResource(res)}
+	exporter, err := createExporter(ctx, &cfg, &tracingCfg)
+	if err != nil && !errors.Is(err, tracing.ErrNoExporter) {
+		return nil, err
+	}
+	if exporter != nil {
+		opts = append(opts, sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, batchOptions(cfg.Batch)...)))
+	}
+	provider := sdklog.NewLoggerProvider(opts...)
+	global.SetLoggerProvider(provider)
+
+	return &Provider{
+		provider: provider,
+		handler:  NewHandler(provider.Logger(tracingCfg.ServiceName), cfg.Level),
+		config:   cfg,
+	}, nil
+}
+
+// applyConfigDefaults sets default values for empty config fields.
+func applyConfigDefaults(cfg *Config, tracingCfg *tracing.Config) {
+	if cfg.Exporter == "" {
+		cfg.Exporter = tracing.SignalExporter[ExporterType](tracingCfg)
+	}
+	if cfg.URLPath == "" {
+		cfg.URLPath = tracing.SignalURLPath(tracingCfg, DefaultURLPath)
+	}
+	if cfg.Writer == nil {
+		cfg.Writer = os.Stdout
+	}
+	if cfg.Level == nil {
+		cfg.Level = slog.LevelInfo
+	}
+}
+
+// createExporter creates a log exporter based on configuration.
+func createExporter(ctx context.Context, cfg *Config, tracingCfg *tracing.Config) (sdklog.Exporter, error) {
+	if cfg.Exporter == ExporterStdout {
+		exporter, err := stdoutlog.New(stdoutlog.WithWriter(cfg.Writer))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout log exporter: %w", err)
+		}
+		return exporter, nil
+	}
+	return tracing.NewOTLPExporter(ctx, cfg.Exporter, tracing.OTLPExporterFuncs[sdklog.Exporter]{
+		HTTP: func(ctx context.Context) (sdklog.Exporter, error) { return createHTTPExporter(ctx, cfg, tracingCfg) },
+		GRPC: func(ctx context.Context) (sdklog.Exporter, error) { return createGRPCExporter(ctx, tracingCfg) },
+	})
+}
+
+// createHTTPExporter creates an OTLP HTTP log exporter.
+func createHTTPExporter(ctx context.Context, cfg *Config, tracingCfg *tracing.Config) (sdklog.Exporter, error) {
+	opts, err := tracing.OTLPOptions(tracingCfg, tracing.OTLPOptionFuncs[otlploghttp.Option]{
+		Endpoint: otlploghttp.WithEndpoint,
+		Insecure: otlploghttp.WithInsecure,
+		Headers:  otlploghttp.WithHeaders,
+		TLS:      otlploghttp.WithTLSClientConfig,
+		Gzip: func() otlploghttp.Option {
+			return otlploghttp.WithCompression(otlploghttp.GzipCompression)
+		},
+		Timeout: otlploghttp.WithTimeout,
+		Retry: func(retry tracing.RetryConfig) otlploghttp.Option {
+			return otlploghttp.WithRetry(otlploghttp.RetryConfig{
+				Enabled:         !retry.Disabled,
+				InitialInterval: retry.InitialInterval,
+				MaxInterval:     retry.MaxInterval,
+				MaxElapsedTime:  retry.MaxElapsedTime,
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, otlploghttp.WithURLPath(cfg.URLPath))
+	exporter, err := otlploghttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP HTTP log exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// createGRPCExporter creates an OTLP gRPC log exporter.
+func createGRPCExporter(ctx context.Context, tracingCfg *tracing.Config) (sdklog.Exporter, error) {
+	opts, err := tracing.OTLPOptions(tracingCfg, tracing.OTLPOptionFuncs[otlploggrpc.Option]{
+		Endpoint: otlploggrpc.WithEndpoint,
+		Insecure: otlploggrpc.WithInsecure,
+		Headers:  otlploggrpc.WithHeaders,
+		TLS: func(tlsCfg *tls.Config) otlploggrpc.Option {
+			return otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg))
+		},
+		Gzip: func() otlploggrpc.Option {
+			return otlploggrpc.WithCompressor(string(tracing.CompressionGzip))
+		},
+		Timeout: otlploggrpc.WithTimeout,
+		Retry: func(retry tracing.RetryConfig) otlploggrpc.Option {
+			return otlploggrpc.WithRetry(otlploggrpc.RetryConfig{
+				Enabled:         !retry.Disabled,
+				InitialInterval: retry.InitialInterval,
+				MaxInterval:     retry.MaxInterval,
+				MaxElapsedTime:  retry.MaxElapsedTime,
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlploggrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP gRPC log exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// batchOptions converts the batch settings to processor options, leaving
+// zero values to the SDK defaults.
+func batchOptions(b BatchConfig) []sdklog.BatchProcessorOption {
+	var opts []sdklog.BatchProcessorOption
+	if b.MaxQueueSize > 0 {
+		opts = append(opts, sdklog.WithMaxQueueSize(b.MaxQueueSize))
+	}
+	if b.MaxExportBatchSize > 0 {
+		opts = append(opts, sdklog.WithExportMaxBatchSize(b.MaxExportBatchSize))
+	}
+	if b.ExportInterval > 0 {
+		opts = append(opts, sdklog.WithExportInterval(b.ExportInterval))
+	}
+	if b.ExportTimeout > 0 {
+		opts = append(opts, sdklog.WithExportTimeout(b.ExportTimeout))
+	}
+	return opts
+}
+
+// Handler returns the slog bridge emitting records to the provider.
+func (p *Provider) Handler() *Handler {
+	return p.handler
+}
+
+// Logger returns a slog.Logger emitting records to the provider.
+func (p *Provider) Logger() *slog.Logger {
+	return slog.New(p.handler)
+}
+
+// LoggerProvider returns the underlying OpenTelemetry logger provider.
+func (p *Provider) LoggerProvider() *sdklog.LoggerProvider {
+	return p.provider
+}
+
+// Config returns the effective configuration.
+func (p *Provider) Config() Config {
+	return p.config
+}
+
+// Shutdown flushes pending records and shuts down the provider.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return p.provider.Shutdown(ctx)
+}
+
+// ForceFlush exports all pending records.
+func (p *Provider) ForceFlush(ctx context.Context) error {
+	return p.provider.ForceFlush(ctx)
+}
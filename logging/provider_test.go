@@ -0,0 +1,179 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// testTracingConfig returns a tracing configuration that exports nothing.
+func testTracingConfig(serviceName string) tracing.Config {
+	return tracing.Config{
+		ServiceName:              serviceName,
+		Exporter:                 tracing.ExporterNone,
+		DisableResourceDetection: true,
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	provider, err := New(ctx, testTracingConfig("test-service"), DefaultConfig())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer provider.Shutdown(ctx)
+
+	if provider.LoggerProvider() == nil {
+		t.Error("LoggerProvider() returned nil")
+	}
+	if provider.Handler() == nil || provider.Logger() == nil {
+		t.Error("Handler() or Logger() returned nil")
+	}
+	if global.GetLoggerProvider() != provider.LoggerProvider() {
+		t.Error("provider not installed as global logger provider")
+	}
+
+	cfg := provider.Config()
+	if cfg.Exporter != ExporterNone {
+		t.Errorf("Exporter = %q, want %q from tracing config", cfg.Exporter, ExporterNone)
+	}
+	if cfg.URLPath != DefaultURLPath {
+		t.Errorf("URLPath = %q, want %q", cfg.URLPath, DefaultURLPath)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	if _, err := New(ctx, testTracingConfig("test-service"), Config{Exporter: "kafka"}); err == nil {
+		t.Error("New() with invalid log exporter should fail")
+	}
+	if _, err := New(ctx, testTracingConfig(""), DefaultConfig()); err == nil {
+		t.Error("New() with invalid tracing config should fail")
+	}
+}
+
+func TestNew_ExporterFromTracing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tracing tracing.ExporterType
+		want    ExporterType
+	}{
+		{"", ExporterOTLPHTTP},
+		{tracing.ExporterOTLPHTTP, ExporterOTLPHTTP},
+		{tracing.ExporterOTLPGRPC, ExporterOTLPGRPC},
+		{tracing.ExporterNone, ExporterNone},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		tracingCfg := testTracingConfig("test-service")
+		tracingCfg.Exporter = tt.tracing
+		applyConfigDefaults(&cfg, &tracingCfg)
+		if cfg.Exporter != tt.want {
+			t.Errorf("tracing exporter %q: log exporter = %q, want %q", tt.tracing, cfg.Exporter, tt.want)
+		}
+	}
+}
+
+func TestNew_OTLPExporters(t *testing.T) {
+	ctx := context.Background()
+
+	for _, exporter := range []ExporterType{ExporterOTLPHTTP, ExporterOTLPGRPC} {
+		tracingCfg := testTracingConfig("test-service")
+		tracingCfg.Endpoint = "localhost:4318"
+		tracingCfg.Insecure = true
+		tracingCfg.Headers = map[string]string{"Authorization": "Bearer token"}
+		tracingCfg.Compression = tracing.CompressionGzip
+		tracingCfg.Retry = tracing.RetryConfig{Disabled: true}
+
+		cfg := DefaultConfig()
+		cfg.Exporter = exporter
+
+		provider, err := New(ctx, tracingCfg, cfg)
+		if err != nil {
+			t.Fatalf("New(%s) error = %v", exporter, err)
+		}
+		provider.Shutdown(ctx)
+	}
+}
+
+func TestNew_PrefixedEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	paths := make(chan string, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case paths <- r.URL.Path:
+		default:
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer collector.Close()
+
+	tracingCfg := testTracingConfig("test-service")
+	tracingCfg.Exporter = tracing.ExporterOTLPHTTP
+	tracingCfg.Endpoint = strings.TrimPrefix(collector.URL, "http://")
+	tracingCfg.Insecure = true
+	tracingCfg.URLPath = "/otlp/v1/traces"
+
+	provider, err := New(ctx, tracingCfg, DefaultConfig())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := provider.Config().URLPath; got != "/otlp/v1/logs" {
+		t.Errorf("URLPath = %q, want /otlp/v1/logs", got)
+	}
+
+	provider.Logger().InfoContext(ctx, "ride requested")
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	select {
+	case path := <-paths:
+		if path != "/otlp/v1/logs" {
+			t.Errorf("export path = %q, want /otlp/v1/logs", path)
+		}
+	default:
+		t.Error("no records exported")
+	}
+}
+
+func TestNew_StdoutExporter(t *testing.T) {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	res := resource.NewSchemaless(semconv.ServiceName("shared-resource"))
+	cfg := DefaultConfig()
+	cfg.WithExporter(ExporterStdout).WithWriter(&buf).WithResource(res)
+
+	provider, err := New(ctx, testTracingConfig("test-service"), cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	provider.Logger().InfoContext(ctx, "ride requested", "ride_id", "r-1")
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"ride requested", "ride_id", "shared-resource"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q: %s", want, out)
+		}
+	}
+}
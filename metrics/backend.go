@@ -0,0 +1,263 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Tag is a label name and value passed to a Backend.
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// Backend receives every value recorded by the collectors, in addition to
+// the Prometheus registry, so the same Record calls can feed another
+// monitoring system such as StatsD. name is the fully qualified metric name
+// (e.g., "txova_http_requests_total") and tags holds the constant labels
+// followed by the metric labels. Implementations must be safe for
+// concurrent use and should not block.
+type Backend interface {
+	// Count records a counter increment.
+	Count(name string, delta float64, tags []Tag)
+	// Gauge records the current value of a gauge.
+	Gauge(name string, value float64, tags []Tag)
+	// Observe records a histogram observation.
+	Observe(name string, value float64, tags []Tag)
+}
+
+// mirror forwards the values recorded on one metric to the Backend.
+type mirror struct {
+	backend    Backend
+	name       string
+	constTags  []Tag
+	labelNames []string
+
+	// gaugeMu serializes gauge changes with their mirrored values, so the
+	// Backend receives them in the order they were applied.
+	gaugeMu sync.Mutex
+}
+
+// newMirror returns the mirror for a metric, or nil when cfg has no Backend.
+func newMirror(cfg Config, namespace, subsystem, name string, constLabels prometheus.Labels, labelNames []string) *mirror {
+	if cfg.Backend == nil {
+		return nil
+	}
+	m := &mirror{
+		backend:    cfg.Backend,
+		name:       prometheus.BuildFQName(namespace, subsystem, name),
+		labelNames: labelNames,
+	}
+	for k, v := range constLabels {
+		m.constTags = append(m.constTags, Tag{Name: k, Value: v})
+	}
+	sort.Slice(m.constTags, func(i, j int) bool { return m.constTags[i].Name < m.constTags[j].Name })
+	return m
+}
+
+// tags returns the tags of the series with the given label values.
+func (m *mirror) tags(lvs []string) []Tag {
+	if len(m.constTags) == 0 && len(lvs) == 0 {
+		return nil
+	}
+	tags := make([]Tag, 0, len(m.constTags)+len(lvs))
+	tags = append(tags, m.constTags...)
+	for i, v := range lvs {
+		if i < len(m.labelNames) {
+			tags = append(tags, Tag{Name: m.labelNames[i], Value: v})
+		}
+	}
+	return tags
+}
+
+// counterVec is a CounterVec whose increments are mirrored to the Backend.
+type counterVec struct {
+	*prometheus.CounterVec
+	mirror *mirror
+}
+
+// newCounterVec creates and registers a CounterVec.
+func newCounterVec(cfg Config, opts prometheus.CounterOpts, labelNames []string) (*counterVec, error) { //nolint:gocritic // opts passed by value like prometheus.NewCounterVec
+	vec, err := registerCollector(cfg.Registry, prometheus.NewCounterVec(opts, labelNames))
+	if err != nil {
+		return nil, err
+	}
+	return &counterVec{
+		CounterVec: vec,
+		mirror:     newMirror(cfg, opts.Namespace, opts.Subsystem, opts.Name, opts.ConstLabels, labelNames),
+	}, nil
+}
+
+// WithLabelValues returns the counter for the label values.
+func (v *counterVec) WithLabelValues(lvs ...string) prometheus.Counter {
+	c := v.CounterVec.WithLabelValues(lvs...)
+	if v.mirror == nil {
+		return c
+	}
+	return &counter{Counter: c, mirror: v.mirror, tags: v.mirror.tags(lvs)}
+}
+
+// counter is a Counter whose increments are mirrored to the Backend.
+type counter struct {
+	prometheus.Counter
+	mirror *mirror
+	tags   []Tag
+}
+
+// newCounter creates and registers a Counter.
+func newCounter(cfg Config, opts prometheus.CounterOpts) (*counter, error) { //nolint:gocritic // opts passed by value like prometheus.NewCounter
+	c, err := registerCollector(cfg.Registry, prometheus.NewCounter(opts))
+	if err != nil {
+		return nil, err
+	}
+	m := newMirror(cfg, opts.Namespace, opts.Subsystem, opts.Name, opts.ConstLabels, nil)
+	var tags []Tag
+	if m != nil {
+		tags = m.tags(nil)
+	}
+	return &counter{Counter: c, mirror: m, tags: tags}, nil
+}
+
+// Inc increments the counter by 1.
+func (c *counter) Inc() {
+	c.Add(1)
+}
+
+// Add adds the given value to the counter.
+func (c *counter) Add(v float64) {
+	c.Counter.Add(v)
+	if c.mirror != nil {
+		c.mirror.backend.Count(c.mirror.name, v, c.tags)
+	}
+}
+
+// gaugeVec is a GaugeVec whose values are mirrored to the Backend.
+type gaugeVec struct {
+	*prometheus.GaugeVec
+	mirror *mirror
+}
+
+// newGaugeVec creates and registers a GaugeVec.
+func newGaugeVec(cfg Config, opts prometheus.GaugeOpts, labelNames []string) (*gaugeVec, error) { //nolint:gocritic // opts passed by value like prometheus.NewGaugeVec
+	vec, err := registerCollector(cfg.Registry, prometheus.NewGaugeVec(opts, labelNames))
+	if err != nil {
+		return nil, err
+	}
+	return &gaugeVec{
+		GaugeVec: vec,
+		mirror:   newMirror(cfg, opts.Namespace, opts.Subsystem, opts.Name, opts.ConstLabels, labelNames),
+	}, nil
+}
+
+// WithLabelValues returns the gauge for the label values.
+func (v *gaugeVec) WithLabelValues(lvs ...string) prometheus.Gauge {
+	g := v.GaugeVec.WithLabelValues(lvs...)
+	if v.mirror == nil {
+		return g
+	}
+	return &gauge{Gauge: g, mirror: v.mirror, tags: v.mirror.tags(lvs)}
+}
+
+// gauge is a Gauge whose values are mirrored to the Backend. Relative
+// changes are sent as the resulting absolute value, because DogStatsD
+// gauges have no relative form.
+type gauge struct {
+	prometheus.Gauge
+	mirror *mirror
+	tags   []Tag
+}
+
+// newGauge creates and registers a Gauge.
+func newGauge(cfg Config, opts prometheus.GaugeOpts) (*gauge, error) { //nolint:gocritic // opts passed by value like prometheus.NewGauge
+	g, err := registerCollector(cfg.Registry, prometheus.NewGauge(opts))
+	if err != nil {
+		return nil, err
+	}
+	m := newMirror(cfg, opts.Namespace, opts.Subsystem, opts.Name, opts.ConstLabels, nil)
+	var tags []Tag
+	if m != nil {
+		tags = m.tags(nil)
+	}
+	return &gauge{Gauge: g, mirror: m, tags: tags}, nil
+}
+
+// Set sets the gauge to the given value.
+func (g *gauge) Set(v float64) {
+	g.update(func() { g.Gauge.Set(v) })
+}
+
+// Inc increments the gauge by 1.
+func (g *gauge) Inc() {
+	g.Add(1)
+}
+
+// Dec decrements the gauge by 1.
+func (g *gauge) Dec() {
+	g.Add(-1)
+}
+
+// Sub subtracts the given value from the gauge.
+func (g *gauge) Sub(v float64) {
+	g.Add(-v)
+}
+
+// Add adds the given value to the gauge.
+func (g *gauge) Add(v float64) {
+	g.update(func() { g.Gauge.Add(v) })
+}
+
+// SetToCurrentTime sets the gauge to the current Unix time in seconds.
+func (g *gauge) SetToCurrentTime() {
+	g.update(g.Gauge.SetToCurrentTime)
+}
+
+// update applies change and mirrors the resulting value as one step, so
+// concurrent changes cannot reach the Backend out of order.
+func (g *gauge) update(change func()) {
+	if g.mirror == nil {
+		change()
+		return
+	}
+
+	g.mirror.gaugeMu.Lock()
+	defer g.mirror.gaugeMu.Unlock()
+	change()
+	var m dto.Metric
+	if err := g.Write(&m); err == nil {
+		g.mirror.backend.Gauge(g.mirror.name, m.GetGauge().GetValue(), g.tags)
+	}
+}
+
+// histogramVec is a HistogramVec whose observations are mirrored to the Backend.
+type histogramVec struct {
+	*prometheus.HistogramVec
+	mirror *mirror
+}
+
+// newHistogramVec creates and registers a HistogramVec.
+func newHistogramVec(cfg Config, opts prometheus.HistogramOpts, labelNames []string) (*histogramVec, error) { //nolint:gocritic // opts passed by value like prometheus.NewHistogramVec
+	vec, err := registerCollector(cfg.Registry, prometheus.NewHistogramVec(opts, labelNames))
+	if err != nil {
+		return nil, err
+	}
+	return &histogramVec{
+		HistogramVec: vec,
+		mirror:       newMirror(cfg, opts.Namespace, opts.Subsystem, opts.Name, opts.ConstLabels, labelNames),
+	}, nil
+}
+
+// WithLabelValues returns the observer for the label values.
+func (v *histogramVec) WithLabelValues(lvs ...string) prometheus.Observer {
+	o := v.HistogramVec.WithLabelValues(lvs...)
+	if v.mirror == nil {
+		return o
+	}
+	tags := v.mirror.tags(lvs)
+	return prometheus.ObserverFunc(func(value float64) {
+		o.Observe(value)
+		v.mirror.backend.Observe(v.mirror.name, value, tags)
+	})
+}
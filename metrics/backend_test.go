@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// recordingBackend records backend calls as "kind name value tag=value,...".
+type recordingBackend struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (b *recordingBackend) record(kind, name string, value float64, tags []Tag) {
+	pairs := make([]string, len(tags))
+	for i, t := range tags {
+		pairs[i] = t.Name + "=" + t.Value
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, fmt.Sprintf("%s %s %v %s", kind, name, value, strings.Join(pairs, ",")))
+}
+
+func (b *recordingBackend) Count(name string, delta float64, tags []Tag) {
+	b.record("count", name, delta, tags)
+}
+
+func (b *recordingBackend) Gauge(name string, value float64, tags []Tag) {
+	b.record("gauge", name, value, tags)
+}
+
+func (b *recordingBackend) Observe(name string, value float64, tags []Tag) {
+	b.record("observe", name, value, tags)
+}
+
+func (b *recordingBackend) Calls() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls...)
+}
+
+func TestBackend_MirrorsCollectors(t *testing.T) {
+	t.Parallel()
+
+	backend := &recordingBackend{}
+	cfg := DefaultConfig().
+		WithRegistry(prometheus.NewRegistry()).
+		WithSubsystem("edge").
+		WithConstLabels(prometheus.Labels{"region": "niassa", "env": "prod"}).
+		WithBackend(backend)
+
+	httpCollector, err := NewHTTPCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewHTTPCollector() error = %v", err)
+	}
+	rides, err := NewRideCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewRideCollector() error = %v", err)
+	}
+	payments, err := NewPaymentCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewPaymentCollector() error = %v", err)
+	}
+	drivers, err := NewDriverCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewDriverCollector() error = %v", err)
+	}
+
+	httpCollector.RecordRequest("GET", "/rides", 200, 250*time.Millisecond)
+	httpCollector.IncRequestsInFlight()
+	httpCollector.IncRequestsInFlight()
+	httpCollector.DecRequestsInFlight()
+	rides.RecordRideRequested("moto", "lichinga")
+	payments.RecordPayment("mpesa", "success")
+	drivers.SetRatingAverage(4.5)
+
+	want := []string{
+		"count txova_edge_http_requests_total 1 env=prod,region=niassa,method=GET,path=/rides,status=200",
+		"observe txova_edge_http_request_duration_seconds 0.25 env=prod,region=niassa,method=GET,path=/rides",
+		"gauge txova_edge_http_requests_in_flight 1 env=prod,region=niassa",
+		"gauge txova_edge_http_requests_in_flight 2 env=prod,region=niassa",
+		"gauge txova_edge_http_requests_in_flight 1 env=prod,region=niassa",
+		"count txova_edge_rides_requested_total 1 env=prod,region=niassa,service_type=moto,city=lichinga",
+		"count txova_edge_payments_total 1 env=prod,region=niassa,method=mpesa,status=success",
+		"gauge txova_edge_driver_rating_average 4.5 env=prod,region=niassa",
+	}
+	got := backend.Calls()
+	if len(got) != len(want) {
+		t.Fatalf("backend calls = %d, want %d:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// The registry still records every value.
+	if v := testutil.ToFloat64(httpCollector.requestsTotal.WithLabelValues("GET", "/rides", "200")); v != 1 {
+		t.Errorf("requestsTotal = %v, want 1", v)
+	}
+	if v := testutil.ToFloat64(httpCollector.requestsInFlight); v != 1 {
+		t.Errorf("requestsInFlight = %v, want 1", v)
+	}
+}
+
+func TestBackend_GaugeAndCounterMethods(t *testing.T) {
+	t.Parallel()
+
+	backend := &recordingBackend{}
+	cfg := DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithNamespace("test").WithBackend(backend)
+
+	g, err := newGauge(cfg, prometheus.GaugeOpts{Namespace: cfg.Namespace, Name: "queue_depth", Help: "Depth."})
+	if err != nil {
+		t.Fatalf("newGauge() error = %v", err)
+	}
+	g.Set(10)
+	g.Add(5)
+	g.Sub(3)
+	g.SetToCurrentTime()
+
+	c, err := newCounter(cfg, prometheus.CounterOpts{Namespace: cfg.Namespace, Name: "events_total", Help: "Events."})
+	if err != nil {
+		t.Fatalf("newCounter() error = %v", err)
+	}
+	c.Inc()
+	c.Add(2.5)
+
+	got := backend.Calls()
+	want := []string{
+		"gauge test_queue_depth 10 ",
+		"gauge test_queue_depth 15 ",
+		"gauge test_queue_depth 12 ",
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if !strings.HasPrefix(got[3], "gauge test_queue_depth ") || got[3] == "gauge test_queue_depth 12 " {
+		t.Errorf("SetToCurrentTime call = %q, want the current time", got[3])
+	}
+	if got[4] != "count test_events_total 1 " || got[5] != "count test_events_total 2.5 " {
+		t.Errorf("counter calls = %q", got[4:])
+	}
+}
+
+// slowFirstGaugeBackend delays the first gauge value it receives, so an
+// unsynchronized later change would be recorded before it.
+type slowFirstGaugeBackend struct {
+	*recordingBackend
+	once sync.Once
+}
+
+func (b *slowFirstGaugeBackend) Gauge(name string, value float64, tags []Tag) {
+	b.once.Do(func() { time.Sleep(20 * time.Millisecond) })
+	b.recordingBackend.Gauge(name, value, tags)
+}
+
+func TestBackend_ConcurrentGaugeChanges(t *testing.T) {
+	t.Parallel()
+
+	backend := &slowFirstGaugeBackend{recordingBackend: &recordingBackend{}}
+	cfg := DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithNamespace("test").WithBackend(backend)
+
+	vec, err := newGaugeVec(cfg, prometheus.GaugeOpts{Namespace: cfg.Namespace, Name: "in_flight", Help: "In flight."}, []string{"method"})
+	if err != nil {
+		t.Fatalf("newGaugeVec() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g := vec.WithLabelValues("GET")
+			g.Inc()
+			if i%2 == 0 {
+				g.Dec()
+			}
+		}()
+	}
+	wg.Wait()
+
+	calls := backend.Calls()
+	want := fmt.Sprintf("gauge test_in_flight %v method=GET", testutil.ToFloat64(vec.WithLabelValues("GET")))
+	if last := calls[len(calls)-1]; last != want {
+		t.Errorf("last mirrored value = %q, want %q", last, want)
+	}
+}
+
+func TestBackend_Nil(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig().WithRegistry(prometheus.NewRegistry())
+	vec, err := newCounterVec(cfg, prometheus.CounterOpts{Name: "plain_total", Help: "Plain."}, []string{"kind"})
+	if err != nil {
+		t.Fatalf("newCounterVec() error = %v", err)
+	}
+	if vec.mirror != nil {
+		t.Error("mirror should be nil without a backend")
+	}
+	if _, ok := vec.WithLabelValues("a").(*counter); ok {
+		t.Error("WithLabelValues() should return the Prometheus counter without a backend")
+	}
+}
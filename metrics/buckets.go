@@ -44,6 +44,21 @@ var DurationBuckets = []float64{
 	3600, // 1 hour
 }
 
+// JobDurationBuckets defines histogram buckets for scheduled and batch job durations in seconds.
+// Covers range from 1 second to 2 hours, suitable for cron and reconciliation jobs.
+var JobDurationBuckets = []float64{
+	1,    // 1s
+	5,    // 5s
+	15,   // 15s
+	30,   // 30s
+	60,   // 1 minute
+	300,  // 5 minutes
+	600,  // 10 minutes
+	1800, // 30 minutes
+	3600, // 1 hour
+	7200, // 2 hours
+}
+
 // FareBuckets defines histogram buckets for fare amounts in MZN (Mozambican Metical).
 // Covers range from 50 MZN to 25,000 MZN, suitable for ride fares.
 var FareBuckets = []float64{
@@ -197,6 +197,7 @@ func TestAllBucketsPositive(t *testing.T) {
 		"HTTPLatencyBuckets":   HTTPLatencyBuckets,
 		"DBLatencyBuckets":     DBLatencyBuckets,
 		"DurationBuckets":      DurationBuckets,
+		"JobDurationBuckets":   JobDurationBuckets,
 		"FareBuckets":          FareBuckets,
 		"RequestSizeBuckets":   RequestSizeBuckets,
 		"DistanceBuckets":      DistanceBuckets,
@@ -5,6 +5,7 @@ package metrics
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -22,6 +23,13 @@ type Config struct {
 
 	// Registry is the Prometheus registry to use. If nil, the default registry is used.
 	Registry prometheus.Registerer
+
+	// ConstLabels are labels applied to every metric (e.g., environment, k8s namespace).
+	ConstLabels prometheus.Labels
+
+	// Backend receives every recorded value in addition to the registry
+	// (e.g., a StatsD client). If nil, values are only recorded in the registry.
+	Backend Backend
 }
 
 // DefaultConfig returns a Config with default values.
@@ -51,6 +59,18 @@ func (c Config) WithRegistry(registry prometheus.Registerer) Config {
 	return c
 }
 
+// WithConstLabels returns a new Config with the specified constant labels.
+func (c Config) WithConstLabels(labels prometheus.Labels) Config {
+	c.ConstLabels = labels
+	return c
+}
+
+// WithBackend returns a new Config with the specified backend.
+func (c Config) WithBackend(backend Backend) Config {
+	c.Backend = backend
+	return c
+}
+
 // Validate checks that the configuration is valid and returns a validated copy.
 func (c Config) Validate() (Config, error) { //nolint:unparam // error kept for API consistency and future validation
 	if c.Namespace == "" {
@@ -62,6 +82,19 @@ func (c Config) Validate() (Config, error) { //nolint:unparam // error kept for
 	return c, nil
 }
 
+// Gatherer returns the registry as a prometheus.Gatherer, for exporters that
+// read the collected metrics. A nil registry is the default registry.
+func (c Config) Gatherer() (prometheus.Gatherer, error) {
+	if c.Registry == nil {
+		return prometheus.DefaultGatherer, nil
+	}
+	gatherer, ok := c.Registry.(prometheus.Gatherer)
+	if !ok {
+		return nil, fmt.Errorf("metrics registry %T does not implement prometheus.Gatherer", c.Registry)
+	}
+	return gatherer, nil
+}
+
 // registerCollector registers a collector with the registry, handling already registered errors.
 // If the collector is already registered, it returns the existing collector.
 func registerCollector[T prometheus.Collector](registry prometheus.Registerer, collector T) (T, error) {
@@ -104,3 +104,62 @@ func TestConfig_Chaining(t *testing.T) {
 		t.Error("Registry should be the custom registry")
 	}
 }
+
+func TestConfig_WithConstLabels(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	labels := prometheus.Labels{"deployment_environment": "staging"}
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_const_labels").WithConstLabels(labels)
+
+	if cfg.ConstLabels["deployment_environment"] != "staging" {
+		t.Errorf("ConstLabels = %v, want deployment_environment=staging", cfg.ConstLabels)
+	}
+
+	collector, err := NewHTTPCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewHTTPCollector() error = %v", err)
+	}
+	collector.RecordRequest("GET", "/api/v1/rides", 200, 0)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	if len(families) == 0 {
+		t.Fatal("Gather() returned no metric families")
+	}
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			found := false
+			for _, lp := range m.GetLabel() {
+				if lp.GetName() == "deployment_environment" && lp.GetValue() == "staging" {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("metric %s missing const label deployment_environment", mf.GetName())
+			}
+		}
+	}
+}
+
+func TestConfig_Gatherer(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	gatherer, err := DefaultConfig().WithRegistry(registry).Gatherer()
+	if err != nil || gatherer != registry {
+		t.Errorf("Gatherer() = %v, %v, want the registry", gatherer, err)
+	}
+
+	gatherer, err = Config{}.Gatherer()
+	if err != nil || gatherer != prometheus.DefaultGatherer {
+		t.Errorf("Gatherer() with nil registry = %v, %v, want the default gatherer", gatherer, err)
+	}
+
+	wrapped := prometheus.WrapRegistererWithPrefix("wrapped_", registry)
+	if _, err := DefaultConfig().WithRegistry(wrapped).Gatherer(); err == nil {
+		t.Error("Gatherer() with a registerer that cannot gather should fail")
+	}
+}
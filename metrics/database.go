@@ -8,10 +8,10 @@ import (
 
 // DBCollector collects database metrics.
 type DBCollector struct {
-	connectionsTotal    *prometheus.GaugeVec
-	queryDuration       *prometheus.HistogramVec
-	queryErrorsTotal    *prometheus.CounterVec
-	transactionDuration *prometheus.HistogramVec
+	connectionsTotal    *gaugeVec
+	queryDuration       *histogramVec
+	queryErrorsTotal    *counterVec
+	transactionDuration *histogramVec
 }
 
 // NewDBCollector creates a new DBCollector with the given configuration.
@@ -23,56 +23,60 @@ func NewDBCollector(cfg Config) (*DBCollector, error) {
 
 	c := &DBCollector{}
 
-	c.connectionsTotal, err = registerCollector(cfg.Registry, prometheus.NewGaugeVec(
+	c.connectionsTotal, err = newGaugeVec(cfg,
 		prometheus.GaugeOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "db_connections_total",
-			Help:      "Current number of database connections by pool and state.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "db_connections_total",
+			Help:        "Current number of database connections by pool and state.",
 		},
 		[]string{"pool", "state"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.queryDuration, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.queryDuration, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "db_query_duration_seconds",
-			Help:      "Database query latency in seconds.",
-			Buckets:   DBLatencyBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "db_query_duration_seconds",
+			Help:        "Database query latency in seconds.",
+			Buckets:     DBLatencyBuckets,
 		},
 		[]string{"operation"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.queryErrorsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.queryErrorsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "db_query_errors_total",
-			Help:      "Total number of database query errors.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "db_query_errors_total",
+			Help:        "Total number of database query errors.",
 		},
 		[]string{"operation", "error"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.transactionDuration, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.transactionDuration, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "db_transaction_duration_seconds",
-			Help:      "Database transaction latency in seconds.",
-			Buckets:   DBLatencyBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "db_transaction_duration_seconds",
+			Help:        "Database transaction latency in seconds.",
+			Buckets:     DBLatencyBuckets,
 		},
 		[]string{},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
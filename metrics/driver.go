@@ -6,10 +6,10 @@ import (
 
 // DriverCollector collects driver-related business metrics.
 type DriverCollector struct {
-	onlineTotal    *prometheus.GaugeVec
-	acceptanceRate *prometheus.GaugeVec
-	ratingAverage  prometheus.Gauge
-	earnings       *prometheus.CounterVec
+	onlineTotal    *gaugeVec
+	acceptanceRate *gaugeVec
+	ratingAverage  *gauge
+	earnings       *counterVec
 }
 
 // NewDriverCollector creates a new DriverCollector with the given configuration.
@@ -21,53 +21,57 @@ func NewDriverCollector(cfg Config) (*DriverCollector, error) {
 
 	c := &DriverCollector{}
 
-	c.onlineTotal, err = registerCollector(cfg.Registry, prometheus.NewGaugeVec(
+	c.onlineTotal, err = newGaugeVec(cfg,
 		prometheus.GaugeOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "drivers_online_total",
-			Help:      "Current number of online drivers.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "drivers_online_total",
+			Help:        "Current number of online drivers.",
 		},
 		[]string{"city", "service_type"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.acceptanceRate, err = registerCollector(cfg.Registry, prometheus.NewGaugeVec(
+	c.acceptanceRate, err = newGaugeVec(cfg,
 		prometheus.GaugeOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "driver_acceptance_rate",
-			Help:      "Driver acceptance rate (0.0-1.0).",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "driver_acceptance_rate",
+			Help:        "Driver acceptance rate (0.0-1.0).",
 		},
 		[]string{"driver_id"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.ratingAverage, err = registerCollector(cfg.Registry, prometheus.NewGauge(
+	c.ratingAverage, err = newGauge(cfg,
 		prometheus.GaugeOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "driver_rating_average",
-			Help:      "Average driver rating across all drivers.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "driver_rating_average",
+			Help:        "Average driver rating across all drivers.",
 		},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.earnings, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.earnings, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "driver_earnings_mzn",
-			Help:      "Total driver earnings in MZN (smallest currency unit).",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "driver_earnings_mzn",
+			Help:        "Total driver earnings in MZN (smallest currency unit).",
 		},
 		[]string{"driver_id"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
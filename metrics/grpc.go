@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GRPCCollector collects gRPC server and client call metrics.
+type GRPCCollector struct {
+	serverHandled  *counterVec
+	serverHandling *histogramVec
+	clientHandled  *counterVec
+	clientHandling *histogramVec
+}
+
+// NewGRPCCollector creates a new GRPCCollector with the given configuration.
+func NewGRPCCollector(cfg Config) (*GRPCCollector, error) {
+	cfg, err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &GRPCCollector{}
+
+	c.serverHandled, err = newCounterVec(cfg,
+		prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "grpc_server_handled_total",
+			Help:        "Total number of gRPC calls completed by the server, by status code.",
+		},
+		[]string{"service", "method", "code"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.serverHandling, err = newHistogramVec(cfg,
+		prometheus.HistogramOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "grpc_server_handling_seconds",
+			Help:        "gRPC server call duration in seconds.",
+			Buckets:     HTTPLatencyBuckets,
+		},
+		[]string{"service", "method"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.clientHandled, err = newCounterVec(cfg,
+		prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "grpc_client_handled_total",
+			Help:        "Total number of gRPC calls completed by the client, by status code.",
+		},
+		[]string{"service", "method", "code"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.clientHandling, err = newHistogramVec(cfg,
+		prometheus.HistogramOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "grpc_client_handling_seconds",
+			Help:        "gRPC client call duration in seconds, until the last response is received.",
+			Buckets:     HTTPLatencyBuckets,
+		},
+		[]string{"service", "method"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// RecordServerHandled records a call completed by the server.
+// service: full service name (e.g., "txova.rides.v1.RideService").
+// method: method name (e.g., "GetRide").
+// code: gRPC status code name (e.g., "OK", "NotFound").
+func (c *GRPCCollector) RecordServerHandled(service, method, code string, duration time.Duration) {
+	c.serverHandled.WithLabelValues(service, method, code).Inc()
+	c.serverHandling.WithLabelValues(service, method).Observe(duration.Seconds())
+}
+
+// RecordClientHandled records a call completed by the client.
+func (c *GRPCCollector) RecordClientHandled(service, method, code string, duration time.Duration) {
+	c.clientHandled.WithLabelValues(service, method, code).Inc()
+	c.clientHandling.WithLabelValues(service, method).Observe(duration.Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (c *GRPCCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.serverHandled.Describe(ch)
+	c.serverHandling.Describe(ch)
+	c.clientHandled.Describe(ch)
+	c.clientHandling.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *GRPCCollector) Collect(ch chan<- prometheus.Metric) {
+	c.serverHandled.Collect(ch)
+	c.serverHandling.Collect(ch)
+	c.clientHandled.Collect(ch)
+	c.clientHandling.Collect(ch)
+}
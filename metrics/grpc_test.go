@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewGRPCCollector(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_grpc")
+
+	collector, err := NewGRPCCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewGRPCCollector() error = %v", err)
+	}
+	if collector == nil {
+		t.Fatal("NewGRPCCollector() returned nil collector")
+	}
+
+	// Registering twice reuses the existing collectors.
+	if _, err := NewGRPCCollector(cfg); err != nil {
+		t.Fatalf("Second NewGRPCCollector() error = %v", err)
+	}
+}
+
+func TestGRPCCollector_RecordHandled(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_grpc_handled")
+
+	collector, err := NewGRPCCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewGRPCCollector() error = %v", err)
+	}
+
+	const service = "txova.rides.v1.RideService"
+	collector.RecordServerHandled(service, "GetRide", "OK", 10*time.Millisecond)
+	collector.RecordServerHandled(service, "GetRide", "OK", 20*time.Millisecond)
+	collector.RecordServerHandled(service, "GetRide", "NotFound", 5*time.Millisecond)
+	collector.RecordClientHandled(service, "GetRide", "Unavailable", time.Second)
+
+	tests := []struct {
+		name string
+		vec  *counterVec
+		code string
+		want float64
+	}{
+		{"server OK", collector.serverHandled, "OK", 2},
+		{"server NotFound", collector.serverHandled, "NotFound", 1},
+		{"client Unavailable", collector.clientHandled, "Unavailable", 1},
+		{"client OK", collector.clientHandled, "OK", 0},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(tt.vec.WithLabelValues(service, "GetRide", tt.code)); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if n := testutil.CollectAndCount(collector.serverHandling); n != 1 {
+		t.Errorf("server handling histogram series = %d, want 1", n)
+	}
+	if n := testutil.CollectAndCount(collector.clientHandling); n != 1 {
+		t.Errorf("client handling histogram series = %d, want 1", n)
+	}
+}
+
+func TestGRPCCollector_DescribeCollect(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_grpc_collect")
+
+	collector, err := NewGRPCCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewGRPCCollector() error = %v", err)
+	}
+
+	descs := make(chan *prometheus.Desc, 10)
+	collector.Describe(descs)
+	close(descs)
+	if len(descs) != 4 {
+		t.Errorf("Describe() produced %d descriptors, want 4", len(descs))
+	}
+
+	collector.RecordServerHandled("svc", "Method", "OK", time.Millisecond)
+	collector.RecordClientHandled("svc", "Method", "OK", time.Millisecond)
+
+	metrics := make(chan prometheus.Metric, 10)
+	collector.Collect(metrics)
+	close(metrics)
+	if len(metrics) != 4 {
+		t.Errorf("Collect() produced %d metrics, want 4", len(metrics))
+	}
+}
@@ -10,12 +10,12 @@ import (
 // HTTPCollector collects HTTP request metrics.
 // It implements the server.MetricsCollector interface from txova-go-core.
 type HTTPCollector struct {
-	requestsTotal    *prometheus.CounterVec
-	requestDuration  *prometheus.HistogramVec
-	requestSize      *prometheus.HistogramVec
-	responseSize     *prometheus.HistogramVec
-	requestsInFlight prometheus.Gauge
-	panicsTotal      *prometheus.CounterVec
+	requestsTotal    *counterVec
+	requestDuration  *histogramVec
+	requestSize      *histogramVec
+	responseSize     *histogramVec
+	requestsInFlight *gauge
+	panicsTotal      *counterVec
 }
 
 // NewHTTPCollector creates a new HTTPCollector with the given configuration.
@@ -27,82 +27,88 @@ func NewHTTPCollector(cfg Config) (*HTTPCollector, error) {
 
 	c := &HTTPCollector{}
 
-	c.requestsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.requestsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "http_requests_total",
-			Help:      "Total number of HTTP requests.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "http_requests_total",
+			Help:        "Total number of HTTP requests.",
 		},
 		[]string{"method", "path", "status"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.requestDuration, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.requestDuration, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "http_request_duration_seconds",
-			Help:      "HTTP request latency in seconds.",
-			Buckets:   HTTPLatencyBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "http_request_duration_seconds",
+			Help:        "HTTP request latency in seconds.",
+			Buckets:     HTTPLatencyBuckets,
 		},
 		[]string{"method", "path"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.requestSize, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.requestSize, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "http_request_size_bytes",
-			Help:      "HTTP request body size in bytes.",
-			Buckets:   RequestSizeBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "http_request_size_bytes",
+			Help:        "HTTP request body size in bytes.",
+			Buckets:     RequestSizeBuckets,
 		},
 		[]string{"method", "path"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.responseSize, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.responseSize, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "http_response_size_bytes",
-			Help:      "HTTP response body size in bytes.",
-			Buckets:   RequestSizeBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "http_response_size_bytes",
+			Help:        "HTTP response body size in bytes.",
+			Buckets:     RequestSizeBuckets,
 		},
 		[]string{"method", "path"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.requestsInFlight, err = registerCollector(cfg.Registry, prometheus.NewGauge(
+	c.requestsInFlight, err = newGauge(cfg,
 		prometheus.GaugeOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "http_requests_in_flight",
-			Help:      "Current number of HTTP requests being processed.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "http_requests_in_flight",
+			Help:        "Current number of HTTP requests being processed.",
 		},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.panicsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.panicsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "http_panics_total",
-			Help:      "Total number of panics during HTTP request handling.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "http_panics_total",
+			Help:        "Total number of panics during HTTP request handling.",
 		},
 		[]string{"method", "path"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Job run status label values.
+const (
+	JobStatusSuccess = "success"
+	JobStatusFailure = "failure"
+	JobStatusSkipped = "skipped"
+)
+
+// JobCollector collects scheduled and batch job metrics.
+type JobCollector struct {
+	runsTotal   *counterVec
+	duration    *histogramVec
+	lastSuccess *gaugeVec
+}
+
+// NewJobCollector creates a new JobCollector with the given configuration.
+func NewJobCollector(cfg Config) (*JobCollector, error) {
+	cfg, err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &JobCollector{}
+
+	c.runsTotal, err = newCounterVec(cfg,
+		prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "job_runs_total",
+			Help:        "Total number of job runs by status.",
+		},
+		[]string{"job", "status"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.duration, err = newHistogramVec(cfg,
+		prometheus.HistogramOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "job_duration_seconds",
+			Help:        "Job run duration in seconds.",
+			Buckets:     JobDurationBuckets,
+		},
+		[]string{"job"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lastSuccess, err = newGaugeVec(cfg,
+		prometheus.GaugeOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "job_last_success_timestamp_seconds",
+			Help:        "Unix timestamp of the last successful job run.",
+		},
+		[]string{"job"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// RecordRun records a completed job run.
+// job: job name (e.g., "payouts", "reconciliation").
+// status: "success" or "failure".
+func (c *JobCollector) RecordRun(job, status string, duration time.Duration) {
+	c.runsTotal.WithLabelValues(job, status).Inc()
+	c.duration.WithLabelValues(job).Observe(duration.Seconds())
+}
+
+// RecordSkipped records a run skipped because the previous run was still in progress.
+func (c *JobCollector) RecordSkipped(job string) {
+	c.runsTotal.WithLabelValues(job, JobStatusSkipped).Inc()
+}
+
+// SetLastSuccess sets the time of the last successful run.
+func (c *JobCollector) SetLastSuccess(job string, t time.Time) {
+	c.lastSuccess.WithLabelValues(job).Set(float64(t.UnixNano()) / float64(time.Second))
+}
+
+// Describe implements prometheus.Collector.
+func (c *JobCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.runsTotal.Describe(ch)
+	c.duration.Describe(ch)
+	c.lastSuccess.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *JobCollector) Collect(ch chan<- prometheus.Metric) {
+	c.runsTotal.Collect(ch)
+	c.duration.Collect(ch)
+	c.lastSuccess.Collect(ch)
+}
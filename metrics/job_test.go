@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewJobCollector(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_job")
+
+	collector, err := NewJobCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewJobCollector() error = %v", err)
+	}
+	if collector == nil {
+		t.Fatal("NewJobCollector() returned nil collector")
+	}
+}
+
+func TestNewJobCollector_DuplicateRegistration(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_job_dup")
+
+	collector1, err := NewJobCollector(cfg)
+	if err != nil {
+		t.Fatalf("First NewJobCollector() error = %v", err)
+	}
+
+	collector2, err := NewJobCollector(cfg)
+	if err != nil {
+		t.Fatalf("Second NewJobCollector() error = %v", err)
+	}
+
+	if collector1 == nil || collector2 == nil {
+		t.Fatal("NewJobCollector() returned nil collectors")
+	}
+}
+
+func TestJobCollector_RecordRun(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_job_run")
+
+	collector, err := NewJobCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewJobCollector() error = %v", err)
+	}
+
+	collector.RecordRun("payouts", JobStatusSuccess, 30*time.Second)
+	collector.RecordRun("payouts", JobStatusFailure, 2*time.Second)
+	collector.RecordRun("payouts", JobStatusSuccess, 45*time.Second)
+	collector.RecordSkipped("payouts")
+
+	tests := []struct {
+		status string
+		want   float64
+	}{
+		{JobStatusSuccess, 2},
+		{JobStatusFailure, 1},
+		{JobStatusSkipped, 1},
+	}
+	for _, tt := range tests {
+		if count := testutil.ToFloat64(collector.runsTotal.WithLabelValues("payouts", tt.status)); count != tt.want {
+			t.Errorf("runsTotal %s = %v, want %v", tt.status, count, tt.want)
+		}
+	}
+
+	if histCount := testutil.CollectAndCount(collector.duration); histCount != 1 {
+		t.Errorf("duration histogram series = %d, want 1", histCount)
+	}
+}
+
+func TestJobCollector_SetLastSuccess(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_job_success")
+
+	collector, err := NewJobCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewJobCollector() error = %v", err)
+	}
+
+	ts := time.Unix(1700000000, 500000000)
+	collector.SetLastSuccess("reconciliation", ts)
+
+	if got := testutil.ToFloat64(collector.lastSuccess.WithLabelValues("reconciliation")); got != 1700000000.5 {
+		t.Errorf("lastSuccess = %v, want 1700000000.5", got)
+	}
+}
+
+func TestJobCollector_DescribeCollect(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_job_collect")
+
+	collector, err := NewJobCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewJobCollector() error = %v", err)
+	}
+
+	descs := make(chan *prometheus.Desc, 10)
+	collector.Describe(descs)
+	close(descs)
+	if len(descs) != 3 {
+		t.Errorf("Describe() produced %d descriptors, want 3", len(descs))
+	}
+
+	collector.RecordRun("cleanup", JobStatusSuccess, time.Second)
+	collector.SetLastSuccess("cleanup", time.Now())
+
+	metrics := make(chan prometheus.Metric, 10)
+	collector.Collect(metrics)
+	close(metrics)
+	if len(metrics) != 3 {
+		t.Errorf("Collect() produced %d metrics, want 3", len(metrics))
+	}
+}
@@ -6,11 +6,11 @@ import (
 
 // KafkaCollector collects Kafka metrics.
 type KafkaCollector struct {
-	messagesProducedTotal *prometheus.CounterVec
-	messagesConsumedTotal *prometheus.CounterVec
-	consumerLag           *prometheus.GaugeVec
-	produceErrorsTotal    *prometheus.CounterVec
-	consumeErrorsTotal    *prometheus.CounterVec
+	messagesProducedTotal *counterVec
+	messagesConsumedTotal *counterVec
+	consumerLag           *gaugeVec
+	produceErrorsTotal    *counterVec
+	consumeErrorsTotal    *counterVec
 }
 
 // NewKafkaCollector creates a new KafkaCollector with the given configuration.
@@ -22,67 +22,72 @@ func NewKafkaCollector(cfg Config) (*KafkaCollector, error) {
 
 	c := &KafkaCollector{}
 
-	c.messagesProducedTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.messagesProducedTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "kafka_messages_produced_total",
-			Help:      "Total number of Kafka messages produced.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "kafka_messages_produced_total",
+			Help:        "Total number of Kafka messages produced.",
 		},
 		[]string{"topic"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.messagesConsumedTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.messagesConsumedTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "kafka_messages_consumed_total",
-			Help:      "Total number of Kafka messages consumed.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "kafka_messages_consumed_total",
+			Help:        "Total number of Kafka messages consumed.",
 		},
 		[]string{"topic", "group"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.consumerLag, err = registerCollector(cfg.Registry, prometheus.NewGaugeVec(
+	c.consumerLag, err = newGaugeVec(cfg,
 		prometheus.GaugeOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "kafka_consumer_lag",
-			Help:      "Current consumer lag by topic, partition, and consumer group.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "kafka_consumer_lag",
+			Help:        "Current consumer lag by topic, partition, and consumer group.",
 		},
 		[]string{"topic", "partition", "group"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.produceErrorsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.produceErrorsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "kafka_produce_errors_total",
-			Help:      "Total number of Kafka produce errors.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "kafka_produce_errors_total",
+			Help:        "Total number of Kafka produce errors.",
 		},
 		[]string{"topic"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.consumeErrorsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.consumeErrorsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "kafka_consume_errors_total",
-			Help:      "Total number of Kafka consume errors.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "kafka_consume_errors_total",
+			Help:        "Total number of Kafka consume errors.",
 		},
 		[]string{"topic"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
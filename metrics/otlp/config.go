@@ -0,0 +1,107 @@
+// Package otlp exports the metrics of a Prometheus registry through
+// OpenTelemetry OTLP, for environments that run an OpenTelemetry Collector
+// without a Prometheus scraper. Counters, gauges, histograms and summaries
+// gathered from the registry are converted to their OpenTelemetry equivalents,
+// so the collectors in the metrics package work unchanged in scrape and push
+// deployments.
+package otlp
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// ExporterType defines the metric exporter type.
+type ExporterType string
+
+const (
+	// ExporterOTLPHTTP exports metrics via OTLP over HTTP.
+	ExporterOTLPHTTP ExporterType = "otlp-http"
+	// ExporterOTLPGRPC exports metrics via OTLP over gRPC.
+	ExporterOTLPGRPC ExporterType = "otlp-grpc"
+	// ExporterNone disables metric exporting (for testing).
+	ExporterNone ExporterType = "none"
+)
+
+// DefaultURLPath is the OTLP HTTP path for metrics.
+const DefaultURLPath = "/v1/metrics"
+
+// DefaultInterval is the default time between two exports.
+const DefaultInterval = 60 * time.Second
+
+// Config holds configuration for the OTLP metrics bridge. Endpoint, headers,
+// TLS, compression, timeout and retry settings are taken from the
+// tracing.Config passed to New.
+type Config struct {
+	// Exporter defines the metric exporter type.
+	// Empty uses the exporter of the tracing configuration.
+	Exporter ExporterType
+
+	// URLPath overrides the URL path for the OTLP HTTP exporter.
+	// Empty uses DefaultURLPath under the prefix of the tracing URLPath,
+	// e.g. "/otlp/v1/traces" gives "/otlp/v1/metrics".
+	URLPath string
+
+	// Interval is the time between two exports. Empty uses DefaultInterval.
+	Interval time.Duration
+
+	// Timeout bounds each export, including gathering the registry.
+	// Empty uses the SDK default of 30s.
+	Timeout time.Duration
+
+	// Resource describes the exporting service. If nil, a resource is built
+	// from the tracing configuration.
+	Resource *resource.Resource
+}
+
+// DefaultConfig returns a Config with default values.
+func DefaultConfig() Config {
+	return Config{
+		Interval: DefaultInterval,
+	}
+}
+
+// WithExporter sets the exporter type.
+func (c *Config) WithExporter(exporter ExporterType) *Config {
+	c.Exporter = exporter
+	return c
+}
+
+// WithURLPath sets the URL path for the OTLP HTTP exporter.
+func (c *Config) WithURLPath(path string) *Config {
+	c.URLPath = path
+	return c
+}
+
+// WithInterval sets the time between two exports.
+func (c *Config) WithInterval(interval time.Duration) *Config {
+	c.Interval = interval
+	return c
+}
+
+// WithTimeout sets the export timeout.
+func (c *Config) WithTimeout(timeout time.Duration) *Config {
+	c.Timeout = timeout
+	return c
+}
+
+// WithResource sets the resource attached to exported metrics.
+func (c *Config) WithResource(res *resource.Resource) *Config {
+	c.Resource = res
+	return c
+}
+
+// Validate checks if the configuration is valid.
+func (c *Config) Validate() error {
+	switch c.Exporter {
+	case "", ExporterOTLPHTTP, ExporterOTLPGRPC, ExporterNone:
+	default:
+		return fmt.Errorf("invalid metric exporter type: %s", c.Exporter)
+	}
+	if c.Interval < 0 || c.Timeout < 0 {
+		return fmt.Errorf("export interval and timeout must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	if cfg.Exporter != "" {
+		t.Errorf("Exporter = %q, want empty (tracing exporter)", cfg.Exporter)
+	}
+	if cfg.Interval != DefaultInterval {
+		t.Errorf("Interval = %v, want %v", cfg.Interval, DefaultInterval)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestConfig_Setters(t *testing.T) {
+	t.Parallel()
+
+	res := resource.Empty()
+
+	cfg := DefaultConfig()
+	cfg.WithExporter(ExporterOTLPGRPC).
+		WithURLPath("/custom/metrics").
+		WithInterval(15 * time.Second).
+		WithTimeout(5 * time.Second).
+		WithResource(res)
+
+	if cfg.Exporter != ExporterOTLPGRPC {
+		t.Errorf("Exporter = %q, want %q", cfg.Exporter, ExporterOTLPGRPC)
+	}
+	if cfg.URLPath != "/custom/metrics" {
+		t.Errorf("URLPath = %q, want /custom/metrics", cfg.URLPath)
+	}
+	if cfg.Interval != 15*time.Second || cfg.Timeout != 5*time.Second {
+		t.Errorf("Interval/Timeout = %v/%v", cfg.Interval, cfg.Timeout)
+	}
+	if cfg.Resource != res {
+		t.Error("Resource not set")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"empty", Config{}, false},
+		{"http", Config{Exporter: ExporterOTLPHTTP}, false},
+		{"grpc", Config{Exporter: ExporterOTLPGRPC}, false},
+		{"none", Config{Exporter: ExporterNone}, false},
+		{"invalid exporter", Config{Exporter: "statsd"}, true},
+		{"negative interval", Config{Interval: -time.Second}, true},
+		{"negative timeout", Config{Timeout: -time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
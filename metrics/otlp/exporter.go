@@ -0,0 +1,175 @@
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	prometheusbridge "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// Exporter periodically gathers a Prometheus registry and exports it via OTLP.
+type Exporter struct {
+	provider *sdkmetric.MeterProvider
+	config   Config
+}
+
+// New creates an Exporter for the registry of metricsCfg, which must
+// implement prometheus.Gatherer (as *prometheus.Registry does). The exporter
+// endpoint, headers, TLS, compression, timeout and retry settings come from
+// tracingCfg, as does the resource unless cfg.Resource is set.
+func New(ctx context.Context, metricsCfg metrics.Config, tracingCfg tracing.Config, cfg Config) (*Exporter, error) { //nolint:gocritic // cfg passed by value for API simplicity
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := tracingCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tracing config: %w", err)
+	}
+	gatherer, err := metricsCfg.Gatherer()
+	if err != nil {
+		return nil, err
+	}
+
+	applyConfigDefaults(&cfg, &tracingCfg)
+
+	res := cfg.Resource
+	if res == nil {
+		res, err = tracing.NewResource(ctx, tracingCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	exporter, err := createExporter(ctx, &cfg, &tracingCfg)
+	if err != nil && !errors.Is(err, tracing.ErrNoExporter) {
+		return nil, err
+	}
+	if exporter != nil {
+		readerOpts := []sdkmetric.PeriodicReaderOption{
+			sdkmetric.WithInterval(cfg.Interval),
+			sdkmetric.WithProducer(prometheusbridge.NewMetricProducer(prometheusbridge.WithGatherer(gatherer))),
+		}
+		if cfg.Timeout > 0 {
+			readerOpts = append(readerOpts, sdkmetric.WithTimeout(cfg.Timeout))
+		}
+		opts = append(opts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, readerOpts...)))
+	}
+
+	return &Exporter{
+		provider: sdkmetric.NewMeterProvider(opts...),
+		config:   cfg,
+	}, nil
+}
+
+// applyConfigDefaults sets default values for empty config fields.
+func applyConfigDefaults(cfg *Config, tracingCfg *tracing.Config) {
+	if cfg.Exporter == "" {
+		cfg.Exporter = tracing.SignalExporter[ExporterType](tracingCfg)
+	}
+	if cfg.URLPath == "" {
+		cfg.URLPath = tracing.SignalURLPath(tracingCfg, DefaultURLPath)
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+}
+
+// createExporter creates a metric exporter based on configuration.
+func createExporter(ctx context.Context, cfg *Config, tracingCfg *tracing.Config) (sdkmetric.Exporter, error) {
+	return tracing.NewOTLPExporter(ctx, cfg.Exporter, tracing.OTLPExporterFuncs[sdkmetric.Exporter]{
+		HTTP: func(ctx context.Context) (sdkmetric.Exporter, error) { return createHTTPExporter(ctx, cfg, tracingCfg) },
+		GRPC: func(ctx context.Context) (sdkmetric.Exporter, error) { return createGRPCExporter(ctx, tracingCfg) },
+	})
+}
+
+// createHTTPExporter creates an OTLP HTTP metric exporter.
+func createHTTPExporter(ctx context.Context, cfg *Config, tracingCfg *tracing.Config) (sdkmetric.Exporter, error) {
+	opts, err := tracing.OTLPOptions(tracingCfg, tracing.OTLPOptionFuncs[otlpmetrichttp.Option]{
+		Endpoint: otlpmetrichttp.WithEndpoint,
+		Insecure: otlpmetrichttp.WithInsecure,
+		Headers:  otlpmetrichttp.WithHeaders,
+		TLS:      otlpmetrichttp.WithTLSClientConfig,
+		Gzip: func() otlpmetrichttp.Option {
+			return otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression)
+		},
+		Timeout: otlpmetrichttp.WithTimeout,
+		Retry: func(retry tracing.RetryConfig) otlpmetrichttp.Option {
+			return otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
+				Enabled:         !retry.Disabled,
+				InitialInterval: retry.InitialInterval,
+				MaxInterval:     retry.MaxInterval,
+				MaxElapsedTime:  retry.MaxElapsedTime,
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, otlpmetrichttp.WithURLPath(cfg.URLPath))
+	exporter, err := otlpmetrichttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP HTTP metric exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// createGRPCExporter creates an OTLP gRPC metric exporter.
+func createGRPCExporter(ctx context.Context, tracingCfg *tracing.Config) (sdkmetric.Exporter, error) {
+	opts, err := tracing.OTLPOptions(tracingCfg, tracing.OTLPOptionFuncs[otlpmetricgrpc.Option]{
+		Endpoint: otlpmetricgrpc.WithEndpoint,
+		Insecure: otlpmetricgrpc.WithInsecure,
+		Headers:  otlpmetricgrpc.WithHeaders,
+		TLS: func(tlsCfg *tls.Config) otlpmetricgrpc.Option {
+			return otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg))
+		},
+		Gzip: func() otlpmetricgrpc.Option {
+			return otlpmetricgrpc.WithCompressor(string(tracing.CompressionGzip))
+		},
+		Timeout: otlpmetricgrpc.WithTimeout,
+		Retry: func(retry tracing.RetryConfig) otlpmetricgrpc.Option {
+			return otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig{
+				Enabled:         !retry.Disabled,
+				InitialInterval: retry.InitialInterval,
+				MaxInterval:     retry.MaxInterval,
+				MaxElapsedTime:  retry.MaxElapsedTime,
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP gRPC metric exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// MeterProvider returns the underlying OpenTelemetry meter provider.
+func (e *Exporter) MeterProvider() *sdkmetric.MeterProvider {
+	return e.provider
+}
+
+// Config returns the effective configuration.
+func (e *Exporter) Config() Config {
+	return e.config
+}
+
+// ForceFlush gathers the registry and exports it immediately.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return e.provider.ForceFlush(ctx)
+}
+
+// Shutdown exports the registry a final time and shuts down the exporter.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return e.provider.Shutdown(ctx)
+}
@@ -0,0 +1,305 @@
+package otlp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	collectormetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
+)
+
+// testTracingConfig returns a tracing configuration that exports nothing.
+func testTracingConfig(serviceName string) tracing.Config {
+	return tracing.Config{
+		ServiceName:              serviceName,
+		Exporter:                 tracing.ExporterNone,
+		DisableResourceDetection: true,
+	}
+}
+
+// fakeCollector is an OTLP HTTP metrics receiver that records exports in memory.
+type fakeCollector struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	paths    []string
+	requests []*collectormetricspb.ExportMetricsServiceRequest
+}
+
+func newFakeCollector(t *testing.T) *fakeCollector {
+	t.Helper()
+
+	c := &fakeCollector{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read export body: %v", err)
+		}
+		req := &collectormetricspb.ExportMetricsServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			t.Errorf("decode export body: %v", err)
+		}
+
+		c.mu.Lock()
+		c.paths = append(c.paths, r.URL.Path)
+		c.requests = append(c.requests, req)
+		c.mu.Unlock()
+
+		resp, _ := proto.Marshal(&collectormetricspb.ExportMetricsServiceResponse{})
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = w.Write(resp)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+// tracingConfig returns a tracing configuration exporting to the collector.
+func (c *fakeCollector) tracingConfig(serviceName string) tracing.Config {
+	cfg := testTracingConfig(serviceName)
+	cfg.Exporter = tracing.ExporterOTLPHTTP
+	cfg.Endpoint = strings.TrimPrefix(c.URL, "http://")
+	cfg.Insecure = true
+	return cfg
+}
+
+// lastMetrics returns the metrics of the last export by name.
+func (c *fakeCollector) lastMetrics() map[string]*metricspb.Metric {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make(map[string]*metricspb.Metric)
+	if len(c.requests) == 0 {
+		return out
+	}
+	for _, rm := range c.requests[len(c.requests)-1].GetResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				out[m.GetName()] = m
+			}
+		}
+	}
+	return out
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	metricsCfg := metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry())
+
+	exporter, err := New(ctx, metricsCfg, testTracingConfig("test-service"), DefaultConfig())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer exporter.Shutdown(ctx)
+
+	if exporter.MeterProvider() == nil {
+		t.Error("MeterProvider() returned nil")
+	}
+	cfg := exporter.Config()
+	if cfg.Exporter != ExporterNone {
+		t.Errorf("Exporter = %q, want %q from tracing config", cfg.Exporter, ExporterNone)
+	}
+	if cfg.URLPath != DefaultURLPath {
+		t.Errorf("URLPath = %q, want %q", cfg.URLPath, DefaultURLPath)
+	}
+	if err := exporter.ForceFlush(ctx); err != nil {
+		t.Errorf("ForceFlush() error = %v", err)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	metricsCfg := metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry())
+
+	if _, err := New(ctx, metricsCfg, testTracingConfig("test-service"), Config{Exporter: "statsd"}); err == nil {
+		t.Error("New() with invalid metric exporter should fail")
+	}
+	if _, err := New(ctx, metricsCfg, testTracingConfig(""), DefaultConfig()); err == nil {
+		t.Error("New() with invalid tracing config should fail")
+	}
+
+	registerer := prometheus.WrapRegistererWith(prometheus.Labels{"env": "test"}, prometheus.NewRegistry())
+	if _, err := New(ctx, metricsCfg.WithRegistry(registerer), testTracingConfig("test-service"), DefaultConfig()); err == nil {
+		t.Error("New() with a registry that cannot gather should fail")
+	}
+}
+
+func TestNew_ExporterFromTracing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tracing tracing.ExporterType
+		want    ExporterType
+	}{
+		{"", ExporterOTLPHTTP},
+		{tracing.ExporterOTLPHTTP, ExporterOTLPHTTP},
+		{tracing.ExporterOTLPGRPC, ExporterOTLPGRPC},
+		{tracing.ExporterNone, ExporterNone},
+	}
+	for _, tt := range tests {
+		cfg := Config{}
+		tracingCfg := testTracingConfig("test-service")
+		tracingCfg.Exporter = tt.tracing
+		applyConfigDefaults(&cfg, &tracingCfg)
+		if cfg.Exporter != tt.want {
+			t.Errorf("tracing exporter %q: metric exporter = %q, want %q", tt.tracing, cfg.Exporter, tt.want)
+		}
+		if cfg.Interval != DefaultInterval {
+			t.Errorf("Interval = %v, want %v", cfg.Interval, DefaultInterval)
+		}
+	}
+}
+
+func TestNew_OTLPExporters(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for _, exporter := range []ExporterType{ExporterOTLPHTTP, ExporterOTLPGRPC} {
+		tracingCfg := testTracingConfig("test-service")
+		tracingCfg.Endpoint = "localhost:4318"
+		tracingCfg.Insecure = true
+		tracingCfg.Headers = map[string]string{"Authorization": "Bearer token"}
+		tracingCfg.Compression = tracing.CompressionGzip
+		tracingCfg.Retry = tracing.RetryConfig{Disabled: true}
+
+		cfg := DefaultConfig()
+		cfg.Exporter = exporter
+
+		metricsCfg := metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry())
+		e, err := New(ctx, metricsCfg, tracingCfg, cfg)
+		if err != nil {
+			t.Fatalf("New(%s) error = %v", exporter, err)
+		}
+		e.MeterProvider().Shutdown(ctx) //nolint:errcheck // nothing listens on the endpoint
+	}
+}
+
+func TestExporter_PrefixedEndpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	collector := newFakeCollector(t)
+	metricsCfg := metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry())
+
+	tracingCfg := collector.tracingConfig("ride-service")
+	tracingCfg.URLPath = "/otlp/v1/traces"
+	exporter, err := New(ctx, metricsCfg, tracingCfg, DefaultConfig())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer exporter.Shutdown(ctx)
+
+	if err := exporter.ForceFlush(ctx); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	collector.mu.Lock()
+	paths := append([]string(nil), collector.paths...)
+	collector.mu.Unlock()
+	if len(paths) != 1 || paths[0] != "/otlp/v1/metrics" {
+		t.Errorf("export paths = %v, want [/otlp/v1/metrics]", paths)
+	}
+}
+
+func TestExporter_ConvertsMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	collector := newFakeCollector(t)
+	registry := prometheus.NewRegistry()
+	metricsCfg := metrics.DefaultConfig().WithRegistry(registry)
+
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "txova", Name: "requests_total", Help: "Requests.",
+	}, []string{"method"})
+	inflight := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "txova", Name: "requests_in_flight", Help: "In-flight requests.",
+	})
+	duration := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "txova", Name: "request_duration_seconds", Help: "Request duration.",
+		Buckets: []float64{0.1, 1},
+	})
+	registry.MustRegister(requests, inflight, duration)
+
+	requests.WithLabelValues("GET").Add(3)
+	inflight.Set(2)
+	duration.Observe(0.05)
+	duration.Observe(0.5)
+	duration.Observe(5)
+
+	cfg := DefaultConfig()
+	cfg.WithInterval(time.Hour)
+	exporter, err := New(ctx, metricsCfg, collector.tracingConfig("ride-service"), cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer exporter.Shutdown(ctx)
+
+	if err := exporter.ForceFlush(ctx); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	collector.mu.Lock()
+	paths := append([]string(nil), collector.paths...)
+	resourceAttrs := collector.requests[0].GetResourceMetrics()[0].GetResource().GetAttributes()
+	collector.mu.Unlock()
+	if len(paths) != 1 || paths[0] != DefaultURLPath {
+		t.Fatalf("export paths = %v, want [%s]", paths, DefaultURLPath)
+	}
+	var serviceName string
+	for _, kv := range resourceAttrs {
+		if kv.GetKey() == "service.name" {
+			serviceName = kv.GetValue().GetStringValue()
+		}
+	}
+	if serviceName != "ride-service" {
+		t.Errorf("service.name = %q, want ride-service", serviceName)
+	}
+
+	got := collector.lastMetrics()
+
+	sum := got["txova_requests_total"].GetSum()
+	if sum == nil || !sum.GetIsMonotonic() ||
+		sum.GetAggregationTemporality() != metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE {
+		t.Fatalf("txova_requests_total = %v, want a monotonic cumulative sum", got["txova_requests_total"])
+	}
+	point := sum.GetDataPoints()[0]
+	if point.GetAsDouble() != 3 || point.GetAttributes()[0].GetValue().GetStringValue() != "GET" {
+		t.Errorf("txova_requests_total point = %v, want 3 with method=GET", point)
+	}
+
+	gauge := got["txova_requests_in_flight"].GetGauge()
+	if gauge == nil || gauge.GetDataPoints()[0].GetAsDouble() != 2 {
+		t.Errorf("txova_requests_in_flight = %v, want gauge 2", got["txova_requests_in_flight"])
+	}
+
+	hist := got["txova_request_duration_seconds"].GetHistogram()
+	if hist == nil {
+		t.Fatalf("txova_request_duration_seconds = %v, want histogram", got["txova_request_duration_seconds"])
+	}
+	hp := hist.GetDataPoints()[0]
+	if hp.GetCount() != 3 || hp.GetSum() != 5.55 {
+		t.Errorf("histogram count/sum = %d/%v, want 3/5.55", hp.GetCount(), hp.GetSum())
+	}
+	if bounds := hp.GetExplicitBounds(); len(bounds) != 2 || bounds[0] != 0.1 || bounds[1] != 1 {
+		t.Errorf("histogram bounds = %v, want [0.1 1]", bounds)
+	}
+	if counts := hp.GetBucketCounts(); len(counts) != 3 || counts[0] != 1 || counts[1] != 1 || counts[2] != 1 {
+		t.Errorf("histogram bucket counts = %v, want [1 1 1]", counts)
+	}
+}
@@ -8,10 +8,10 @@ import (
 
 // PaymentCollector collects payment-related business metrics.
 type PaymentCollector struct {
-	paymentsTotal  *prometheus.CounterVec
-	paymentAmount  *prometheus.HistogramVec
-	processingTime *prometheus.HistogramVec
-	refundsTotal   *prometheus.CounterVec
+	paymentsTotal  *counterVec
+	paymentAmount  *histogramVec
+	processingTime *histogramVec
+	refundsTotal   *counterVec
 }
 
 // NewPaymentCollector creates a new PaymentCollector with the given configuration.
@@ -23,56 +23,60 @@ func NewPaymentCollector(cfg Config) (*PaymentCollector, error) {
 
 	c := &PaymentCollector{}
 
-	c.paymentsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.paymentsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "payments_total",
-			Help:      "Total number of payment attempts.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "payments_total",
+			Help:        "Total number of payment attempts.",
 		},
 		[]string{"method", "status"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.paymentAmount, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.paymentAmount, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "payment_amount_mzn",
-			Help:      "Payment amounts in MZN (smallest currency unit).",
-			Buckets:   PaymentAmountBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "payment_amount_mzn",
+			Help:        "Payment amounts in MZN (smallest currency unit).",
+			Buckets:     PaymentAmountBuckets,
 		},
 		[]string{"method"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.processingTime, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.processingTime, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "payment_processing_seconds",
-			Help:      "Payment processing time in seconds.",
-			Buckets:   HTTPLatencyBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "payment_processing_seconds",
+			Help:        "Payment processing time in seconds.",
+			Buckets:     HTTPLatencyBuckets,
 		},
 		[]string{"method"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.refundsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.refundsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "refunds_total",
-			Help:      "Total number of refunds issued.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "refunds_total",
+			Help:        "Total number of refunds issued.",
 		},
 		[]string{"reason"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
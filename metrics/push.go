@@ -0,0 +1,260 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultPushTimeout is the default timeout for a single push.
+const DefaultPushTimeout = 10 * time.Second
+
+// PushConfig holds configuration for pushing metrics to a Prometheus Pushgateway.
+type PushConfig struct {
+	// URL is the Pushgateway base URL (e.g., "http://pushgateway:9091").
+	URL string
+
+	// Job is the job grouping label. Required.
+	Job string
+
+	// Grouping holds additional grouping labels (e.g., "instance").
+	Grouping map[string]string
+
+	// Interval is how often Start pushes. Zero pushes only on Push and Close.
+	Interval time.Duration
+
+	// Timeout bounds each push. Default: DefaultPushTimeout.
+	Timeout time.Duration
+
+	// Username and Password enable basic auth when Username is set.
+	Username string
+	Password string
+
+	// Logger logs failed periodic pushes. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// WithURL returns a new PushConfig with the specified Pushgateway URL.
+func (c PushConfig) WithURL(url string) PushConfig {
+	c.URL = url
+	return c
+}
+
+// WithJob returns a new PushConfig with the specified job label.
+func (c PushConfig) WithJob(job string) PushConfig {
+	c.Job = job
+	return c
+}
+
+// WithGrouping returns a new PushConfig with an additional grouping label.
+func (c PushConfig) WithGrouping(name, value string) PushConfig {
+	grouping := make(map[string]string, len(c.Grouping)+1)
+	for k, v := range c.Grouping {
+		grouping[k] = v
+	}
+	grouping[name] = value
+	c.Grouping = grouping
+	return c
+}
+
+// WithInterval returns a new PushConfig with the specified push interval.
+func (c PushConfig) WithInterval(interval time.Duration) PushConfig {
+	c.Interval = interval
+	return c
+}
+
+// WithTimeout returns a new PushConfig with the specified push timeout.
+func (c PushConfig) WithTimeout(timeout time.Duration) PushConfig {
+	c.Timeout = timeout
+	return c
+}
+
+// WithBasicAuth returns a new PushConfig with basic auth credentials.
+func (c PushConfig) WithBasicAuth(username, password string) PushConfig {
+	c.Username = username
+	c.Password = password
+	return c
+}
+
+// Validate checks that the configuration is valid and returns a copy with defaults applied.
+func (c PushConfig) Validate() (PushConfig, error) {
+	if c.URL == "" {
+		return c, fmt.Errorf("pushgateway URL is required")
+	}
+	if c.Job == "" {
+		return c, fmt.Errorf("pushgateway job is required")
+	}
+	if c.Interval < 0 || c.Timeout < 0 {
+		return c, fmt.Errorf("push interval and timeout must not be negative")
+	}
+	if c.Timeout == 0 {
+		c.Timeout = DefaultPushTimeout
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c, nil
+}
+
+// Pusher pushes the metrics of a registry to a Pushgateway, periodically
+// and on Close, for processes that exit before they can be scraped.
+type Pusher struct {
+	pusher *push.Pusher
+	config PushConfig
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPusher creates a Pusher for the registry in cfg, which must also
+// implement prometheus.Gatherer (as *prometheus.Registry does).
+//
+// Metric labels that clash with the job or grouping labels are renamed with
+// an "exported_" prefix, as Prometheus does when scraping, because the
+// Pushgateway rejects them otherwise.
+func NewPusher(cfg Config, pushCfg PushConfig) (*Pusher, error) { //nolint:gocritic // pushCfg passed by value for API simplicity
+	cfg, err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	pushCfg, err = pushCfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	gatherer, err := cfg.Gatherer()
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := map[string]bool{"job": true}
+	pusher := push.New(pushCfg.URL, pushCfg.Job).
+		Client(&http.Client{Timeout: pushCfg.Timeout})
+	for name, value := range pushCfg.Grouping {
+		pusher = pusher.Grouping(name, value)
+		reserved[name] = true
+	}
+	pusher = pusher.Gatherer(renameLabels(gatherer, reserved))
+	if pushCfg.Username != "" {
+		pusher = pusher.BasicAuth(pushCfg.Username, pushCfg.Password)
+	}
+	if err := pusher.Error(); err != nil {
+		return nil, fmt.Errorf("invalid pushgateway grouping: %w", err)
+	}
+
+	return &Pusher{pusher: pusher, config: pushCfg}, nil
+}
+
+// renameLabels returns a gatherer that prefixes the reserved label names
+// with "exported_".
+func renameLabels(g prometheus.Gatherer, reserved map[string]bool) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families, err := g.Gather()
+		for i, family := range families {
+			if !hasReservedLabel(family, reserved) {
+				continue
+			}
+			family, ok := proto.Clone(family).(*dto.MetricFamily)
+			if !ok {
+				continue
+			}
+			for _, m := range family.GetMetric() {
+				for _, l := range m.GetLabel() {
+					if reserved[l.GetName()] {
+						l.Name = proto.String("exported_" + l.GetName())
+					}
+				}
+			}
+			families[i] = family
+		}
+		return families, err
+	})
+}
+
+// hasReservedLabel reports whether any metric in the family uses a reserved label.
+func hasReservedLabel(family *dto.MetricFamily, reserved map[string]bool) bool {
+	for _, m := range family.GetMetric() {
+		for _, l := range m.GetLabel() {
+			if reserved[l.GetName()] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Push pushes the current metrics, replacing the metrics previously pushed
+// with the same grouping labels.
+func (p *Pusher) Push(ctx context.Context) error {
+	if err := p.pusher.PushContext(ctx); err != nil {
+		return fmt.Errorf("failed to push metrics to %s: %w", p.config.URL, err)
+	}
+	return nil
+}
+
+// Start pushes every Interval in the background until Stop or Close is
+// called or ctx is canceled. It does nothing when Interval is zero or the
+// pusher is already running.
+func (p *Pusher) Start(ctx context.Context) {
+	if p.config.Interval <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.run(ctx, p.done)
+}
+
+// run pushes on every tick until ctx is canceled.
+func (p *Pusher) run(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Push(ctx); err != nil {
+				p.config.Logger.WarnContext(ctx, "periodic metrics push failed", "error", err)
+			}
+		}
+	}
+}
+
+// Stop stops periodic pushing and waits for an in-flight push to finish.
+func (p *Pusher) Stop() {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.cancel, p.done = nil, nil
+	p.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// Close stops periodic pushing and pushes the final metrics.
+func (p *Pusher) Close(ctx context.Context) error {
+	p.Stop()
+	return p.Push(ctx)
+}
@@ -0,0 +1,264 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// pushRequest is a request received by the fake Pushgateway.
+type pushRequest struct {
+	method   string
+	path     string
+	user     string
+	password string
+	body     string
+}
+
+// fakePushgateway records pushes in memory.
+type fakePushgateway struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []pushRequest
+	status   int
+}
+
+func newFakePushgateway(t *testing.T) *fakePushgateway {
+	t.Helper()
+
+	gw := &fakePushgateway{status: http.StatusOK}
+	gw.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := decodePushBody(t, r)
+		user, password, _ := r.BasicAuth()
+
+		gw.mu.Lock()
+		defer gw.mu.Unlock()
+		gw.requests = append(gw.requests, pushRequest{
+			method:   r.Method,
+			path:     r.URL.Path,
+			user:     user,
+			password: password,
+			body:     body,
+		})
+		w.WriteHeader(gw.status)
+	}))
+	t.Cleanup(gw.Close)
+	return gw
+}
+
+// decodePushBody converts the pushed protobuf metric families to text.
+func decodePushBody(t *testing.T, r *http.Request) string {
+	t.Helper()
+
+	var out strings.Builder
+	decoder := expfmt.NewDecoder(r.Body, expfmt.ResponseFormat(r.Header))
+	encoder := expfmt.NewEncoder(&out, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for {
+		family := &dto.MetricFamily{}
+		if err := decoder.Decode(family); err != nil {
+			if err != io.EOF {
+				t.Errorf("decode pushed metrics: %v", err)
+			}
+			break
+		}
+		if err := encoder.Encode(family); err != nil {
+			t.Errorf("encode pushed metrics: %v", err)
+		}
+	}
+	return out.String()
+}
+
+func (gw *fakePushgateway) Requests() []pushRequest {
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+	return append([]pushRequest(nil), gw.requests...)
+}
+
+func (gw *fakePushgateway) SetStatus(status int) {
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+	gw.status = status
+}
+
+func TestPushConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     PushConfig
+		wantErr bool
+	}{
+		{"valid", PushConfig{URL: "http://pushgateway:9091", Job: "backfill"}, false},
+		{"missing url", PushConfig{Job: "backfill"}, true},
+		{"missing job", PushConfig{URL: "http://pushgateway:9091"}, true},
+		{"negative interval", PushConfig{URL: "http://pushgateway:9091", Job: "backfill", Interval: -time.Second}, true},
+		{"negative timeout", PushConfig{URL: "http://pushgateway:9091", Job: "backfill", Timeout: -time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (cfg.Timeout != DefaultPushTimeout || cfg.Logger == nil) {
+				t.Errorf("Validate() = %+v, want defaults applied", cfg)
+			}
+		})
+	}
+}
+
+func TestPushConfig_Setters(t *testing.T) {
+	t.Parallel()
+
+	base := PushConfig{}.WithGrouping("instance", "worker-1")
+	cfg := base.
+		WithURL("http://pushgateway:9091").
+		WithJob("backfill").
+		WithGrouping("region", "maputo").
+		WithInterval(time.Minute).
+		WithTimeout(time.Second).
+		WithBasicAuth("user", "secret")
+
+	if cfg.URL != "http://pushgateway:9091" || cfg.Job != "backfill" {
+		t.Errorf("URL/Job = %q/%q", cfg.URL, cfg.Job)
+	}
+	if cfg.Grouping["instance"] != "worker-1" || cfg.Grouping["region"] != "maputo" {
+		t.Errorf("Grouping = %v", cfg.Grouping)
+	}
+	if _, ok := base.Grouping["region"]; ok {
+		t.Error("WithGrouping modified the original config")
+	}
+	if cfg.Interval != time.Minute || cfg.Timeout != time.Second {
+		t.Errorf("Interval/Timeout = %v/%v", cfg.Interval, cfg.Timeout)
+	}
+	if cfg.Username != "user" || cfg.Password != "secret" {
+		t.Errorf("basic auth = %q/%q", cfg.Username, cfg.Password)
+	}
+}
+
+func TestPusher_Push(t *testing.T) {
+	t.Parallel()
+
+	gw := newFakePushgateway(t)
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_push")
+
+	jobs, err := NewJobCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewJobCollector() error = %v", err)
+	}
+	jobs.RecordRun("backfill", JobStatusSuccess, time.Second)
+
+	pusher, err := NewPusher(cfg, PushConfig{
+		URL:      gw.URL,
+		Job:      "migrations",
+		Grouping: map[string]string{"instance": "worker-1"},
+		Username: "user",
+		Password: "secret",
+	})
+	if err != nil {
+		t.Fatalf("NewPusher() error = %v", err)
+	}
+
+	if err := pusher.Push(context.Background()); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	requests := gw.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("requests = %d, want 1", len(requests))
+	}
+	req := requests[0]
+	if req.method != http.MethodPut {
+		t.Errorf("method = %s, want PUT", req.method)
+	}
+	if req.path != "/metrics/job/migrations/instance/worker-1" {
+		t.Errorf("path = %s", req.path)
+	}
+	if req.user != "user" || req.password != "secret" {
+		t.Errorf("basic auth = %q/%q", req.user, req.password)
+	}
+	if !strings.Contains(req.body, `txova_test_push_job_runs_total{exported_job="backfill",status="success"} 1`) {
+		t.Errorf("body missing renamed job label:\n%s", req.body)
+	}
+}
+
+func TestPusher_PushError(t *testing.T) {
+	t.Parallel()
+
+	gw := newFakePushgateway(t)
+	gw.SetStatus(http.StatusInternalServerError)
+
+	cfg := DefaultConfig().WithRegistry(prometheus.NewRegistry())
+	pusher, err := NewPusher(cfg, PushConfig{URL: gw.URL, Job: "migrations"})
+	if err != nil {
+		t.Fatalf("NewPusher() error = %v", err)
+	}
+	if err := pusher.Push(context.Background()); err == nil {
+		t.Error("Push() should fail when the Pushgateway returns an error")
+	}
+}
+
+func TestNewPusher_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig().WithRegistry(prometheus.NewRegistry())
+
+	if _, err := NewPusher(cfg, PushConfig{Job: "migrations"}); err == nil {
+		t.Error("NewPusher() without URL should fail")
+	}
+	if _, err := NewPusher(cfg, PushConfig{URL: "http://pushgateway:9091", Job: "migrations", Grouping: map[string]string{"": "x"}}); err == nil {
+		t.Error("NewPusher() with an invalid grouping label should fail")
+	}
+
+	registerer := prometheus.WrapRegistererWith(prometheus.Labels{"env": "test"}, prometheus.NewRegistry())
+	if _, err := NewPusher(cfg.WithRegistry(registerer), PushConfig{URL: "http://pushgateway:9091", Job: "migrations"}); err == nil {
+		t.Error("NewPusher() with a registry that cannot gather should fail")
+	}
+}
+
+func TestPusher_StartClose(t *testing.T) {
+	t.Parallel()
+
+	gw := newFakePushgateway(t)
+	cfg := DefaultConfig().WithRegistry(prometheus.NewRegistry())
+
+	pusher, err := NewPusher(cfg, PushConfig{URL: gw.URL, Job: "migrations", Interval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewPusher() error = %v", err)
+	}
+
+	ctx := context.Background()
+	pusher.Start(ctx)
+	pusher.Start(ctx) // Already running: no second loop.
+
+	deadline := time.Now().Add(time.Second)
+	for len(gw.Requests()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(gw.Requests()) < 2 {
+		t.Fatal("periodic pushes did not happen")
+	}
+
+	if err := pusher.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	afterClose := len(gw.Requests())
+	time.Sleep(30 * time.Millisecond)
+	if got := len(gw.Requests()); got != afterClose {
+		t.Errorf("requests after Close = %d, want %d (periodic pushes stopped)", got, afterClose)
+	}
+}
@@ -8,10 +8,10 @@ import (
 
 // RedisCollector collects Redis metrics.
 type RedisCollector struct {
-	commandsTotal   *prometheus.CounterVec
-	commandDuration *prometheus.HistogramVec
-	cacheHitsTotal  *prometheus.CounterVec
-	cacheMissTotal  *prometheus.CounterVec
+	commandsTotal   *counterVec
+	commandDuration *histogramVec
+	cacheHitsTotal  *counterVec
+	cacheMissTotal  *counterVec
 }
 
 // NewRedisCollector creates a new RedisCollector with the given configuration.
@@ -23,55 +23,59 @@ func NewRedisCollector(cfg Config) (*RedisCollector, error) {
 
 	c := &RedisCollector{}
 
-	c.commandsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.commandsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "redis_commands_total",
-			Help:      "Total number of Redis commands executed.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "redis_commands_total",
+			Help:        "Total number of Redis commands executed.",
 		},
 		[]string{"command"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.commandDuration, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.commandDuration, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "redis_command_duration_seconds",
-			Help:      "Redis command latency in seconds.",
-			Buckets:   DBLatencyBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "redis_command_duration_seconds",
+			Help:        "Redis command latency in seconds.",
+			Buckets:     DBLatencyBuckets,
 		},
 		[]string{"command"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.cacheHitsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.cacheHitsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "redis_cache_hits_total",
-			Help:      "Total number of cache hits.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "redis_cache_hits_total",
+			Help:        "Total number of cache hits.",
 		},
 		[]string{"cache"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.cacheMissTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.cacheMissTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "redis_cache_misses_total",
-			Help:      "Total number of cache misses.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "redis_cache_misses_total",
+			Help:        "Total number of cache misses.",
 		},
 		[]string{"cache"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Remote-write request result label values.
+const (
+	RemoteWriteResultSuccess  = "success"
+	RemoteWriteResultRetry    = "retry"
+	RemoteWriteResultRejected = "rejected"
+)
+
+// Remote-write drop reason label values.
+const (
+	RemoteWriteDropBufferFull = "buffer_full"
+	RemoteWriteDropRejected   = "rejected"
+	RemoteWriteDropBufferIO   = "buffer_io"
+)
+
+// RemoteWriteCollector collects self-metrics of the Prometheus remote-write client.
+type RemoteWriteCollector struct {
+	requestsTotal  *counterVec
+	duration       *histogramVec
+	samplesSent    *counterVec
+	samplesDropped *counterVec
+	queueRequests  *gaugeVec
+	queueBytes     *gaugeVec
+}
+
+// NewRemoteWriteCollector creates a new RemoteWriteCollector with the given configuration.
+func NewRemoteWriteCollector(cfg Config) (*RemoteWriteCollector, error) {
+	cfg, err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &RemoteWriteCollector{}
+
+	c.requestsTotal, err = newCounterVec(cfg,
+		prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "remote_write_requests_total",
+			Help:        "Total number of remote-write requests by result.",
+		},
+		[]string{"result"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.duration, err = newHistogramVec(cfg,
+		prometheus.HistogramOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "remote_write_request_duration_seconds",
+			Help:        "Remote-write request duration in seconds.",
+			Buckets:     HTTPLatencyBuckets,
+		},
+		[]string{"result"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.samplesSent, err = newCounterVec(cfg,
+		prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "remote_write_samples_sent_total",
+			Help:        "Total number of samples accepted by the remote-write endpoint.",
+		},
+		[]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.samplesDropped, err = newCounterVec(cfg,
+		prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "remote_write_samples_dropped_total",
+			Help:        "Total number of samples dropped without being sent, by reason.",
+		},
+		[]string{"reason"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.queueRequests, err = newGaugeVec(cfg,
+		prometheus.GaugeOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "remote_write_queue_requests",
+			Help:        "Number of remote-write requests buffered for sending.",
+		},
+		[]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.queueBytes, err = newGaugeVec(cfg,
+		prometheus.GaugeOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "remote_write_queue_bytes",
+			Help:        "Size in bytes of the compressed remote-write requests buffered for sending.",
+		},
+		[]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// RecordRequest records a remote-write request.
+// result: "success", "retry" (failed, will be retried) or "rejected" (failed, dropped).
+func (c *RemoteWriteCollector) RecordRequest(result string, duration time.Duration) {
+	c.requestsTotal.WithLabelValues(result).Inc()
+	c.duration.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// RecordSamplesSent records samples accepted by the endpoint.
+func (c *RemoteWriteCollector) RecordSamplesSent(count int) {
+	c.samplesSent.WithLabelValues().Add(float64(count))
+}
+
+// RecordSamplesDropped records samples dropped without being sent.
+// reason: "buffer_full", "rejected" or "buffer_io".
+func (c *RemoteWriteCollector) RecordSamplesDropped(reason string, count int) {
+	c.samplesDropped.WithLabelValues(reason).Add(float64(count))
+}
+
+// SetQueue sets the number and total size of buffered requests.
+func (c *RemoteWriteCollector) SetQueue(requests int, bytes int64) {
+	c.queueRequests.WithLabelValues().Set(float64(requests))
+	c.queueBytes.WithLabelValues().Set(float64(bytes))
+}
+
+// Describe implements prometheus.Collector.
+func (c *RemoteWriteCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.requestsTotal.Describe(ch)
+	c.duration.Describe(ch)
+	c.samplesSent.Describe(ch)
+	c.samplesDropped.Describe(ch)
+	c.queueRequests.Describe(ch)
+	c.queueBytes.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *RemoteWriteCollector) Collect(ch chan<- prometheus.Metric) {
+	c.requestsTotal.Collect(ch)
+	c.duration.Collect(ch)
+	c.samplesSent.Collect(ch)
+	c.samplesDropped.Collect(ch)
+	c.queueRequests.Collect(ch)
+	c.queueBytes.Collect(ch)
+}
@@ -0,0 +1,206 @@
+package remotewrite
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// bufferFileExt is the extension of buffered request files.
+const bufferFileExt = ".rw"
+
+// entry is a buffered, compressed remote-write request.
+type entry struct {
+	seq     uint64
+	samples int
+	size    int64
+	data    []byte // nil when stored on disk
+}
+
+// buffer is a bounded FIFO of compressed requests, kept in memory or in
+// files under dir. When a new request does not fit, the oldest ones are
+// dropped.
+type buffer struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries []entry
+	bytes   int64
+	nextSeq uint64
+}
+
+// openBuffer creates a buffer. With a directory, requests left over from a
+// previous process are loaded, oldest first.
+func openBuffer(dir string, maxBytes int64) (*buffer, error) {
+	b := &buffer{dir: dir, maxBytes: maxBytes}
+	if dir == "" {
+		return b, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create remote-write buffer directory: %w", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read remote-write buffer directory: %w", err)
+	}
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), bufferFileExt+".tmp") {
+			// Left behind by a write interrupted by a crash.
+			_ = os.Remove(filepath.Join(dir, f.Name()))
+			continue
+		}
+		if f.IsDir() || !strings.HasSuffix(f.Name(), bufferFileExt) {
+			continue
+		}
+		var e entry
+		if _, err := fmt.Sscanf(f.Name(), "%d-%d"+bufferFileExt, &e.seq, &e.samples); err != nil {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		e.size = info.Size()
+		b.entries = append(b.entries, e)
+		b.bytes += e.size
+	}
+	sort.Slice(b.entries, func(i, j int) bool { return b.entries[i].seq < b.entries[j].seq })
+	if n := len(b.entries); n > 0 {
+		b.nextSeq = b.entries[n-1].seq + 1
+	}
+	b.evict(0)
+	return b, nil
+}
+
+// push appends a request and returns the number of samples dropped to make
+// room for it, including its own when it is larger than the buffer.
+func (b *buffer) push(data []byte, samples int) (int, error) {
+	size := int64(len(data))
+	if size > b.maxBytes {
+		return samples, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Write before evicting, so a failed write does not drop older requests
+	// too. The sequence number is not reused after a failure.
+	e := entry{seq: b.nextSeq, samples: samples, size: size, data: data}
+	b.nextSeq++
+	if b.dir != "" {
+		if err := writeFileAtomic(b.path(e), data); err != nil {
+			return samples, err
+		}
+		e.data = nil
+	}
+	dropped := b.evict(size)
+	b.entries = append(b.entries, e)
+	b.bytes += size
+	return dropped, nil
+}
+
+// evict drops the oldest requests until size more bytes fit and returns the
+// number of samples dropped. The caller must hold b.mu.
+func (b *buffer) evict(size int64) int {
+	dropped := 0
+	for len(b.entries) > 0 && b.bytes+size > b.maxBytes {
+		dropped += b.entries[0].samples
+		b.removeAt(0)
+	}
+	return dropped
+}
+
+// peek returns the oldest request and its data. A request evicted by a
+// concurrent push while its file is read is skipped, as its samples were
+// already counted as dropped.
+func (b *buffer) peek() (entry, []byte, bool, error) {
+	for {
+		b.mu.Lock()
+		if len(b.entries) == 0 {
+			b.mu.Unlock()
+			return entry{}, nil, false, nil
+		}
+		e := b.entries[0]
+		b.mu.Unlock()
+
+		if e.data != nil {
+			return e, e.data, true, nil
+		}
+		data, err := os.ReadFile(b.path(e))
+		if err != nil {
+			if !b.contains(e) {
+				continue
+			}
+			return e, nil, true, fmt.Errorf("failed to read buffered remote-write request: %w", err)
+		}
+		return e, data, true, nil
+	}
+}
+
+// contains reports whether a request is still buffered.
+func (b *buffer) contains(e entry) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.index(e) >= 0
+}
+
+// remove removes a request unless it has already been evicted.
+func (b *buffer) remove(e entry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if i := b.index(e); i >= 0 {
+		b.removeAt(i)
+	}
+}
+
+// index returns the position of a request, or -1 if it is no longer
+// buffered. The caller must hold b.mu.
+func (b *buffer) index(e entry) int {
+	for i := range b.entries {
+		if b.entries[i].seq == e.seq {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeAt removes the request at index i. The caller must hold b.mu.
+func (b *buffer) removeAt(i int) {
+	e := b.entries[i]
+	if b.dir != "" {
+		_ = os.Remove(b.path(e))
+	}
+	b.entries = append(b.entries[:i], b.entries[i+1:]...)
+	b.bytes -= e.size
+}
+
+// stats returns the number and total size of buffered requests.
+func (b *buffer) stats() (int, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.entries), b.bytes
+}
+
+// path returns the file path of a request stored on disk.
+func (b *buffer) path(e entry) string {
+	return filepath.Join(b.dir, fmt.Sprintf("%020d-%d%s", e.seq, e.samples, bufferFileExt))
+}
+
+// writeFileAtomic writes data to a temporary file and renames it to path,
+// so a crash never leaves a truncated request behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write buffered remote-write request: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write buffered remote-write request: %w", err)
+	}
+	return nil
+}
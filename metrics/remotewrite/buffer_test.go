@@ -0,0 +1,137 @@
+package remotewrite
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuffer_EvictsOldest(t *testing.T) {
+	t.Parallel()
+
+	for _, dir := range []string{"", t.TempDir()} {
+		b, err := openBuffer(dir, 10)
+		if err != nil {
+			t.Fatalf("openBuffer(%q) error = %v", dir, err)
+		}
+
+		for i, data := range [][]byte{[]byte("aaaa"), []byte("bbbb"), []byte("cccc")} {
+			dropped, err := b.push(data, i+1)
+			if err != nil {
+				t.Fatalf("push() error = %v", err)
+			}
+			if want := map[int]int{0: 0, 1: 0, 2: 1}[i]; dropped != want {
+				t.Errorf("push %d dropped = %d, want %d", i, dropped, want)
+			}
+		}
+		if dropped, _ := b.push(make([]byte, 11), 7); dropped != 7 {
+			t.Errorf("oversized push dropped = %d, want 7", dropped)
+		}
+
+		if count, size := b.stats(); count != 2 || size != 8 {
+			t.Errorf("stats() = %d/%d, want 2/8", count, size)
+		}
+		e, data, ok, err := b.peek()
+		if !ok || err != nil || !bytes.Equal(data, []byte("bbbb")) || e.samples != 2 {
+			t.Fatalf("peek() = %v/%q/%v/%v, want bbbb with 2 samples", e, data, ok, err)
+		}
+		b.remove(e)
+		b.remove(e) // Already removed: no-op.
+		if count, _ := b.stats(); count != 1 {
+			t.Errorf("count after remove = %d, want 1", count)
+		}
+	}
+}
+
+func TestBuffer_DiskSurvivesRestart(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	b, err := openBuffer(dir, 1<<10)
+	if err != nil {
+		t.Fatalf("openBuffer() error = %v", err)
+	}
+	for _, data := range []string{"first", "second"} {
+		if _, err := b.push([]byte(data), 3); err != nil {
+			t.Fatalf("push() error = %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "00000000000000000009-1.rw.tmp"), []byte("partial"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := openBuffer(dir, 1<<10)
+	if err != nil {
+		t.Fatalf("openBuffer() error = %v", err)
+	}
+	if count, size := reopened.stats(); count != 2 || size != int64(len("first")+len("second")) {
+		t.Errorf("stats() after restart = %d/%d", count, size)
+	}
+	e, data, _, err := reopened.peek()
+	if err != nil || string(data) != "first" || e.samples != 3 {
+		t.Errorf("peek() after restart = %q/%d/%v, want first with 3 samples", data, e.samples, err)
+	}
+	if _, err := reopened.push([]byte("third"), 1); err != nil {
+		t.Fatalf("push() error = %v", err)
+	}
+	if n := reopened.entries[len(reopened.entries)-1].seq; n != 2 {
+		t.Errorf("next sequence = %d, want 2", n)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "00000000000000000009-1.rw.tmp")); !os.IsNotExist(err) {
+		t.Error("temporary file from an interrupted write should be removed")
+	}
+}
+
+func TestBuffer_FailedWriteKeepsOlderRequests(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	b, err := openBuffer(dir, 10)
+	if err != nil {
+		t.Fatalf("openBuffer() error = %v", err)
+	}
+	if _, err := b.push([]byte("aaaa"), 1); err != nil {
+		t.Fatalf("push() error = %v", err)
+	}
+	if _, err := b.push([]byte("bbbb"), 2); err != nil {
+		t.Fatalf("push() error = %v", err)
+	}
+
+	// A directory in place of the temporary file makes the next write fail.
+	next := entry{seq: 2, samples: 3}
+	if err := os.Mkdir(b.path(next)+".tmp", 0o750); err != nil {
+		t.Fatal(err)
+	}
+	dropped, err := b.push([]byte("cccc"), 3)
+	if err == nil {
+		t.Fatal("push() should fail when the request cannot be written")
+	}
+	if dropped != 3 {
+		t.Errorf("dropped = %d, want only the 3 samples of the failed request", dropped)
+	}
+	if count, size := b.stats(); count != 2 || size != 8 {
+		t.Errorf("stats() = %d/%d, want both older requests kept", count, size)
+	}
+}
+
+func TestBuffer_PeekReportsMissingFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	b, err := openBuffer(dir, 1<<10)
+	if err != nil {
+		t.Fatalf("openBuffer() error = %v", err)
+	}
+	if _, err := b.push([]byte("first"), 1); err != nil {
+		t.Fatalf("push() error = %v", err)
+	}
+	if err := os.Remove(b.path(b.entries[0])); err != nil {
+		t.Fatal(err)
+	}
+
+	// The request is still buffered, so its unreadable file is an I/O error.
+	if _, _, ok, err := b.peek(); !ok || err == nil {
+		t.Errorf("peek() = %v/%v, want an error for the missing file", ok, err)
+	}
+}
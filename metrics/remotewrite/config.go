@@ -0,0 +1,212 @@
+// Package remotewrite sends the metrics of a Prometheus registry to a
+// Prometheus remote-write endpoint, for deployments where the services cannot
+// be scraped reliably. Samples are gathered periodically, batched into
+// snappy-compressed requests and buffered in memory or on disk, within a size
+// bound, while the endpoint is unreachable. Failed requests are retried with
+// exponential backoff.
+package remotewrite
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Default configuration values.
+const (
+	DefaultInterval             = 15 * time.Second
+	DefaultTimeout              = 10 * time.Second
+	DefaultMaxSamplesPerRequest = 2000
+	DefaultMaxBufferBytes       = 64 << 20
+	DefaultMinBackoff           = 500 * time.Millisecond
+	DefaultMaxBackoff           = time.Minute
+)
+
+// MetricsRecorder receives remote-write self-metrics.
+// *metrics.RemoteWriteCollector implements this interface.
+type MetricsRecorder interface {
+	// RecordRequest records a request with its result ("success", "retry" or "rejected").
+	RecordRequest(result string, duration time.Duration)
+	// RecordSamplesSent records samples accepted by the endpoint.
+	RecordSamplesSent(count int)
+	// RecordSamplesDropped records samples dropped without being sent.
+	RecordSamplesDropped(reason string, count int)
+	// SetQueue sets the number and total size of buffered requests.
+	SetQueue(requests int, bytes int64)
+}
+
+// Config holds configuration for the remote-write client.
+type Config struct {
+	// URL is the remote-write endpoint (e.g., "https://prometheus:9090/api/v1/write").
+	URL string
+
+	// Interval is how often the registry is gathered. Default: DefaultInterval.
+	Interval time.Duration
+
+	// Timeout bounds each request. Default: DefaultTimeout.
+	Timeout time.Duration
+
+	// MaxSamplesPerRequest limits the samples sent in one request.
+	// Default: DefaultMaxSamplesPerRequest.
+	MaxSamplesPerRequest int
+
+	// BufferDir buffers requests in files under this directory, so they
+	// survive restarts. Empty buffers in memory.
+	BufferDir string
+
+	// MaxBufferBytes bounds the compressed size of buffered requests. When
+	// the buffer is full the oldest requests are dropped.
+	// Default: DefaultMaxBufferBytes.
+	MaxBufferBytes int64
+
+	// MinBackoff and MaxBackoff bound the exponential backoff between retries.
+	// Defaults: DefaultMinBackoff and DefaultMaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// ExternalLabels are added to every series unless already present
+	// (e.g., "region", "site").
+	ExternalLabels map[string]string
+
+	// Headers are added to every request.
+	Headers map[string]string
+
+	// Username and Password enable basic auth when Username is set.
+	Username string
+	Password string
+
+	// BearerToken sets the Authorization header when not empty.
+	BearerToken string
+
+	// Metrics receives self-metrics. Optional.
+	Metrics MetricsRecorder
+
+	// Logger logs failed requests and buffer errors. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// WithURL returns a new Config with the specified endpoint URL.
+func (c Config) WithURL(url string) Config {
+	c.URL = url
+	return c
+}
+
+// WithInterval returns a new Config with the specified gather interval.
+func (c Config) WithInterval(interval time.Duration) Config {
+	c.Interval = interval
+	return c
+}
+
+// WithTimeout returns a new Config with the specified request timeout.
+func (c Config) WithTimeout(timeout time.Duration) Config {
+	c.Timeout = timeout
+	return c
+}
+
+// WithMaxSamplesPerRequest returns a new Config with the specified batch size.
+func (c Config) WithMaxSamplesPerRequest(n int) Config {
+	c.MaxSamplesPerRequest = n
+	return c
+}
+
+// WithBufferDir returns a new Config buffering requests on disk under dir.
+func (c Config) WithBufferDir(dir string) Config {
+	c.BufferDir = dir
+	return c
+}
+
+// WithMaxBufferBytes returns a new Config with the specified buffer bound.
+func (c Config) WithMaxBufferBytes(n int64) Config {
+	c.MaxBufferBytes = n
+	return c
+}
+
+// WithBackoff returns a new Config with the specified retry backoff bounds.
+func (c Config) WithBackoff(minBackoff, maxBackoff time.Duration) Config {
+	c.MinBackoff = minBackoff
+	c.MaxBackoff = maxBackoff
+	return c
+}
+
+// WithExternalLabel returns a new Config with an additional external label.
+func (c Config) WithExternalLabel(name, value string) Config {
+	c.ExternalLabels = withEntry(c.ExternalLabels, name, value)
+	return c
+}
+
+// WithHeader returns a new Config with an additional request header.
+func (c Config) WithHeader(name, value string) Config {
+	c.Headers = withEntry(c.Headers, name, value)
+	return c
+}
+
+// WithBasicAuth returns a new Config with basic auth credentials.
+func (c Config) WithBasicAuth(username, password string) Config {
+	c.Username = username
+	c.Password = password
+	return c
+}
+
+// WithBearerToken returns a new Config with a bearer token.
+func (c Config) WithBearerToken(token string) Config {
+	c.BearerToken = token
+	return c
+}
+
+// WithMetrics returns a new Config with the specified self-metrics recorder.
+func (c Config) WithMetrics(recorder MetricsRecorder) Config {
+	c.Metrics = recorder
+	return c
+}
+
+// withEntry returns a copy of m with key set to value.
+func withEntry(m map[string]string, key, value string) map[string]string {
+	out := make(map[string]string, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
+
+// Validate checks that the configuration is valid and returns a copy with defaults applied.
+func (c Config) Validate() (Config, error) {
+	if c.URL == "" {
+		return c, fmt.Errorf("remote-write URL is required")
+	}
+	if c.Interval < 0 || c.Timeout < 0 || c.MinBackoff < 0 || c.MaxBackoff < 0 {
+		return c, fmt.Errorf("remote-write interval, timeout and backoff must not be negative")
+	}
+	if c.MaxSamplesPerRequest < 0 || c.MaxBufferBytes < 0 {
+		return c, fmt.Errorf("remote-write batch and buffer sizes must not be negative")
+	}
+	if c.Username != "" && c.BearerToken != "" {
+		return c, fmt.Errorf("remote-write basic auth and bearer token are mutually exclusive")
+	}
+
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.MaxSamplesPerRequest == 0 {
+		c.MaxSamplesPerRequest = DefaultMaxSamplesPerRequest
+	}
+	if c.MaxBufferBytes == 0 {
+		c.MaxBufferBytes = DefaultMaxBufferBytes
+	}
+	if c.MinBackoff == 0 {
+		c.MinBackoff = DefaultMinBackoff
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+	if c.MaxBackoff < c.MinBackoff {
+		return c, fmt.Errorf("remote-write max backoff %s is less than min backoff %s", c.MaxBackoff, c.MinBackoff)
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c, nil
+}
@@ -0,0 +1,85 @@
+package remotewrite
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	const url = "http://prometheus:9090/api/v1/write"
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"valid", Config{URL: url}, false},
+		{"missing url", Config{}, true},
+		{"negative interval", Config{URL: url, Interval: -time.Second}, true},
+		{"negative timeout", Config{URL: url, Timeout: -time.Second}, true},
+		{"negative backoff", Config{URL: url, MinBackoff: -time.Second}, true},
+		{"negative batch", Config{URL: url, MaxSamplesPerRequest: -1}, true},
+		{"negative buffer", Config{URL: url, MaxBufferBytes: -1}, true},
+		{"inverted backoff", Config{URL: url, MinBackoff: time.Minute, MaxBackoff: time.Second}, true},
+		{"basic auth and bearer token", Config{URL: url, Username: "user", BearerToken: "token"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.Interval != DefaultInterval || cfg.Timeout != DefaultTimeout ||
+				cfg.MaxSamplesPerRequest != DefaultMaxSamplesPerRequest || cfg.MaxBufferBytes != DefaultMaxBufferBytes ||
+				cfg.MinBackoff != DefaultMinBackoff || cfg.MaxBackoff != DefaultMaxBackoff || cfg.Logger == nil {
+				t.Errorf("Validate() = %+v, want defaults applied", cfg)
+			}
+		})
+	}
+}
+
+func TestConfig_Setters(t *testing.T) {
+	t.Parallel()
+
+	base := Config{}.WithExternalLabel("region", "niassa")
+	cfg := base.
+		WithURL("http://prometheus:9090/api/v1/write").
+		WithInterval(time.Minute).
+		WithTimeout(5*time.Second).
+		WithMaxSamplesPerRequest(500).
+		WithBufferDir("/var/lib/txova/rw").
+		WithMaxBufferBytes(1<<20).
+		WithBackoff(time.Second, 5*time.Minute).
+		WithExternalLabel("site", "lichinga").
+		WithHeader("X-Scope-OrgID", "edge").
+		WithBasicAuth("user", "secret")
+
+	if cfg.URL != "http://prometheus:9090/api/v1/write" || cfg.Interval != time.Minute || cfg.Timeout != 5*time.Second {
+		t.Errorf("URL/Interval/Timeout = %q/%v/%v", cfg.URL, cfg.Interval, cfg.Timeout)
+	}
+	if cfg.MaxSamplesPerRequest != 500 || cfg.BufferDir != "/var/lib/txova/rw" || cfg.MaxBufferBytes != 1<<20 {
+		t.Errorf("batch/buffer = %d/%q/%d", cfg.MaxSamplesPerRequest, cfg.BufferDir, cfg.MaxBufferBytes)
+	}
+	if cfg.MinBackoff != time.Second || cfg.MaxBackoff != 5*time.Minute {
+		t.Errorf("backoff = %v/%v", cfg.MinBackoff, cfg.MaxBackoff)
+	}
+	if cfg.ExternalLabels["region"] != "niassa" || cfg.ExternalLabels["site"] != "lichinga" {
+		t.Errorf("ExternalLabels = %v", cfg.ExternalLabels)
+	}
+	if _, ok := base.ExternalLabels["site"]; ok {
+		t.Error("WithExternalLabel modified the original config")
+	}
+	if cfg.Headers["X-Scope-OrgID"] != "edge" || cfg.Username != "user" || cfg.Password != "secret" {
+		t.Errorf("Headers/auth = %v/%q/%q", cfg.Headers, cfg.Username, cfg.Password)
+	}
+	if token := (Config{}).WithBearerToken("token").BearerToken; token != "token" {
+		t.Errorf("BearerToken = %q", token)
+	}
+}
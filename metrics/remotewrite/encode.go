@@ -0,0 +1,145 @@
+package remotewrite
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/golang/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// label is a remote-write label.
+type label struct {
+	name  string
+	value string
+}
+
+// series is a remote-write time series with a single sample.
+type series struct {
+	labels    []label
+	value     float64
+	timestamp int64
+}
+
+// toSeries flattens metric families into time series the way Prometheus
+// stores them: histograms and summaries become _bucket, _sum, _count and
+// quantile series. Samples without a timestamp get nowMs.
+func toSeries(families []*dto.MetricFamily, external map[string]string, nowMs int64) []series {
+	var out []series
+	for _, family := range families {
+		name := family.GetName()
+		for _, m := range family.GetMetric() {
+			ts := nowMs
+			if m.TimestampMs != nil {
+				ts = m.GetTimestampMs()
+			}
+			add := func(suffix string, value float64, extra ...label) {
+				out = append(out, series{
+					labels:    seriesLabels(name+suffix, m.GetLabel(), external, extra...),
+					value:     value,
+					timestamp: ts,
+				})
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add("", m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add("", m.GetGauge().GetValue())
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				h := m.GetHistogram()
+				hasInf := false
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), +1) {
+						hasInf = true
+					}
+					add("_bucket", float64(b.GetCumulativeCount()), label{"le", formatFloat(b.GetUpperBound())})
+				}
+				if !hasInf {
+					add("_bucket", float64(h.GetSampleCount()), label{"le", "+Inf"})
+				}
+				add("_sum", h.GetSampleSum())
+				add("_count", float64(h.GetSampleCount()))
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add("", q.GetValue(), label{"quantile", formatFloat(q.GetQuantile())})
+				}
+				add("_sum", s.GetSampleSum())
+				add("_count", float64(s.GetSampleCount()))
+			default:
+				add("", m.GetUntyped().GetValue())
+			}
+		}
+	}
+	return out
+}
+
+// seriesLabels returns the sorted labels of a series, including __name__
+// and the external labels not already set.
+func seriesLabels(name string, pairs []*dto.LabelPair, external map[string]string, extra ...label) []label {
+	labels := make([]label, 0, len(pairs)+len(external)+len(extra)+1)
+	labels = append(labels, label{"__name__", name})
+	seen := map[string]bool{"__name__": true}
+	for _, p := range pairs {
+		labels = append(labels, label{p.GetName(), p.GetValue()})
+		seen[p.GetName()] = true
+	}
+	for _, l := range extra {
+		labels = append(labels, l)
+		seen[l.name] = true
+	}
+	for k, v := range external {
+		if !seen[k] {
+			labels = append(labels, label{k, v})
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+	return labels
+}
+
+// formatFloat formats a bucket bound or quantile like the Prometheus text format.
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, +1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+// encodeRequest encodes series as a snappy-compressed remote-write
+// WriteRequest protobuf message.
+func encodeRequest(batch []series) []byte {
+	var buf []byte
+	for _, s := range batch {
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, appendSeries(nil, s))
+	}
+	return snappy.Encode(nil, buf)
+}
+
+// appendSeries appends the TimeSeries message for s.
+func appendSeries(buf []byte, s series) []byte {
+	for _, l := range s.labels {
+		var lb []byte
+		lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.name)
+		lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.value)
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, lb)
+	}
+
+	var sb []byte
+	sb = protowire.AppendTag(sb, 1, protowire.Fixed64Type)
+	sb = protowire.AppendFixed64(sb, math.Float64bits(s.value))
+	sb = protowire.AppendTag(sb, 2, protowire.VarintType)
+	sb = protowire.AppendVarint(sb, uint64(s.timestamp)) //nolint:gosec // int64 timestamps are encoded as two's complement varints
+	buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+	return protowire.AppendBytes(buf, sb)
+}
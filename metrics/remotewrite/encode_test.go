@@ -0,0 +1,171 @@
+package remotewrite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodedSeries is a time series decoded from a remote-write request.
+type decodedSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// decodeRequest decodes a snappy-compressed WriteRequest.
+func decodeRequest(t *testing.T, body []byte) []decodedSeries {
+	t.Helper()
+
+	data, err := snappy.Decode(nil, body)
+	if err != nil {
+		t.Fatalf("snappy decode: %v", err)
+	}
+
+	var out []decodedSeries
+	forEachField(t, data, func(num protowire.Number, v []byte, _ uint64) {
+		if num != 1 {
+			return
+		}
+		s := decodedSeries{labels: map[string]string{}}
+		forEachField(t, v, func(num protowire.Number, v []byte, _ uint64) {
+			switch num {
+			case 1:
+				var name, value string
+				forEachField(t, v, func(num protowire.Number, v []byte, _ uint64) {
+					if num == 1 {
+						name = string(v)
+					} else {
+						value = string(v)
+					}
+				})
+				s.labels[name] = value
+			case 2:
+				forEachField(t, v, func(num protowire.Number, _ []byte, n uint64) {
+					if num == 1 {
+						s.value = math.Float64frombits(n)
+					} else {
+						s.timestamp = int64(n) //nolint:gosec // decoding a two's complement varint
+					}
+				})
+			}
+		})
+		out = append(out, s)
+	})
+	return out
+}
+
+// forEachField calls fn with the bytes or numeric value of each field in a message.
+func forEachField(t *testing.T, b []byte, fn func(protowire.Number, []byte, uint64)) {
+	t.Helper()
+
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatalf("invalid tag: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				t.Fatalf("invalid bytes: %v", protowire.ParseError(n))
+			}
+			fn(num, v, 0)
+			b = b[n:]
+		case protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			if n < 0 {
+				t.Fatalf("invalid fixed64: %v", protowire.ParseError(n))
+			}
+			fn(num, nil, v)
+			b = b[n:]
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				t.Fatalf("invalid varint: %v", protowire.ParseError(n))
+			}
+			fn(num, nil, v)
+			b = b[n:]
+		default:
+			t.Fatalf("unexpected wire type %v", typ)
+		}
+	}
+}
+
+// byName indexes decoded series by name and the given label.
+func byName(series []decodedSeries, label string) map[string]decodedSeries {
+	out := make(map[string]decodedSeries, len(series))
+	for _, s := range series {
+		out[s.labels["__name__"]+"/"+s.labels[label]] = s
+	}
+	return out
+}
+
+func TestEncodeRequest(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "requests_total", Help: "Requests."}, []string{"method"})
+	inflight := prometheus.NewGauge(prometheus.GaugeOpts{Name: "in_flight", Help: "In flight."})
+	duration := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "duration_seconds", Help: "Duration.", Buckets: []float64{0.5}})
+	latency := prometheus.NewSummary(prometheus.SummaryOpts{Name: "latency_seconds", Help: "Latency.", Objectives: map[float64]float64{0.5: 0.05}})
+	registry.MustRegister(requests, inflight, duration, latency)
+
+	requests.WithLabelValues("GET").Add(3)
+	inflight.Set(-2)
+	duration.Observe(0.25)
+	duration.Observe(2)
+	latency.Observe(1)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	external := map[string]string{"region": "niassa", "method": "ignored"}
+	got := decodeRequest(t, encodeRequest(toSeries(families, external, 1700000000000)))
+
+	for _, s := range got {
+		if s.timestamp != 1700000000000 {
+			t.Errorf("%v timestamp = %d", s.labels, s.timestamp)
+		}
+		if s.labels["region"] != "niassa" {
+			t.Errorf("%v missing external label", s.labels)
+		}
+	}
+
+	tests := []struct {
+		key  string
+		want float64
+	}{
+		{"requests_total/", 3},
+		{"in_flight/", -2},
+		{"duration_seconds_bucket/0.5", 1},
+		{"duration_seconds_bucket/+Inf", 2},
+		{"duration_seconds_sum/", 2.25},
+		{"duration_seconds_count/", 2},
+		{"latency_seconds/0.5", 1},
+		{"latency_seconds_sum/", 1},
+		{"latency_seconds_count/", 1},
+	}
+	index := byName(got, "le")
+	for k, v := range byName(got, "quantile") {
+		index[k] = v
+	}
+	for _, tt := range tests {
+		s, ok := index[tt.key]
+		if !ok {
+			t.Errorf("series %s missing", tt.key)
+			continue
+		}
+		if s.value != tt.want {
+			t.Errorf("series %s = %v, want %v", tt.key, s.value, tt.want)
+		}
+	}
+	if m := index["requests_total/"].labels["method"]; m != "GET" {
+		t.Errorf("requests_total method = %q, want GET (metric labels win over external labels)", m)
+	}
+}
@@ -0,0 +1,326 @@
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+)
+
+// Remote-write request headers.
+const (
+	headerVersion = "X-Prometheus-Remote-Write-Version"
+	version       = "0.1.0"
+	userAgent     = "txova-go-observability"
+)
+
+// Writer gathers a registry periodically and sends its samples to a
+// Prometheus remote-write endpoint.
+type Writer struct {
+	config   Config
+	gatherer prometheus.Gatherer
+	client   *http.Client
+	buffer   *buffer
+	metrics  MetricsRecorder
+	wake     chan struct{}
+
+	// sendMu serializes sending so requests stay in order.
+	sendMu sync.Mutex
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a Writer for the registry in metricsCfg, which must also
+// implement prometheus.Gatherer (as *prometheus.Registry does). With
+// cfg.BufferDir set, requests buffered by a previous process are sent first.
+func New(metricsCfg metrics.Config, cfg Config) (*Writer, error) { //nolint:gocritic // cfg passed by value for API simplicity
+	cfg, err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	gatherer, err := metricsCfg.Gatherer()
+	if err != nil {
+		return nil, err
+	}
+	buf, err := openBuffer(cfg.BufferDir, cfg.MaxBufferBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := cfg.Metrics
+	if recorder == nil {
+		recorder = nopRecorder{}
+	}
+
+	w := &Writer{
+		config:   cfg,
+		gatherer: gatherer,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		buffer:   buf,
+		metrics:  recorder,
+		wake:     make(chan struct{}, 1),
+	}
+	w.updateQueue()
+	return w, nil
+}
+
+// Config returns the effective configuration.
+func (w *Writer) Config() Config {
+	return w.config
+}
+
+// Gather gathers the registry and buffers its samples for sending. Samples
+// that do not fit in the buffer evict the oldest buffered requests. A
+// request that cannot be buffered is dropped and the remaining ones are
+// still buffered; the errors are joined into the returned error.
+func (w *Writer) Gather() error {
+	families, gatherErr := w.gatherer.Gather()
+	all := toSeries(families, w.config.ExternalLabels, time.Now().UnixMilli())
+
+	var errs []error
+	if gatherErr != nil {
+		errs = append(errs, fmt.Errorf("failed to gather metrics: %w", gatherErr))
+	}
+	for start := 0; start < len(all); start += w.config.MaxSamplesPerRequest {
+		end := min(start+w.config.MaxSamplesPerRequest, len(all))
+		dropped, err := w.buffer.push(encodeRequest(all[start:end]), end-start)
+		switch {
+		case err != nil:
+			w.metrics.RecordSamplesDropped(metrics.RemoteWriteDropBufferIO, dropped)
+			errs = append(errs, err)
+		case dropped > 0:
+			w.metrics.RecordSamplesDropped(metrics.RemoteWriteDropBufferFull, dropped)
+		}
+	}
+	w.updateQueue()
+
+	return errors.Join(errs...)
+}
+
+// Send sends buffered requests, oldest first, until the buffer is empty.
+// Requests the endpoint rejects as invalid are dropped. It returns the
+// first error that should be retried, leaving that request buffered.
+func (w *Writer) Send(ctx context.Context) error {
+	w.sendMu.Lock()
+	defer w.sendMu.Unlock()
+	defer w.updateQueue()
+
+	for {
+		e, data, ok, err := w.buffer.peek()
+		if !ok {
+			return nil
+		}
+		if err != nil {
+			w.config.Logger.WarnContext(ctx, "dropping unreadable remote-write request", "error", err)
+			w.metrics.RecordSamplesDropped(metrics.RemoteWriteDropBufferIO, e.samples)
+			w.buffer.remove(e)
+			continue
+		}
+
+		start := time.Now()
+		err = w.send(ctx, data)
+		var rejected *rejectedError
+		switch {
+		case err == nil:
+			w.metrics.RecordRequest(metrics.RemoteWriteResultSuccess, time.Since(start))
+			w.metrics.RecordSamplesSent(e.samples)
+		case errors.As(err, &rejected):
+			w.metrics.RecordRequest(metrics.RemoteWriteResultRejected, time.Since(start))
+			w.metrics.RecordSamplesDropped(metrics.RemoteWriteDropRejected, e.samples)
+			w.config.Logger.WarnContext(ctx, "remote-write request rejected", "error", err, "samples", e.samples)
+		default:
+			w.metrics.RecordRequest(metrics.RemoteWriteResultRetry, time.Since(start))
+			return err
+		}
+		w.buffer.remove(e)
+		w.updateQueue()
+	}
+}
+
+// Flush gathers the registry and sends all buffered requests.
+func (w *Writer) Flush(ctx context.Context) error {
+	if err := w.Gather(); err != nil {
+		return err
+	}
+	return w.Send(ctx)
+}
+
+// rejectedError is a non-retryable response from the endpoint.
+type rejectedError struct {
+	status int
+	body   string
+}
+
+func (e *rejectedError) Error() string {
+	return fmt.Sprintf("remote-write endpoint rejected request: %d %s", e.status, e.body)
+}
+
+// send posts one compressed request. Client errors other than 429 are
+// returned as *rejectedError; everything else can be retried.
+func (w *Writer) send(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(data))
+	if err != nil {
+		return &rejectedError{body: err.Error()}
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(headerVersion, version)
+	for k, v := range w.config.Headers {
+		req.Header.Set(k, v)
+	}
+	switch {
+	case w.config.Username != "":
+		req.SetBasicAuth(w.config.Username, w.config.Password)
+	case w.config.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+w.config.BearerToken)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote-write request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	switch {
+	case resp.StatusCode/100 == 2:
+		return nil
+	case resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusTooManyRequests:
+		return &rejectedError{status: resp.StatusCode, body: string(body)}
+	default:
+		return fmt.Errorf("remote-write endpoint returned %d: %s", resp.StatusCode, body)
+	}
+}
+
+// Start gathers every Interval and sends in the background, retrying failed
+// requests with exponential backoff, until Stop or Close is called or ctx
+// is canceled. It does nothing when the writer is already running.
+func (w *Writer) Start(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil {
+		return
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.wg.Add(2)
+	go w.gatherLoop(ctx)
+	go w.sendLoop(ctx)
+	w.notify()
+}
+
+// gatherLoop gathers on every tick until ctx is canceled.
+func (w *Writer) gatherLoop(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.Gather(); err != nil {
+				w.config.Logger.WarnContext(ctx, "remote-write gather failed", "error", err)
+			}
+			w.notify()
+		}
+	}
+}
+
+// sendLoop sends when notified, backing off after failures, until ctx is canceled.
+func (w *Writer) sendLoop(ctx context.Context) {
+	defer w.wg.Done()
+
+	var backoff time.Duration
+	for {
+		if backoff > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		} else {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.wake:
+			}
+		}
+
+		err := w.Send(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			backoff = w.nextBackoff(backoff)
+			w.config.Logger.WarnContext(ctx, "remote-write failed, retrying", "error", err, "backoff", backoff)
+		default:
+			backoff = 0
+		}
+	}
+}
+
+// nextBackoff doubles the backoff within the configured bounds.
+func (w *Writer) nextBackoff(current time.Duration) time.Duration {
+	if current == 0 {
+		return w.config.MinBackoff
+	}
+	return min(2*current, w.config.MaxBackoff)
+}
+
+// notify wakes the send loop without blocking.
+func (w *Writer) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Stop stops the background loops and waits for an in-flight request to finish.
+func (w *Writer) Stop() {
+	w.mu.Lock()
+	cancel := w.cancel
+	w.cancel = nil
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		w.wg.Wait()
+	}
+}
+
+// Close stops the background loops, gathers a final time and sends what is
+// buffered within ctx. With a buffer directory, unsent requests are kept
+// for the next process.
+func (w *Writer) Close(ctx context.Context) error {
+	w.Stop()
+	return w.Flush(ctx)
+}
+
+// updateQueue reports the buffer size.
+func (w *Writer) updateQueue() {
+	w.metrics.SetQueue(w.buffer.stats())
+}
+
+// nopRecorder discards self-metrics.
+type nopRecorder struct{}
+
+func (nopRecorder) RecordRequest(string, time.Duration) {}
+func (nopRecorder) RecordSamplesSent(int)               {}
+func (nopRecorder) RecordSamplesDropped(string, int)    {}
+func (nopRecorder) SetQueue(int, int64)                 {}
@@ -0,0 +1,394 @@
+package remotewrite
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+)
+
+var _ MetricsRecorder = (*metrics.RemoteWriteCollector)(nil)
+
+// receivedRequest is a request received by the fake endpoint.
+type receivedRequest struct {
+	header http.Header
+	series []decodedSeries
+}
+
+// fakeReceiver is a remote-write endpoint that records requests in memory
+// and answers with the queued statuses, then 204.
+type fakeReceiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []receivedRequest
+	statuses []int
+}
+
+func newFakeReceiver(t *testing.T, statuses ...int) *fakeReceiver {
+	t.Helper()
+
+	rcv := &fakeReceiver{statuses: statuses}
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+
+		rcv.mu.Lock()
+		defer rcv.mu.Unlock()
+		status := http.StatusNoContent
+		if len(rcv.statuses) > 0 {
+			status, rcv.statuses = rcv.statuses[0], rcv.statuses[1:]
+		}
+		if status/100 == 2 {
+			rcv.requests = append(rcv.requests, receivedRequest{header: r.Header, series: decodeRequest(t, body)})
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+func (rcv *fakeReceiver) Requests() []receivedRequest {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	return append([]receivedRequest(nil), rcv.requests...)
+}
+
+// newTestWriter returns a writer for a registry with one counter, recording
+// self-metrics in a separate registry.
+func newTestWriter(t *testing.T, cfg Config) (*Writer, prometheus.Counter, *metrics.RemoteWriteCollector) {
+	t.Helper()
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "rides_total", Help: "Rides."})
+	registry.MustRegister(counter)
+
+	collector, err := metrics.NewRemoteWriteCollector(metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()))
+	if err != nil {
+		t.Fatalf("NewRemoteWriteCollector() error = %v", err)
+	}
+
+	w, err := New(metrics.DefaultConfig().WithRegistry(registry), cfg.WithMetrics(collector))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return w, counter, collector
+}
+
+// metricValue returns the value of a self-metric series.
+func metricValue(t *testing.T, collector *metrics.RemoteWriteCollector, name string, labels prometheus.Labels) float64 {
+	t.Helper()
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "txova_"+name {
+			continue
+		}
+	metric:
+		for _, m := range family.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if labels[l.GetName()] != l.GetValue() {
+					continue metric
+				}
+			}
+			if c := m.GetCounter(); c != nil {
+				return c.GetValue()
+			}
+			return m.GetGauge().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestWriter_Flush(t *testing.T) {
+	t.Parallel()
+
+	rcv := newFakeReceiver(t)
+	w, counter, collector := newTestWriter(t, Config{
+		URL:            rcv.URL,
+		ExternalLabels: map[string]string{"site": "lichinga"},
+		BearerToken:    "token",
+		Headers:        map[string]string{"X-Scope-OrgID": "edge"},
+	})
+	counter.Add(4)
+
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	requests := rcv.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("requests = %d, want 1", len(requests))
+	}
+	header := requests[0].header
+	for k, want := range map[string]string{
+		"Content-Encoding": "snappy",
+		"Content-Type":     "application/x-protobuf",
+		headerVersion:      version,
+		"Authorization":    "Bearer token",
+		"X-Scope-OrgID":    "edge",
+	} {
+		if got := header.Get(k); got != want {
+			t.Errorf("header %s = %q, want %q", k, got, want)
+		}
+	}
+	series := requests[0].series
+	if len(series) != 1 || series[0].labels["__name__"] != "rides_total" ||
+		series[0].labels["site"] != "lichinga" || series[0].value != 4 {
+		t.Errorf("series = %+v, want rides_total{site=lichinga} 4", series)
+	}
+
+	if got := metricValue(t, collector, "remote_write_samples_sent_total", nil); got != 1 {
+		t.Errorf("samples sent = %v, want 1", got)
+	}
+	if got := metricValue(t, collector, "remote_write_queue_requests", nil); got != 0 {
+		t.Errorf("queue requests = %v, want 0", got)
+	}
+}
+
+func TestWriter_Batches(t *testing.T) {
+	t.Parallel()
+
+	rcv := newFakeReceiver(t)
+	registry := prometheus.NewRegistry()
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "drivers_online", Help: "Drivers."}, []string{"zone"})
+	registry.MustRegister(vec)
+	for _, zone := range []string{"a", "b", "c"} {
+		vec.WithLabelValues(zone).Set(1)
+	}
+
+	w, err := New(metrics.DefaultConfig().WithRegistry(registry), Config{URL: rcv.URL, MaxSamplesPerRequest: 2})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	requests := rcv.Requests()
+	if len(requests) != 2 || len(requests[0].series) != 2 || len(requests[1].series) != 1 {
+		t.Errorf("requests = %d, want batches of 2 and 1", len(requests))
+	}
+}
+
+// failingCollector reports one invalid metric, so gathering fails.
+type failingCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c failingCollector) Describe(ch chan<- *prometheus.Desc) { ch <- c.desc }
+
+func (c failingCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.NewInvalidMetric(c.desc, errors.New("scrape failed"))
+}
+
+func TestWriter_GatherContinuesAfterBufferError(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "drivers_online", Help: "Drivers."}, []string{"zone"})
+	registry.MustRegister(vec, failingCollector{prometheus.NewDesc("broken", "Broken.", nil, nil)})
+	for _, zone := range []string{"a", "b", "c"} {
+		vec.WithLabelValues(zone).Set(1)
+	}
+	collector, err := metrics.NewRemoteWriteCollector(metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()))
+	if err != nil {
+		t.Fatalf("NewRemoteWriteCollector() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	w, err := New(metrics.DefaultConfig().WithRegistry(registry),
+		Config{URL: "http://127.0.0.1:0", BufferDir: dir, MaxSamplesPerRequest: 1}.WithMetrics(collector))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// A directory in place of the temporary file makes the first write fail.
+	if err := os.Mkdir(w.buffer.path(entry{seq: 0, samples: 1})+".tmp", 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Gather()
+	if err == nil || !strings.Contains(err.Error(), "failed to gather metrics") ||
+		!strings.Contains(err.Error(), "failed to write buffered remote-write request") {
+		t.Errorf("Gather() error = %v, want the gather and buffer errors joined", err)
+	}
+	if count, _ := w.buffer.stats(); count != 2 {
+		t.Errorf("buffered requests = %d, want the 2 batches after the failed one", count)
+	}
+	if got := metricValue(t, collector, "remote_write_samples_dropped_total", prometheus.Labels{"reason": metrics.RemoteWriteDropBufferIO}); got != 1 {
+		t.Errorf("buffer I/O dropped samples = %v, want 1", got)
+	}
+}
+
+func TestWriter_RetryKeepsBuffered(t *testing.T) {
+	t.Parallel()
+
+	rcv := newFakeReceiver(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	w, _, collector := newTestWriter(t, Config{URL: rcv.URL})
+	ctx := context.Background()
+
+	if err := w.Flush(ctx); err == nil {
+		t.Fatal("Flush() should fail on 503")
+	}
+	if err := w.Send(ctx); err == nil {
+		t.Fatal("Send() should fail on 429")
+	}
+	if got := metricValue(t, collector, "remote_write_queue_requests", nil); got != 1 {
+		t.Errorf("queue requests = %v, want 1 while failing", got)
+	}
+	if got := metricValue(t, collector, "remote_write_requests_total", prometheus.Labels{"result": metrics.RemoteWriteResultRetry}); got != 2 {
+		t.Errorf("retried requests = %v, want 2", got)
+	}
+
+	if err := w.Send(ctx); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(rcv.Requests()) != 1 {
+		t.Errorf("requests = %d, want the buffered request delivered", len(rcv.Requests()))
+	}
+}
+
+func TestWriter_RejectedDropped(t *testing.T) {
+	t.Parallel()
+
+	rcv := newFakeReceiver(t, http.StatusBadRequest)
+	w, _, collector := newTestWriter(t, Config{URL: rcv.URL})
+
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v, want rejected requests dropped", err)
+	}
+	if got := metricValue(t, collector, "remote_write_samples_dropped_total", prometheus.Labels{"reason": metrics.RemoteWriteDropRejected}); got != 1 {
+		t.Errorf("rejected samples = %v, want 1", got)
+	}
+	if count, _ := w.buffer.stats(); count != 0 {
+		t.Errorf("buffered requests = %d, want 0", count)
+	}
+}
+
+func TestWriter_BufferFull(t *testing.T) {
+	t.Parallel()
+
+	rcv := newFakeReceiver(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	w, _, collector := newTestWriter(t, Config{URL: rcv.URL, MaxBufferBytes: 60})
+	ctx := context.Background()
+
+	for range 3 {
+		_ = w.Flush(ctx)
+	}
+	if got := metricValue(t, collector, "remote_write_samples_dropped_total", prometheus.Labels{"reason": metrics.RemoteWriteDropBufferFull}); got == 0 {
+		t.Error("samples should be dropped when the buffer is full")
+	}
+	if _, size := w.buffer.stats(); size > 60 {
+		t.Errorf("buffered bytes = %d, want at most 60", size)
+	}
+}
+
+func TestWriter_DiskBuffer(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	down := newFakeReceiver(t, http.StatusServiceUnavailable)
+	w, counter, _ := newTestWriter(t, Config{URL: down.URL, BufferDir: dir})
+	counter.Inc()
+	if err := w.Close(context.Background()); err == nil {
+		t.Fatal("Close() should fail while the endpoint is down")
+	}
+
+	// A new process sends what the previous one buffered.
+	up := newFakeReceiver(t)
+	restarted, _, _ := newTestWriter(t, Config{URL: up.URL, BufferDir: dir})
+	if err := restarted.Send(context.Background()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	requests := up.Requests()
+	if len(requests) != 1 || requests[0].series[0].value != 1 {
+		t.Errorf("requests after restart = %+v, want the buffered sample", requests)
+	}
+}
+
+func TestWriter_StartRetriesWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	rcv := newFakeReceiver(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	w, _, _ := newTestWriter(t, Config{
+		URL:        rcv.URL,
+		Interval:   10 * time.Millisecond,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	})
+
+	ctx := context.Background()
+	w.Start(ctx)
+	w.Start(ctx) // Already running: no second loop.
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(rcv.Requests()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(rcv.Requests()) == 0 {
+		t.Fatal("no request delivered after retries")
+	}
+
+	if err := w.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestWriter_NextBackoff(t *testing.T) {
+	t.Parallel()
+
+	w := &Writer{config: Config{MinBackoff: time.Second, MaxBackoff: 3 * time.Second}}
+	var got []time.Duration
+	backoff := time.Duration(0)
+	for range 4 {
+		backoff = w.nextBackoff(backoff)
+		got = append(got, backoff)
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("backoff %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cfg := metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry())
+	if _, err := New(cfg, Config{}); err == nil {
+		t.Error("New() without URL should fail")
+	}
+
+	registerer := prometheus.WrapRegistererWith(prometheus.Labels{"env": "test"}, prometheus.NewRegistry())
+	if _, err := New(cfg.WithRegistry(registerer), Config{URL: "http://prometheus:9090/api/v1/write"}); err == nil {
+		t.Error("New() with a registry that cannot gather should fail")
+	}
+}
+
+func TestWriter_Config(t *testing.T) {
+	t.Parallel()
+
+	w, _, _ := newTestWriter(t, Config{URL: "http://prometheus:9090/api/v1/write"})
+	if got := w.Config(); got.Interval != DefaultInterval || got.URL == "" {
+		t.Errorf("Config() = %+v, want defaults applied", got)
+	}
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewRemoteWriteCollector_DuplicateRegistration(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_rw_dup")
+
+	collector1, err := NewRemoteWriteCollector(cfg)
+	if err != nil {
+		t.Fatalf("First NewRemoteWriteCollector() error = %v", err)
+	}
+
+	collector2, err := NewRemoteWriteCollector(cfg)
+	if err != nil {
+		t.Fatalf("Second NewRemoteWriteCollector() error = %v", err)
+	}
+
+	if collector1 == nil || collector2 == nil {
+		t.Fatal("NewRemoteWriteCollector() returned nil collectors")
+	}
+}
+
+func TestRemoteWriteCollector_Record(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_rw")
+
+	collector, err := NewRemoteWriteCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewRemoteWriteCollector() error = %v", err)
+	}
+
+	collector.RecordRequest(RemoteWriteResultSuccess, 20*time.Millisecond)
+	collector.RecordRequest(RemoteWriteResultRetry, time.Second)
+	collector.RecordRequest(RemoteWriteResultSuccess, 30*time.Millisecond)
+	collector.RecordSamplesSent(100)
+	collector.RecordSamplesSent(50)
+	collector.RecordSamplesDropped(RemoteWriteDropBufferFull, 25)
+	collector.SetQueue(3, 4096)
+
+	if got := testutil.ToFloat64(collector.requestsTotal.WithLabelValues(RemoteWriteResultSuccess)); got != 2 {
+		t.Errorf("requestsTotal success = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(collector.requestsTotal.WithLabelValues(RemoteWriteResultRetry)); got != 1 {
+		t.Errorf("requestsTotal retry = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(collector.duration); got != 2 {
+		t.Errorf("duration histogram series = %d, want 2", got)
+	}
+	if got := testutil.ToFloat64(collector.samplesSent.WithLabelValues()); got != 150 {
+		t.Errorf("samplesSent = %v, want 150", got)
+	}
+	if got := testutil.ToFloat64(collector.samplesDropped.WithLabelValues(RemoteWriteDropBufferFull)); got != 25 {
+		t.Errorf("samplesDropped buffer_full = %v, want 25", got)
+	}
+	if got := testutil.ToFloat64(collector.queueRequests.WithLabelValues()); got != 3 {
+		t.Errorf("queueRequests = %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(collector.queueBytes.WithLabelValues()); got != 4096 {
+		t.Errorf("queueBytes = %v, want 4096", got)
+	}
+}
@@ -8,13 +8,13 @@ import (
 
 // RideCollector collects ride-related business metrics.
 type RideCollector struct {
-	requestedTotal *prometheus.CounterVec
-	completedTotal *prometheus.CounterVec
-	cancelledTotal *prometheus.CounterVec
-	duration       *prometheus.HistogramVec
-	distance       *prometheus.HistogramVec
-	fare           *prometheus.HistogramVec
-	waitTime       *prometheus.HistogramVec
+	requestedTotal *counterVec
+	completedTotal *counterVec
+	cancelledTotal *counterVec
+	duration       *histogramVec
+	distance       *histogramVec
+	fare           *histogramVec
+	waitTime       *histogramVec
 }
 
 // NewRideCollector creates a new RideCollector with the given configuration.
@@ -26,97 +26,104 @@ func NewRideCollector(cfg Config) (*RideCollector, error) {
 
 	c := &RideCollector{}
 
-	c.requestedTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.requestedTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "rides_requested_total",
-			Help:      "Total number of ride requests.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "rides_requested_total",
+			Help:        "Total number of ride requests.",
 		},
 		[]string{"service_type", "city"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.completedTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.completedTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "rides_completed_total",
-			Help:      "Total number of completed rides.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "rides_completed_total",
+			Help:        "Total number of completed rides.",
 		},
 		[]string{"service_type", "city"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.cancelledTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.cancelledTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "rides_cancelled_total",
-			Help:      "Total number of cancelled rides.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "rides_cancelled_total",
+			Help:        "Total number of cancelled rides.",
 		},
 		[]string{"cancelled_by", "reason"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.duration, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.duration, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "ride_duration_seconds",
-			Help:      "Duration of rides in seconds.",
-			Buckets:   DurationBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "ride_duration_seconds",
+			Help:        "Duration of rides in seconds.",
+			Buckets:     DurationBuckets,
 		},
 		[]string{"service_type"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.distance, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.distance, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "ride_distance_km",
-			Help:      "Distance of rides in kilometers.",
-			Buckets:   DistanceBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "ride_distance_km",
+			Help:        "Distance of rides in kilometers.",
+			Buckets:     DistanceBuckets,
 		},
 		[]string{"service_type"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.fare, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.fare, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "ride_fare_mzn",
-			Help:      "Fare of rides in MZN (smallest currency unit).",
-			Buckets:   FareBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "ride_fare_mzn",
+			Help:        "Fare of rides in MZN (smallest currency unit).",
+			Buckets:     FareBuckets,
 		},
 		[]string{"service_type"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.waitTime, err = registerCollector(cfg.Registry, prometheus.NewHistogramVec(
+	c.waitTime, err = newHistogramVec(cfg,
 		prometheus.HistogramOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "ride_wait_time_seconds",
-			Help:      "Time to match a driver in seconds.",
-			Buckets:   DurationBuckets,
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "ride_wait_time_seconds",
+			Help:        "Time to match a driver in seconds.",
+			Buckets:     DurationBuckets,
 		},
 		[]string{"service_type"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
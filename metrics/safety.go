@@ -6,9 +6,9 @@ import (
 
 // SafetyCollector collects safety-related business metrics.
 type SafetyCollector struct {
-	emergenciesTotal *prometheus.CounterVec
-	incidentsTotal   *prometheus.CounterVec
-	tripSharesTotal  prometheus.Counter
+	emergenciesTotal *counterVec
+	incidentsTotal   *counterVec
+	tripSharesTotal  *counter
 }
 
 // NewSafetyCollector creates a new SafetyCollector with the given configuration.
@@ -20,40 +20,43 @@ func NewSafetyCollector(cfg Config) (*SafetyCollector, error) {
 
 	c := &SafetyCollector{}
 
-	c.emergenciesTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.emergenciesTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "emergencies_triggered_total",
-			Help:      "Total number of emergency (SOS) activations.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "emergencies_triggered_total",
+			Help:        "Total number of emergency (SOS) activations.",
 		},
 		[]string{"type", "city"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.incidentsTotal, err = registerCollector(cfg.Registry, prometheus.NewCounterVec(
+	c.incidentsTotal, err = newCounterVec(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "incidents_reported_total",
-			Help:      "Total number of incidents reported.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "incidents_reported_total",
+			Help:        "Total number of incidents reported.",
 		},
 		[]string{"severity"},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	c.tripSharesTotal, err = registerCollector(cfg.Registry, prometheus.NewCounter(
+	c.tripSharesTotal, err = newCounter(cfg,
 		prometheus.CounterOpts{
-			Namespace: cfg.Namespace,
-			Subsystem: cfg.Subsystem,
-			Name:      "trip_shares_total",
-			Help:      "Total number of trip sharing activations.",
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "trip_shares_total",
+			Help:        "Total number of trip sharing activations.",
 		},
-	))
+	)
 	if err != nil {
 		return nil, err
 	}
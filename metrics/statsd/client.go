@@ -0,0 +1,273 @@
+package statsd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+)
+
+// series identifies an aggregated line by metric name and formatted tags.
+type series struct {
+	name string
+	tags string
+}
+
+// Client aggregates recorded values and sends them to a StatsD server.
+// It implements metrics.Backend.
+type Client struct {
+	config     Config
+	globalTags []metrics.Tag
+
+	mu       sync.Mutex
+	counts   map[series]float64
+	gauges   map[series]float64
+	observed map[series][]float64
+	samples  int
+
+	// sendMu serializes flushes and guards conn.
+	sendMu sync.Mutex
+	conn   net.Conn
+
+	flush  chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ metrics.Backend = (*Client)(nil)
+
+// New creates a Client and starts flushing every FlushInterval. The socket
+// is connected on the first flush and reconnected after write errors, so
+// the server does not need to be up yet.
+func New(cfg Config) (*Client, error) { //nolint:gocritic // cfg passed by value for API simplicity
+	cfg, err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		config:   cfg,
+		counts:   make(map[series]float64),
+		gauges:   make(map[series]float64),
+		observed: make(map[series][]float64),
+		flush:    make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	for k, v := range cfg.Tags {
+		c.globalTags = append(c.globalTags, metrics.Tag{Name: k, Value: v})
+	}
+	sort.Slice(c.globalTags, func(i, j int) bool { return c.globalTags[i].Name < c.globalTags[j].Name })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	go c.run(ctx)
+	return c, nil
+}
+
+// Count implements metrics.Backend. Increments are summed until the next flush.
+func (c *Client) Count(name string, delta float64, tags []metrics.Tag) {
+	key := c.series(name, tags)
+	c.mu.Lock()
+	c.counts[key] += delta
+	c.mu.Unlock()
+}
+
+// Gauge implements metrics.Backend. The last value before a flush is sent.
+func (c *Client) Gauge(name string, value float64, tags []metrics.Tag) {
+	key := c.series(name, tags)
+	c.mu.Lock()
+	c.gauges[key] = value
+	c.mu.Unlock()
+}
+
+// Observe implements metrics.Backend. Observations are buffered until the
+// next flush, or until MaxBufferedSamples are buffered.
+func (c *Client) Observe(name string, value float64, tags []metrics.Tag) {
+	key := c.series(name, tags)
+	c.mu.Lock()
+	c.observed[key] = append(c.observed[key], value)
+	c.samples++
+	full := c.samples >= c.config.MaxBufferedSamples
+	c.mu.Unlock()
+
+	if full {
+		select {
+		case c.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// series returns the aggregation key of a line.
+func (c *Client) series(name string, tags []metrics.Tag) series {
+	return series{name: sanitize(c.config.Prefix + name), tags: c.formatTags(tags)}
+}
+
+// formatTags formats the global and metric tags as a DogStatsD tag suffix.
+func (c *Client) formatTags(tags []metrics.Tag) string {
+	if len(c.globalTags) == 0 && len(tags) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("|#")
+	for i, t := range append(c.globalTags[:len(c.globalTags):len(c.globalTags)], tags...) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(sanitize(t.Name))
+		b.WriteByte(':')
+		b.WriteString(sanitize(t.Value))
+	}
+	return b.String()
+}
+
+// sanitize replaces the characters that delimit DogStatsD fields.
+func sanitize(s string) string {
+	if !strings.ContainsAny(s, ":|@,#\n") {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '|', '@', ',', '#', '\n':
+			return '_'
+		default:
+			return r
+		}
+	}, s)
+}
+
+// run flushes every FlushInterval and when the observation buffer fills up.
+func (c *Client) run(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.flush:
+		}
+		if err := c.Flush(); err != nil {
+			c.config.Logger.WarnContext(ctx, "statsd flush failed", "error", err)
+		}
+	}
+}
+
+// Flush sends the aggregated values now. Values that cannot be sent are
+// dropped, as are lines longer than MaxPacketSize, and reported in the error.
+func (c *Client) Flush() error {
+	c.mu.Lock()
+	counts, gauges, observed := c.counts, c.gauges, c.observed
+	c.counts = make(map[series]float64, len(counts))
+	c.gauges = make(map[series]float64, len(gauges))
+	c.observed = make(map[series][]float64, len(observed))
+	c.samples = 0
+	c.mu.Unlock()
+
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	w := packetWriter{client: c, buf: make([]byte, 0, c.config.MaxPacketSize)}
+	for key, v := range counts {
+		w.line(key, v, "c")
+	}
+	for key, v := range gauges {
+		w.line(key, v, "g")
+	}
+	for key, values := range observed {
+		for _, v := range values {
+			w.line(key, v, string(c.config.HistogramType))
+		}
+	}
+	w.send()
+	if w.dropped > 0 {
+		w.err = errors.Join(w.err, fmt.Errorf("dropped %d statsd lines longer than %d bytes", w.dropped, c.config.MaxPacketSize))
+	}
+	return w.err
+}
+
+// packetWriter packs lines into datagrams of at most MaxPacketSize bytes.
+type packetWriter struct {
+	client  *Client
+	buf     []byte
+	err     error
+	dropped int
+}
+
+// line appends one line, sending the current datagram first if it would
+// overflow. A line that does not fit in a datagram on its own is dropped.
+func (w *packetWriter) line(key series, value float64, typ string) {
+	n := len(key.name) + 1 + len(strconv.FormatFloat(value, 'f', -1, 64)) + 1 + len(typ) + len(key.tags)
+	if n > w.client.config.MaxPacketSize {
+		w.dropped++
+		return
+	}
+	if len(w.buf) > 0 && len(w.buf)+1+n > w.client.config.MaxPacketSize {
+		w.send()
+	}
+	if len(w.buf) > 0 {
+		w.buf = append(w.buf, '\n')
+	}
+	w.buf = append(w.buf, key.name...)
+	w.buf = append(w.buf, ':')
+	w.buf = strconv.AppendFloat(w.buf, value, 'f', -1, 64)
+	w.buf = append(w.buf, '|')
+	w.buf = append(w.buf, typ...)
+	w.buf = append(w.buf, key.tags...)
+}
+
+// send writes the current datagram, keeping the first error.
+func (w *packetWriter) send() {
+	if len(w.buf) == 0 {
+		return
+	}
+	if err := w.client.write(w.buf); err != nil && w.err == nil {
+		w.err = err
+	}
+	w.buf = w.buf[:0]
+}
+
+// write sends one datagram, connecting first if needed. The caller must
+// hold sendMu.
+func (c *Client) write(packet []byte) error {
+	if c.conn == nil {
+		network, addr := c.config.network()
+		conn, err := net.Dial(network, addr)
+		if err != nil {
+			return fmt.Errorf("failed to connect to statsd at %s: %w", c.config.Address, err)
+		}
+		c.conn = conn
+	}
+	if _, err := c.conn.Write(packet); err != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+		return fmt.Errorf("failed to write to statsd at %s: %w", c.config.Address, err)
+	}
+	return nil
+}
+
+// Close stops the flush loop, sends the remaining values and closes the socket.
+func (c *Client) Close() error {
+	c.cancel()
+	<-c.done
+
+	err := c.Flush()
+
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+package statsd
+
+import (
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+)
+
+// listenUDP returns a UDP socket for the fake server.
+func listenUDP(t *testing.T) net.PacketConn {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readPackets reads datagrams until none arrives for a short while.
+func readPackets(t *testing.T, conn net.PacketConn) []string {
+	t.Helper()
+
+	var packets []string
+	buf := make([]byte, 65536)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return packets
+		}
+		packets = append(packets, string(buf[:n]))
+	}
+}
+
+// lines returns the sorted lines of the packets.
+func lines(packets []string) []string {
+	var out []string
+	for _, p := range packets {
+		out = append(out, strings.Split(p, "\n")...)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestClient_AggregatesAndFormats(t *testing.T) {
+	t.Parallel()
+
+	server := listenUDP(t)
+	client, err := New(Config{
+		Address:       server.LocalAddr().String(),
+		Prefix:        "partner.",
+		Tags:          map[string]string{"env": "prod"},
+		FlushInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Close()
+
+	tags := []metrics.Tag{{Name: "method", Value: "GET"}, {Name: "path", Value: "/rides|all"}}
+	client.Count("http_requests_total", 1, tags)
+	client.Count("http_requests_total", 2, tags)
+	client.Gauge("in_flight", 3, nil)
+	client.Gauge("in_flight", 1, nil)
+	client.Observe("duration_seconds", 0.25, nil)
+	client.Observe("duration_seconds", 1.5, nil)
+
+	if err := client.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	packets := readPackets(t, server)
+	if len(packets) != 1 {
+		t.Errorf("packets = %d, want 1", len(packets))
+	}
+	got := lines(packets)
+	want := []string{
+		"partner.duration_seconds:0.25|h|#env:prod",
+		"partner.duration_seconds:1.5|h|#env:prod",
+		"partner.http_requests_total:3|c|#env:prod,method:GET,path:/rides_all",
+		"partner.in_flight:1|g|#env:prod",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("lines =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Nothing is sent when nothing was recorded.
+	if err := client.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if packets := readPackets(t, server); len(packets) != 0 {
+		t.Errorf("empty flush sent %v", packets)
+	}
+}
+
+func TestClient_SplitsPackets(t *testing.T) {
+	t.Parallel()
+
+	server := listenUDP(t)
+	client, err := New(Config{Address: server.LocalAddr().String(), MaxPacketSize: 64, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Close()
+
+	for range 10 {
+		client.Observe("txova_ride_distance_km", 12.5, []metrics.Tag{{Name: "city", Value: "maputo"}})
+	}
+	if err := client.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	packets := readPackets(t, server)
+	if len(packets) < 2 {
+		t.Errorf("packets = %d, want the lines split over several datagrams", len(packets))
+	}
+	for _, p := range packets {
+		if len(p) > 64 {
+			t.Errorf("packet of %d bytes exceeds MaxPacketSize", len(p))
+		}
+	}
+	if got := len(lines(packets)); got != 10 {
+		t.Errorf("lines = %d, want 10", got)
+	}
+}
+
+func TestClient_DropsOversizedLines(t *testing.T) {
+	t.Parallel()
+
+	server := listenUDP(t)
+	client, err := New(Config{Address: server.LocalAddr().String(), MaxPacketSize: 64, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Close()
+
+	client.Count("rides_requested_total", 1, nil)
+	client.Count("rides_requested_total", 1, []metrics.Tag{{Name: "pickup", Value: strings.Repeat("x", 64)}})
+
+	err = client.Flush()
+	if err == nil || !strings.Contains(err.Error(), "dropped 1 statsd lines") {
+		t.Errorf("Flush() error = %v, want the dropped line reported", err)
+	}
+	if got := lines(readPackets(t, server)); len(got) != 1 || got[0] != "rides_requested_total:1|c" {
+		t.Errorf("lines = %v, want only the line that fits", got)
+	}
+}
+
+func TestClient_FlushesWhenBufferFull(t *testing.T) {
+	t.Parallel()
+
+	server := listenUDP(t)
+	client, err := New(Config{
+		Address:            server.LocalAddr().String(),
+		FlushInterval:      time.Hour,
+		MaxBufferedSamples: 3,
+		HistogramType:      HistogramTypeDistribution,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Close()
+
+	for range 3 {
+		client.Observe("payment_processing_seconds", 2, nil)
+	}
+	got := lines(readPackets(t, server))
+	if len(got) != 3 || got[0] != "payment_processing_seconds:2|d" {
+		t.Errorf("lines = %v, want 3 distribution lines flushed early", got)
+	}
+}
+
+func TestClient_UnixSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dsd.sock")
+	server, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram not supported: %v", err)
+	}
+	defer server.Close()
+
+	client, err := New(Config{Address: unixPrefix + path, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.Count("rides_requested_total", 1, []metrics.Tag{{Name: "city", Value: "beira"}})
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got := lines(readPackets(t, server)); len(got) != 1 || got[0] != "rides_requested_total:1|c|#city:beira" {
+		t.Errorf("lines = %v", got)
+	}
+}
+
+func TestClient_UnreachableSocket(t *testing.T) {
+	t.Parallel()
+
+	client, err := New(Config{Address: unixPrefix + filepath.Join(t.TempDir(), "missing.sock"), FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.Count("rides_requested_total", 1, nil)
+	if err := client.Close(); err == nil {
+		t.Error("Close() should report the failed write")
+	}
+}
+
+func TestClient_MetricsBackend(t *testing.T) {
+	t.Parallel()
+
+	server := listenUDP(t)
+	client, err := New(Config{Address: server.LocalAddr().String(), FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	cfg := metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithBackend(client)
+	rides, err := metrics.NewRideCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewRideCollector() error = %v", err)
+	}
+	rides.RecordRideRequested("moto", "lichinga")
+	rides.RecordRideRequested("moto", "lichinga")
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	got := lines(readPackets(t, server))
+	if len(got) != 1 || got[0] != "txova_rides_requested_total:2|c|#service_type:moto,city:lichinga" {
+		t.Errorf("lines = %v", got)
+	}
+}
@@ -0,0 +1,161 @@
+// Package statsd sends the values recorded by the metrics collectors to a
+// StatsD server in the DogStatsD line format, with metric labels as tags.
+// It implements metrics.Backend: set it on metrics.Config and the collector
+// Record calls feed StatsD in addition to the Prometheus registry.
+//
+// Counters are summed and gauges keep their last value between flushes;
+// histogram observations are buffered. Lines are packed into as few
+// datagrams as the packet size allows.
+package statsd
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// HistogramType is the StatsD metric type used for histogram observations.
+type HistogramType string
+
+const (
+	// HistogramTypeHistogram sends observations as histograms ("h"),
+	// aggregated by the agent.
+	HistogramTypeHistogram HistogramType = "h"
+	// HistogramTypeDistribution sends observations as distributions ("d"),
+	// aggregated server-side by Datadog.
+	HistogramTypeDistribution HistogramType = "d"
+)
+
+// Default configuration values.
+const (
+	DefaultAddress            = "localhost:8125"
+	DefaultFlushInterval      = time.Second
+	DefaultMaxBufferedSamples = 4096
+
+	// DefaultUDPPacketSize keeps datagrams below a typical MTU.
+	DefaultUDPPacketSize = 1432
+	// DefaultUnixPacketSize is the DogStatsD default for Unix sockets.
+	DefaultUnixPacketSize = 8192
+)
+
+// unixPrefix marks an address as a Unix datagram socket path.
+const unixPrefix = "unix://"
+
+// Config holds configuration for the StatsD client.
+type Config struct {
+	// Address is "host:port" for UDP or "unix:///path/to/dsd.socket" for a
+	// Unix datagram socket. Default: DefaultAddress.
+	Address string
+
+	// Prefix is prepended to every metric name (e.g., "partner.").
+	Prefix string
+
+	// Tags are added to every line (e.g., "env", "site").
+	Tags map[string]string
+
+	// FlushInterval is how often aggregated values are sent.
+	// Default: DefaultFlushInterval.
+	FlushInterval time.Duration
+
+	// MaxPacketSize bounds the size of one datagram. Default:
+	// DefaultUDPPacketSize for UDP and DefaultUnixPacketSize for Unix sockets.
+	MaxPacketSize int
+
+	// MaxBufferedSamples triggers an early flush when this many histogram
+	// observations are buffered. Default: DefaultMaxBufferedSamples.
+	MaxBufferedSamples int
+
+	// HistogramType is the metric type for observations.
+	// Default: HistogramTypeHistogram.
+	HistogramType HistogramType
+
+	// Logger logs failed writes. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// WithAddress returns a new Config with the specified server address.
+func (c Config) WithAddress(address string) Config {
+	c.Address = address
+	return c
+}
+
+// WithPrefix returns a new Config with the specified metric name prefix.
+func (c Config) WithPrefix(prefix string) Config {
+	c.Prefix = prefix
+	return c
+}
+
+// WithTag returns a new Config with an additional global tag.
+func (c Config) WithTag(name, value string) Config {
+	tags := make(map[string]string, len(c.Tags)+1)
+	for k, v := range c.Tags {
+		tags[k] = v
+	}
+	tags[name] = value
+	c.Tags = tags
+	return c
+}
+
+// WithFlushInterval returns a new Config with the specified flush interval.
+func (c Config) WithFlushInterval(interval time.Duration) Config {
+	c.FlushInterval = interval
+	return c
+}
+
+// WithMaxPacketSize returns a new Config with the specified datagram size bound.
+func (c Config) WithMaxPacketSize(size int) Config {
+	c.MaxPacketSize = size
+	return c
+}
+
+// WithHistogramType returns a new Config with the specified observation type.
+func (c Config) WithHistogramType(t HistogramType) Config {
+	c.HistogramType = t
+	return c
+}
+
+// network returns the network and address to dial.
+func (c Config) network() (string, string) {
+	if path, ok := strings.CutPrefix(c.Address, unixPrefix); ok {
+		return "unixgram", path
+	}
+	return "udp", c.Address
+}
+
+// Validate checks that the configuration is valid and returns a copy with defaults applied.
+func (c Config) Validate() (Config, error) {
+	if c.Address == "" {
+		c.Address = DefaultAddress
+	}
+	if network, addr := c.network(); addr == "" {
+		return c, fmt.Errorf("statsd %s address is empty", network)
+	}
+	if c.FlushInterval < 0 || c.MaxPacketSize < 0 || c.MaxBufferedSamples < 0 {
+		return c, fmt.Errorf("statsd flush interval, packet size and buffer size must not be negative")
+	}
+	switch c.HistogramType {
+	case "":
+		c.HistogramType = HistogramTypeHistogram
+	case HistogramTypeHistogram, HistogramTypeDistribution:
+	default:
+		return c, fmt.Errorf("invalid statsd histogram type: %s", c.HistogramType)
+	}
+
+	if c.FlushInterval == 0 {
+		c.FlushInterval = DefaultFlushInterval
+	}
+	if c.MaxPacketSize == 0 {
+		c.MaxPacketSize = DefaultUDPPacketSize
+		if network, _ := c.network(); network == "unixgram" {
+			c.MaxPacketSize = DefaultUnixPacketSize
+		}
+	}
+	if c.MaxBufferedSamples == 0 {
+		c.MaxBufferedSamples = DefaultMaxBufferedSamples
+	}
+	if c.Logger == nil {
+		c.Logger = slog.Default()
+	}
+	return c, nil
+}
@@ -0,0 +1,75 @@
+package statsd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		wantErr    bool
+		wantPacket int
+	}{
+		{"defaults", Config{}, false, DefaultUDPPacketSize},
+		{"unix socket", Config{Address: "unix:///var/run/datadog/dsd.socket"}, false, DefaultUnixPacketSize},
+		{"distribution", Config{HistogramType: HistogramTypeDistribution}, false, DefaultUDPPacketSize},
+		{"empty unix path", Config{Address: "unix://"}, true, 0},
+		{"invalid histogram type", Config{HistogramType: "ms"}, true, 0},
+		{"negative interval", Config{FlushInterval: -time.Second}, true, 0},
+		{"negative packet size", Config{MaxPacketSize: -1}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.MaxPacketSize != tt.wantPacket {
+				t.Errorf("MaxPacketSize = %d, want %d", cfg.MaxPacketSize, tt.wantPacket)
+			}
+			if cfg.Address == "" || cfg.FlushInterval != DefaultFlushInterval ||
+				cfg.MaxBufferedSamples != DefaultMaxBufferedSamples || cfg.HistogramType == "" || cfg.Logger == nil {
+				t.Errorf("Validate() = %+v, want defaults applied", cfg)
+			}
+		})
+	}
+}
+
+func TestConfig_Setters(t *testing.T) {
+	t.Parallel()
+
+	base := Config{}.WithTag("env", "prod")
+	cfg := base.
+		WithAddress("unix:///var/run/datadog/dsd.socket").
+		WithPrefix("partner.").
+		WithTag("site", "lichinga").
+		WithFlushInterval(5 * time.Second).
+		WithMaxPacketSize(512).
+		WithHistogramType(HistogramTypeDistribution)
+
+	if cfg.Address != "unix:///var/run/datadog/dsd.socket" || cfg.Prefix != "partner." {
+		t.Errorf("Address/Prefix = %q/%q", cfg.Address, cfg.Prefix)
+	}
+	if cfg.Tags["env"] != "prod" || cfg.Tags["site"] != "lichinga" {
+		t.Errorf("Tags = %v", cfg.Tags)
+	}
+	if _, ok := base.Tags["site"]; ok {
+		t.Error("WithTag modified the original config")
+	}
+	if cfg.FlushInterval != 5*time.Second || cfg.MaxPacketSize != 512 || cfg.HistogramType != HistogramTypeDistribution {
+		t.Errorf("FlushInterval/MaxPacketSize/HistogramType = %v/%d/%q", cfg.FlushInterval, cfg.MaxPacketSize, cfg.HistogramType)
+	}
+	if network, addr := cfg.network(); network != "unixgram" || addr != "/var/run/datadog/dsd.socket" {
+		t.Errorf("network() = %s %s", network, addr)
+	}
+}
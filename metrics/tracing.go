@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TracingCollector collects self-metrics of the tracing pipeline.
+type TracingCollector struct {
+	redactionsTotal *counterVec
+	errorsTotal     *counterVec
+}
+
+// NewTracingCollector creates a new TracingCollector with the given configuration.
+func NewTracingCollector(cfg Config) (*TracingCollector, error) {
+	cfg, err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &TracingCollector{}
+
+	c.redactionsTotal, err = newCounterVec(cfg,
+		prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "tracing_redactions_total",
+			Help:        "Total number of span values redacted before export.",
+		},
+		[]string{"rule"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.errorsTotal, err = newCounterVec(cfg,
+		prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Subsystem:   cfg.Subsystem,
+			ConstLabels: cfg.ConstLabels,
+			Name:        "tracing_errors_total",
+			Help:        "Total number of errors recorded on spans.",
+		},
+		[]string{"type", "expected"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// RecordRedaction records a redacted span value.
+// rule: redaction rule that matched (e.g., "deny_key", "phone", "email", "hash").
+func (c *TracingCollector) RecordRedaction(rule string) {
+	c.redactionsTotal.WithLabelValues(rule).Inc()
+}
+
+// RecordError records an error recorded on a span.
+// errorType: error.type of the error (e.g., "timeout", "NotFound", "503").
+// expected: whether the error was marked as expected.
+func (c *TracingCollector) RecordError(errorType string, expected bool) {
+	c.errorsTotal.WithLabelValues(errorType, strconv.FormatBool(expected)).Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (c *TracingCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.redactionsTotal.Describe(ch)
+	c.errorsTotal.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *TracingCollector) Collect(ch chan<- prometheus.Metric) {
+	c.redactionsTotal.Collect(ch)
+	c.errorsTotal.Collect(ch)
+}
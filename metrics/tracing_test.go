@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewTracingCollector(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_tracing")
+
+	collector, err := NewTracingCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewTracingCollector() error = %v", err)
+	}
+	if collector == nil {
+		t.Fatal("NewTracingCollector() returned nil collector")
+	}
+}
+
+func TestNewTracingCollector_DuplicateRegistration(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_tracing_dup")
+
+	collector1, err := NewTracingCollector(cfg)
+	if err != nil {
+		t.Fatalf("First NewTracingCollector() error = %v", err)
+	}
+
+	collector2, err := NewTracingCollector(cfg)
+	if err != nil {
+		t.Fatalf("Second NewTracingCollector() error = %v", err)
+	}
+
+	if collector1 == nil || collector2 == nil {
+		t.Fatal("NewTracingCollector() returned nil collectors")
+	}
+}
+
+func TestTracingCollector_RecordRedaction(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_tracing_redaction")
+
+	collector, err := NewTracingCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewTracingCollector() error = %v", err)
+	}
+
+	collector.RecordRedaction("phone")
+	collector.RecordRedaction("phone")
+	collector.RecordRedaction("hash")
+
+	if count := testutil.ToFloat64(collector.redactionsTotal.WithLabelValues("phone")); count != 2 {
+		t.Errorf("redactionsTotal phone = %v, want 2", count)
+	}
+	if count := testutil.ToFloat64(collector.redactionsTotal.WithLabelValues("hash")); count != 1 {
+		t.Errorf("redactionsTotal hash = %v, want 1", count)
+	}
+}
+
+func TestTracingCollector_RecordError(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	cfg := DefaultConfig().WithRegistry(registry).WithSubsystem("test_tracing_errors")
+
+	collector, err := NewTracingCollector(cfg)
+	if err != nil {
+		t.Fatalf("NewTracingCollector() error = %v", err)
+	}
+
+	collector.RecordError("timeout", false)
+	collector.RecordError("timeout", false)
+	collector.RecordError("ride_cancelled", true)
+
+	if count := testutil.ToFloat64(collector.errorsTotal.WithLabelValues("timeout", "false")); count != 2 {
+		t.Errorf("errorsTotal timeout = %v, want 2", count)
+	}
+	if count := testutil.ToFloat64(collector.errorsTotal.WithLabelValues("ride_cancelled", "true")); count != 1 {
+		t.Errorf("errorsTotal ride_cancelled expected = %v, want 1", count)
+	}
+}
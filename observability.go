@@ -1,17 +1,27 @@
 // Package observability provides a unified observability solution for Txova services,
-// including metrics collection, distributed tracing, and health checks.
+// including metrics collection, distributed tracing, log export, and health checks.
 package observability
 
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/sdk/resource"
+
 	"github.com/Dorico-Dynamics/txova-go-observability/health"
+	"github.com/Dorico-Dynamics/txova-go-observability/jobs"
+	"github.com/Dorico-Dynamics/txova-go-observability/logging"
 	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics/otlp"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics/remotewrite"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics/statsd"
 	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
 )
 
@@ -31,14 +41,46 @@ type Config struct {
 	// Health configuration.
 	Health health.ManagerConfig
 
-	// Enabled flags for each subsystem.
-	MetricsEnabled bool
-	TracingEnabled bool
-	HealthEnabled  bool
+	// Logging configuration. The exporter and resource settings are shared
+	// with Tracing.
+	Logging logging.Config
+
+	// Push configures pushing metrics to a Prometheus Pushgateway. If
+	// Push.Job is empty, Tracing.ServiceName is used.
+	Push metrics.PushConfig
+
+	// OTLPMetrics configures exporting the metrics registry via OTLP. The
+	// exporter and resource settings are shared with Tracing.
+	OTLPMetrics otlp.Config
+
+	// RemoteWrite configures sending metrics to a Prometheus remote-write
+	// endpoint.
+	RemoteWrite remotewrite.Config
+
+	// StatsD configures sending the values recorded by the collectors to a
+	// StatsD server, in addition to the Prometheus registry.
+	StatsD statsd.Config
+
+	// Enabled flags for each subsystem. PushEnabled, OTLPMetricsEnabled,
+	// RemoteWriteEnabled and StatsDEnabled require MetricsEnabled.
+	MetricsEnabled     bool
+	TracingEnabled     bool
+	HealthEnabled      bool
+	LoggingEnabled     bool
+	PushEnabled        bool
+	OTLPMetricsEnabled bool
+	RemoteWriteEnabled bool
+	StatsDEnabled      bool
 
 	// PathLabeler extracts a normalized path label for metrics.
 	// If nil, defaults to returning "/unknown" to prevent cardinality explosion.
 	PathLabeler PathLabeler
+
+	// MetricsResourceLabels applies the stable tracing resource attributes
+	// (service, environment, Kubernetes namespace and cluster, and user-supplied
+	// attributes) as constant labels on every metric. Explicit
+	// Metrics.ConstLabels take precedence.
+	MetricsResourceLabels bool
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -47,6 +89,8 @@ func DefaultConfig() Config {
 		Metrics:        metrics.DefaultConfig(),
 		Tracing:        tracing.DefaultConfig(),
 		Health:         health.DefaultManagerConfig(),
+		Logging:        logging.DefaultConfig(),
+		OTLPMetrics:    otlp.DefaultConfig(),
 		MetricsEnabled: true,
 		TracingEnabled: true,
 		HealthEnabled:  true,
@@ -63,15 +107,24 @@ type Observability struct {
 	// Tracer is the OpenTelemetry tracer.
 	Tracer *tracing.Tracer
 
+	// LogProvider exports logs through OpenTelemetry.
+	LogProvider *logging.Provider
+
 	// HealthManager manages health checks.
 	HealthManager *health.Manager
 
 	// HealthHandler provides HTTP handlers for health endpoints.
 	HealthHandler *health.Handler
 
+	// HealthGRPCServer serves the gRPC health checking protocol.
+	HealthGRPCServer *health.GRPCServer
+
 	// HTTPCollector collects HTTP metrics.
 	HTTPCollector *metrics.HTTPCollector
 
+	// GRPCCollector collects gRPC server and client metrics.
+	GRPCCollector *metrics.GRPCCollector
+
 	// DBCollector collects database metrics.
 	DBCollector *metrics.DBCollector
 
@@ -92,6 +145,29 @@ type Observability struct {
 
 	// SafetyCollector collects safety metrics.
 	SafetyCollector *metrics.SafetyCollector
+
+	// TracingCollector collects tracing pipeline self-metrics.
+	TracingCollector *metrics.TracingCollector
+
+	// JobCollector collects scheduled and batch job metrics.
+	JobCollector *metrics.JobCollector
+
+	// Pusher pushes metrics to a Pushgateway when PushEnabled is set.
+	Pusher *metrics.Pusher
+
+	// MetricsExporter exports metrics via OTLP when OTLPMetricsEnabled is set.
+	MetricsExporter *otlp.Exporter
+
+	// RemoteWriteCollector collects remote-write self-metrics when
+	// RemoteWriteEnabled is set.
+	RemoteWriteCollector *metrics.RemoteWriteCollector
+
+	// RemoteWriter sends metrics to a remote-write endpoint when
+	// RemoteWriteEnabled is set.
+	RemoteWriter *remotewrite.Writer
+
+	// StatsD sends collector values to StatsD when StatsDEnabled is set.
+	StatsD *statsd.Client
 }
 
 // defaultPathLabeler returns a safe default path to prevent cardinality explosion.
@@ -100,7 +176,9 @@ func defaultPathLabeler(r *http.Request) string {
 }
 
 // New creates a new Observability instance with the given configuration.
-func New(ctx context.Context, cfg *Config) (*Observability, error) {
+// If any subsystem fails to initialize, those already created are shut down
+// before the error is returned.
+func New(ctx context.Context, cfg *Config) (_ *Observability, err error) {
 	if cfg == nil {
 		defaultCfg := DefaultConfig()
 		cfg = &defaultCfg
@@ -115,68 +193,303 @@ func New(ctx context.Context, cfg *Config) (*Observability, error) {
 		config:      *cfg,
 		pathLabeler: pathLabeler,
 	}
+	// Release the StatsD client, tracer and metric reader created before a failure.
+	defer func() {
+		if err != nil {
+			_ = obs.Close(ctx)
+		}
+	}()
+
+	var metricsCfg metrics.Config
+	if cfg.MetricsEnabled {
+		metricsCfg, err = obs.metricsConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize the StatsD backend before any collector is created.
+	if cfg.MetricsEnabled && cfg.StatsDEnabled {
+		if err := obs.initStatsD(&metricsCfg); err != nil {
+			return nil, err
+		}
+	}
 
 	// Initialize tracing.
 	if cfg.TracingEnabled {
-		tracer, err := tracing.New(ctx, cfg.Tracing)
-		if err != nil {
-			return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+		if err := obs.initTracing(ctx, metricsCfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// Initialize log export.
+	if cfg.LoggingEnabled {
+		if err := obs.initLogging(ctx); err != nil {
+			return nil, err
 		}
-		obs.Tracer = tracer
 	}
 
 	// Initialize health manager.
 	if cfg.HealthEnabled {
 		obs.HealthManager = health.NewManager(cfg.Health)
 		obs.HealthHandler = health.NewHandler(obs.HealthManager)
+		obs.HealthGRPCServer = health.NewGRPCServer(obs.HealthManager)
 	}
 
 	// Initialize metrics collectors.
 	if cfg.MetricsEnabled {
-		var err error
-
-		obs.HTTPCollector, err = metrics.NewHTTPCollector(cfg.Metrics)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create HTTP collector: %w", err)
+		if err := obs.initCollectors(metricsCfg); err != nil {
+			return nil, err
 		}
+	}
 
-		obs.DBCollector, err = metrics.NewDBCollector(cfg.Metrics)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create DB collector: %w", err)
+	// Initialize Pushgateway pushing.
+	if cfg.MetricsEnabled && cfg.PushEnabled {
+		if err := obs.initPusher(metricsCfg); err != nil {
+			return nil, err
 		}
+	}
 
-		obs.RedisCollector, err = metrics.NewRedisCollector(cfg.Metrics)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Redis collector: %w", err)
+	// Initialize OTLP metrics export.
+	if cfg.MetricsEnabled && cfg.OTLPMetricsEnabled {
+		if err := obs.initMetricsExporter(ctx, metricsCfg); err != nil {
+			return nil, err
 		}
+	}
 
-		obs.KafkaCollector, err = metrics.NewKafkaCollector(cfg.Metrics)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Kafka collector: %w", err)
+	// Initialize remote write.
+	if cfg.MetricsEnabled && cfg.RemoteWriteEnabled {
+		if err := obs.initRemoteWrite(metricsCfg); err != nil {
+			return nil, err
 		}
+	}
 
-		obs.RideCollector, err = metrics.NewRideCollector(cfg.Metrics)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Ride collector: %w", err)
-		}
+	return obs, nil
+}
 
-		obs.DriverCollector, err = metrics.NewDriverCollector(cfg.Metrics)
+// initTracing creates the tracer, recording its self-metrics when metrics are enabled.
+func (o *Observability) initTracing(ctx context.Context, metricsCfg metrics.Config) error {
+	tracingCfg := o.config.Tracing
+	if o.config.MetricsEnabled {
+		var err error
+		o.TracingCollector, err = metrics.NewTracingCollector(metricsCfg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create Driver collector: %w", err)
+			return fmt.Errorf("failed to create Tracing collector: %w", err)
 		}
-
-		obs.PaymentCollector, err = metrics.NewPaymentCollector(cfg.Metrics)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Payment collector: %w", err)
+		if tracingCfg.Metrics == nil {
+			tracingCfg.Metrics = o.TracingCollector
 		}
+	}
 
-		obs.SafetyCollector, err = metrics.NewSafetyCollector(cfg.Metrics)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Safety collector: %w", err)
+	tracer, err := tracing.New(ctx, tracingCfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	o.Tracer = tracer
+	return nil
+}
+
+// initLogging creates the log provider, sharing the tracer's resource when
+// tracing is enabled.
+func (o *Observability) initLogging(ctx context.Context) error {
+	loggingCfg := o.config.Logging
+	if loggingCfg.Resource == nil && o.Tracer != nil {
+		loggingCfg.Resource = o.Tracer.Resource()
+	}
+
+	provider, err := logging.New(ctx, o.config.Tracing, loggingCfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize logging: %w", err)
+	}
+	o.LogProvider = provider
+	return nil
+}
+
+// initCollectors creates the metric collectors.
+func (o *Observability) initCollectors(metricsCfg metrics.Config) error {
+	var err error
+	o.HTTPCollector, err = metrics.NewHTTPCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP collector: %w", err)
+	}
+
+	o.GRPCCollector, err = metrics.NewGRPCCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC collector: %w", err)
+	}
+
+	o.DBCollector, err = metrics.NewDBCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create DB collector: %w", err)
+	}
+
+	o.RedisCollector, err = metrics.NewRedisCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Redis collector: %w", err)
+	}
+
+	o.KafkaCollector, err = metrics.NewKafkaCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka collector: %w", err)
+	}
+
+	o.RideCollector, err = metrics.NewRideCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Ride collector: %w", err)
+	}
+
+	o.DriverCollector, err = metrics.NewDriverCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Driver collector: %w", err)
+	}
+
+	o.PaymentCollector, err = metrics.NewPaymentCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Payment collector: %w", err)
+	}
+
+	o.SafetyCollector, err = metrics.NewSafetyCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Safety collector: %w", err)
+	}
+
+	o.JobCollector, err = metrics.NewJobCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Job collector: %w", err)
+	}
+
+	return nil
+}
+
+// initPusher creates the Pushgateway pusher for the metrics registry.
+func (o *Observability) initPusher(metricsCfg metrics.Config) error {
+	pushCfg := o.config.Push
+	if pushCfg.Job == "" {
+		pushCfg.Job = o.config.Tracing.ServiceName
+	}
+
+	pusher, err := metrics.NewPusher(metricsCfg, pushCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics pusher: %w", err)
+	}
+	o.Pusher = pusher
+	return nil
+}
+
+// initMetricsExporter creates the OTLP exporter for the metrics registry,
+// sharing the tracer's resource when tracing is enabled.
+func (o *Observability) initMetricsExporter(ctx context.Context, metricsCfg metrics.Config) error {
+	exporterCfg := o.config.OTLPMetrics
+	if exporterCfg.Resource == nil && o.Tracer != nil {
+		exporterCfg.Resource = o.Tracer.Resource()
+	}
+
+	exporter, err := otlp.New(ctx, metricsCfg, o.config.Tracing, exporterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize OTLP metrics export: %w", err)
+	}
+	o.MetricsExporter = exporter
+	return nil
+}
+
+// initRemoteWrite creates the remote-write client for the metrics registry,
+// recording its self-metrics in the same registry.
+func (o *Observability) initRemoteWrite(metricsCfg metrics.Config) error {
+	var err error
+	o.RemoteWriteCollector, err = metrics.NewRemoteWriteCollector(metricsCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create RemoteWrite collector: %w", err)
+	}
+
+	rwCfg := o.config.RemoteWrite
+	if rwCfg.Metrics == nil {
+		rwCfg.Metrics = o.RemoteWriteCollector
+	}
+	writer, err := remotewrite.New(metricsCfg, rwCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create remote writer: %w", err)
+	}
+	o.RemoteWriter = writer
+	return nil
+}
+
+// initStatsD creates the StatsD client and sets it as the collectors' backend.
+func (o *Observability) initStatsD(metricsCfg *metrics.Config) error {
+	client, err := statsd.New(o.config.StatsD)
+	if err != nil {
+		return fmt.Errorf("failed to create StatsD client: %w", err)
+	}
+	o.StatsD = client
+	metricsCfg.Backend = client
+	return nil
+}
+
+// metricsConfig returns the metrics configuration, with resource attributes
+// applied as constant labels when MetricsResourceLabels is enabled.
+func (o *Observability) metricsConfig(ctx context.Context) (metrics.Config, error) {
+	cfg := o.config.Metrics
+	if !o.config.MetricsResourceLabels {
+		return cfg, nil
+	}
+
+	// Metrics are configured before the tracer so the tracer can record
+	// self-metrics; the instance ID that differs between the two resources is
+	// not used as a label.
+	res, err := tracing.NewResource(ctx, o.config.Tracing)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to detect resource for metric labels: %w", err)
+	}
+
+	labels := resourceLabels(res, o.config.Tracing.ResourceAttributes)
+	for k, v := range cfg.ConstLabels {
+		labels[k] = v
+	}
+	cfg.ConstLabels = labels
+	return cfg, nil
+}
+
+// resourceLabelKeys are the detected resource attributes applied as metric
+// labels. Only attributes that are stable across restarts are used: pod,
+// container, host and instance attributes would start new series every time
+// the process is replaced.
+var resourceLabelKeys = map[string]bool{
+	tracing.AttrServiceName:           true,
+	"service.namespace":               true,
+	tracing.AttrDeploymentEnvironment: true,
+	"deployment.environment.name":     true,
+	"k8s.namespace.name":              true,
+	"k8s.cluster.name":                true,
+}
+
+// resourceLabels converts the stable resource attributes and the explicitly
+// configured ones to Prometheus label names and values.
+func resourceLabels(res *resource.Resource, configured map[string]string) prometheus.Labels {
+	labels := prometheus.Labels{}
+	iter := res.Iter()
+	for iter.Next() {
+		kv := iter.Attribute()
+		key := string(kv.Key)
+		if _, ok := configured[key]; !ok && !resourceLabelKeys[key] {
+			continue
 		}
+		labels[sanitizeLabelName(key)] = kv.Value.Emit()
 	}
+	return labels
+}
 
-	return obs, nil
+// sanitizeLabelName replaces characters not allowed in Prometheus label names
+// and prefixes names starting with a digit.
+func sanitizeLabelName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
 }
 
 // Initialize starts all observability subsystems.
@@ -185,23 +498,67 @@ func (o *Observability) Initialize(ctx context.Context) error { //nolint:unparam
 	if o.HealthManager != nil && o.config.HealthEnabled {
 		o.HealthManager.StartBackground(ctx)
 	}
+	if o.Pusher != nil {
+		o.Pusher.Start(ctx)
+	}
+	if o.RemoteWriter != nil {
+		o.RemoteWriter.Start(ctx)
+	}
 	return nil
 }
 
 // Close shuts down all observability subsystems.
+// Every subsystem is shut down even if an earlier one fails, so one
+// unreachable backend does not lose the data buffered for the others; the
+// failures are joined into the returned error.
 // This implements the app.Closer interface from txova-go-core.
 func (o *Observability) Close(ctx context.Context) error {
+	if o.HealthGRPCServer != nil {
+		o.HealthGRPCServer.Shutdown()
+	}
 	if o.HealthManager != nil {
 		o.HealthManager.StopBackground()
 	}
 
+	var errs []error
 	if o.Tracer != nil {
 		if err := o.Tracer.Shutdown(ctx); err != nil {
-			return fmt.Errorf("failed to shutdown tracer: %w", err)
+			errs = append(errs, fmt.Errorf("failed to shutdown tracer: %w", err))
 		}
 	}
 
-	return nil
+	if o.LogProvider != nil {
+		if err := o.LogProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shutdown log provider: %w", err))
+		}
+	}
+
+	// Export metrics last so those recorded while flushing spans and logs are included.
+	if o.Pusher != nil {
+		if err := o.Pusher.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to push final metrics: %w", err))
+		}
+	}
+
+	if o.MetricsExporter != nil {
+		if err := o.MetricsExporter.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shutdown metrics exporter: %w", err))
+		}
+	}
+
+	if o.RemoteWriter != nil {
+		if err := o.RemoteWriter.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send final metrics via remote write: %w", err))
+		}
+	}
+
+	if o.StatsD != nil {
+		if err := o.StatsD.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush StatsD metrics: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 // HealthCheck returns the current health status.
@@ -218,28 +575,66 @@ func (o *Observability) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// MiddlewareOption configures HTTPMiddleware.
+type MiddlewareOption func(*middlewareOptions)
+
+// middlewareOptions holds the HTTPMiddleware options.
+type middlewareOptions struct {
+	filter  tracing.RequestFilter
+	tracing []tracing.MiddlewareOption
+}
+
+// WithRequestFilter sets the filter deciding which requests are traced and
+// recorded in request metrics. It replaces tracing.DefaultRequestFilter, which
+// skips the health endpoints; nil instruments every request.
+func WithRequestFilter(filter tracing.RequestFilter) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.filter = filter
+	}
+}
+
+// WithTracingOptions passes options such as span naming and header capture
+// to the tracing middleware.
+func WithTracingOptions(opts ...tracing.MiddlewareOption) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.tracing = append(o.tracing, opts...)
+	}
+}
+
 // HTTPMiddleware returns an HTTP middleware that adds tracing and metrics.
-func (o *Observability) HTTPMiddleware() func(http.Handler) http.Handler {
+func (o *Observability) HTTPMiddleware(opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	options := middlewareOptions{filter: tracing.DefaultRequestFilter}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	tracingOpts := append([]tracing.MiddlewareOption{tracing.WithFilter(options.filter)}, options.tracing...)
+
 	return func(next http.Handler) http.Handler {
 		handler := next
 
 		// Apply metrics middleware.
 		if o.HTTPCollector != nil {
-			handler = o.metricsMiddleware(handler)
+			handler = o.metricsMiddleware(handler, options.filter)
 		}
 
 		// Apply tracing middleware.
 		if o.Tracer != nil {
-			handler = tracing.Middleware(o.Tracer)(handler)
+			handler = tracing.Middleware(o.Tracer, tracingOpts...)(handler)
 		}
 
 		return handler
 	}
 }
 
-// metricsMiddleware wraps an HTTP handler to collect metrics.
-func (o *Observability) metricsMiddleware(next http.Handler) http.Handler {
+// metricsMiddleware wraps an HTTP handler to collect metrics for requests
+// accepted by filter.
+func (o *Observability) metricsMiddleware(next http.Handler, filter tracing.RequestFilter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if filter != nil && !filter(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		o.HTTPCollector.IncRequestsInFlight()
 		defer o.HTTPCollector.DecRequestsInFlight()
 
@@ -310,10 +705,33 @@ func (rw *responseWriter) Push(target string, opts *http.PushOptions) error {
 }
 
 // RegisterHealthChecker registers a health checker with the manager.
-func (o *Observability) RegisterHealthChecker(checker health.Checker) {
+// Options such as health.WithCheckTimeout override the manager settings
+// for this checker.
+func (o *Observability) RegisterHealthChecker(checker health.Checker, opts ...health.CheckerOption) {
 	if o.HealthManager != nil {
-		o.HealthManager.Register(checker)
+		o.HealthManager.Register(checker, opts...)
+	}
+}
+
+// NewJob creates an instrumented job using the tracer and job collector when
+// cfg does not set them, and registers its staleness health checker.
+func (o *Observability) NewJob(name string, fn jobs.Func, cfg jobs.Config) (*jobs.Job, error) { //nolint:gocritic // cfg passed by value for API simplicity
+	if cfg.Tracer == nil {
+		cfg.Tracer = o.Tracer
+	}
+	if cfg.Metrics == nil {
+		cfg.Metrics = o.JobCollector
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = o.config.Health.Logger
+	}
+
+	job, err := jobs.New(name, fn, cfg)
+	if err != nil {
+		return nil, err
 	}
+	o.RegisterHealthChecker(job.Checker())
+	return job, nil
 }
 
 // HTTPRoundTripper returns an HTTP RoundTripper with tracing.
@@ -1,16 +1,31 @@
 package observability
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
 
 	"github.com/Dorico-Dynamics/txova-go-observability/health"
+	"github.com/Dorico-Dynamics/txova-go-observability/jobs"
+	"github.com/Dorico-Dynamics/txova-go-observability/logging"
 	"github.com/Dorico-Dynamics/txova-go-observability/metrics"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics/otlp"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics/remotewrite"
+	"github.com/Dorico-Dynamics/txova-go-observability/metrics/statsd"
 	"github.com/Dorico-Dynamics/txova-go-observability/tracing"
 )
 
@@ -62,6 +77,9 @@ func TestNew_AllEnabled(t *testing.T) {
 	if obs.HealthHandler == nil {
 		t.Error("HealthHandler should not be nil")
 	}
+	if obs.HealthGRPCServer == nil {
+		t.Error("HealthGRPCServer should not be nil")
+	}
 	if obs.HTTPCollector == nil {
 		t.Error("HTTPCollector should not be nil")
 	}
@@ -86,6 +104,12 @@ func TestNew_AllEnabled(t *testing.T) {
 	if obs.SafetyCollector == nil {
 		t.Error("SafetyCollector should not be nil")
 	}
+	if obs.JobCollector == nil {
+		t.Error("JobCollector should not be nil")
+	}
+	if obs.GRPCCollector == nil {
+		t.Error("GRPCCollector should not be nil")
+	}
 }
 
 func TestNew_AllDisabled(t *testing.T) {
@@ -188,6 +212,43 @@ func TestObservability_Close(t *testing.T) {
 	}
 }
 
+func TestNew_Logging(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var buf bytes.Buffer
+	loggingCfg := logging.DefaultConfig()
+	loggingCfg.WithExporter(logging.ExporterStdout).WithWriter(&buf)
+	cfg := &Config{
+		Tracing: tracing.Config{
+			ServiceName: "test-service",
+			Exporter:    tracing.ExporterNone,
+		},
+		Logging:        loggingCfg,
+		TracingEnabled: true,
+		LoggingEnabled: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if obs.LogProvider == nil {
+		t.Fatal("LogProvider should be initialized")
+	}
+	if obs.LogProvider.Config().Resource != obs.Tracer.Resource() {
+		t.Error("LogProvider should share the tracer resource")
+	}
+
+	obs.LogProvider.Logger().InfoContext(ctx, "ride requested")
+	if err := obs.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "ride requested") {
+		t.Errorf("log record not exported on Close: %s", buf.String())
+	}
+}
+
 func TestObservability_HealthCheck_NoManager(t *testing.T) {
 	t.Parallel()
 
@@ -383,6 +444,351 @@ func TestObservability_RegisterHealthChecker_NoManager(t *testing.T) {
 	obs.RegisterHealthChecker(checker)
 }
 
+func TestObservability_NewJob(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	cfg := &Config{
+		Metrics: metrics.DefaultConfig().WithRegistry(registry).WithSubsystem("test_jobs"),
+		Tracing: tracing.Config{
+			ServiceName: "test-service",
+			Exporter:    tracing.ExporterNone,
+		},
+		Health:         health.DefaultManagerConfig().WithCacheTTL(0),
+		MetricsEnabled: true,
+		TracingEnabled: true,
+		HealthEnabled:  true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer obs.Close(ctx)
+
+	job, err := obs.NewJob("payouts", func(context.Context) error { return nil }, jobs.Config{Interval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewJob() error = %v", err)
+	}
+	if err := job.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if n, err := testutil.GatherAndCount(registry, "txova_test_jobs_job_runs_total"); err != nil || n != 1 {
+		t.Errorf("job_runs_total series = %d (err %v), want 1", n, err)
+	}
+	report := obs.HealthManager.Check(ctx)
+	if result, ok := report.Checks["job:payouts"]; !ok || result.Status != health.StatusHealthy {
+		t.Errorf("job checker = %+v (found %v), want healthy", result, ok)
+	}
+
+	if _, err := obs.NewJob("", nil, jobs.Config{}); err == nil {
+		t.Error("NewJob() with invalid arguments should fail")
+	}
+}
+
+func TestObservability_Pusher(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gateway.Close()
+
+	ctx := context.Background()
+	cfg := &Config{
+		Metrics: metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithSubsystem("test_push"),
+		Tracing: tracing.Config{
+			ServiceName: "backfill-service",
+			Exporter:    tracing.ExporterNone,
+		},
+		Push:           metrics.PushConfig{URL: gateway.URL},
+		MetricsEnabled: true,
+		TracingEnabled: true,
+		PushEnabled:    true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if obs.Pusher == nil {
+		t.Fatal("Pusher should be created when push is enabled")
+	}
+	if err := obs.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if err := obs.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 1 || paths[0] != "PUT /metrics/job/backfill-service" {
+		t.Errorf("pushes = %v, want one PUT for the service job", paths)
+	}
+}
+
+func TestNew_PusherInvalid(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Metrics:        metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()),
+		MetricsEnabled: true,
+		PushEnabled:    true,
+	}
+	if _, err := New(context.Background(), cfg); err == nil {
+		t.Error("New() with push enabled and no Pushgateway URL should fail")
+	}
+}
+
+func TestObservability_MetricsExporter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	ctx := context.Background()
+	cfg := &Config{
+		Metrics: metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithSubsystem("test_otlp"),
+		Tracing: tracing.Config{
+			ServiceName: "ride-service",
+			Exporter:    tracing.ExporterNone,
+			Endpoint:    strings.TrimPrefix(collector.URL, "http://"),
+			Insecure:    true,
+		},
+		OTLPMetrics:        otlp.Config{Exporter: otlp.ExporterOTLPHTTP, Interval: time.Hour},
+		MetricsEnabled:     true,
+		TracingEnabled:     true,
+		OTLPMetricsEnabled: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if obs.MetricsExporter == nil {
+		t.Fatal("MetricsExporter should be created when OTLP metrics are enabled")
+	}
+	if obs.MetricsExporter.Config().Resource != obs.Tracer.Resource() {
+		t.Error("MetricsExporter should share the tracer resource")
+	}
+	obs.HTTPCollector.RecordRequest("GET", "/rides", http.StatusOK, time.Millisecond)
+	if err := obs.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 1 || paths[0] != "POST "+otlp.DefaultURLPath {
+		t.Errorf("exports = %v, want one POST on shutdown", paths)
+	}
+}
+
+func TestNew_MetricsExporterInvalid(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Metrics:            metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()),
+		Tracing:            tracing.Config{ServiceName: "ride-service", Exporter: tracing.ExporterNone},
+		OTLPMetrics:        otlp.Config{Exporter: "statsd"},
+		MetricsEnabled:     true,
+		OTLPMetricsEnabled: true,
+	}
+	if _, err := New(context.Background(), cfg); err == nil {
+		t.Error("New() with an invalid OTLP metrics exporter should fail")
+	}
+}
+
+func TestNew_FailureShutsDownCreatedSubsystems(t *testing.T) {
+	t.Parallel()
+
+	var exports atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		exports.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	// Remote write is initialized after the OTLP metric reader and fails without a URL.
+	cfg := &Config{
+		Metrics: metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithSubsystem("test_new_cleanup"),
+		Tracing: tracing.Config{
+			ServiceName: "ride-service",
+			Exporter:    tracing.ExporterNone,
+			Endpoint:    strings.TrimPrefix(collector.URL, "http://"),
+			Insecure:    true,
+		},
+		OTLPMetrics:        otlp.Config{Exporter: otlp.ExporterOTLPHTTP, Interval: time.Hour},
+		MetricsEnabled:     true,
+		TracingEnabled:     true,
+		OTLPMetricsEnabled: true,
+		RemoteWriteEnabled: true,
+	}
+	if _, err := New(context.Background(), cfg); err == nil {
+		t.Fatal("New() with remote write enabled and no URL should fail")
+	}
+	if exports.Load() != 1 {
+		t.Errorf("exports = %d, want the metric reader shut down with one final export", exports.Load())
+	}
+}
+
+func TestObservability_RemoteWriter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer endpoint.Close()
+
+	ctx := context.Background()
+	cfg := &Config{
+		Metrics:            metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithSubsystem("test_rw"),
+		RemoteWrite:        remotewrite.Config{URL: endpoint.URL, Interval: time.Hour},
+		MetricsEnabled:     true,
+		RemoteWriteEnabled: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if obs.RemoteWriter == nil || obs.RemoteWriteCollector == nil {
+		t.Fatal("RemoteWriter and RemoteWriteCollector should be created when remote write is enabled")
+	}
+	if obs.RemoteWriter.Config().Metrics != obs.RemoteWriteCollector {
+		t.Error("RemoteWriter should record self-metrics with RemoteWriteCollector")
+	}
+	if err := obs.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if err := obs.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests == 0 {
+		t.Error("Close() should send the final metrics")
+	}
+}
+
+func TestObservability_CloseContinuesAfterError(t *testing.T) {
+	t.Parallel()
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer gateway.Close()
+
+	var written atomic.Bool
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		written.Store(true)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer endpoint.Close()
+
+	ctx := context.Background()
+	cfg := &Config{
+		Metrics:            metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithSubsystem("test_close_errors"),
+		Tracing:            tracing.Config{ServiceName: "backfill-service"},
+		Push:               metrics.PushConfig{URL: gateway.URL},
+		RemoteWrite:        remotewrite.Config{URL: endpoint.URL, Interval: time.Hour},
+		MetricsEnabled:     true,
+		PushEnabled:        true,
+		RemoteWriteEnabled: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := obs.Close(ctx); err == nil {
+		t.Error("Close() should report the failed Pushgateway push")
+	}
+	if !written.Load() {
+		t.Error("Close() should still send the final metrics via remote write after a failed push")
+	}
+}
+
+func TestNew_RemoteWriterInvalid(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Metrics:            metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()),
+		MetricsEnabled:     true,
+		RemoteWriteEnabled: true,
+	}
+	if _, err := New(context.Background(), cfg); err == nil {
+		t.Error("New() with remote write enabled and no URL should fail")
+	}
+}
+
+func TestObservability_StatsD(t *testing.T) {
+	t.Parallel()
+
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+	cfg := &Config{
+		Metrics:        metrics.DefaultConfig().WithRegistry(prometheus.NewRegistry()).WithSubsystem("test_statsd"),
+		StatsD:         statsd.Config{Address: server.LocalAddr().String(), FlushInterval: time.Hour},
+		MetricsEnabled: true,
+		StatsDEnabled:  true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if obs.StatsD == nil {
+		t.Fatal("StatsD should be created when StatsD is enabled")
+	}
+	obs.PaymentCollector.RecordPayment("mpesa", "success")
+	if err := obs.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	buf := make([]byte, 1024)
+	_ = server.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := server.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if want := "txova_test_statsd_payments_total:1|c|#method:mpesa,status:success"; string(buf[:n]) != want {
+		t.Errorf("packet = %q, want %q", buf[:n], want)
+	}
+}
+
 func TestObservability_HTTPRoundTripper_WithTracer(t *testing.T) {
 	t.Parallel()
 
@@ -517,3 +923,216 @@ func TestNew_NilConfig(t *testing.T) {
 		t.Errorf("Tracing.ServiceName = %v, want %v", obs.config.Tracing.ServiceName, defaultCfg.Tracing.ServiceName)
 	}
 }
+
+func TestNew_MetricsResourceLabels(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	cfg := &Config{
+		Metrics: metrics.DefaultConfig().
+			WithRegistry(registry).
+			WithSubsystem("test_resource_labels").
+			WithConstLabels(prometheus.Labels{"team": "rides"}),
+		Tracing: tracing.Config{
+			ServiceName:        "test-service",
+			Exporter:           tracing.ExporterNone,
+			Environment:        "staging",
+			ResourceAttributes: map[string]string{"team": "ignored", "region": "maputo"},
+		},
+		MetricsEnabled:        true,
+		TracingEnabled:        true,
+		MetricsResourceLabels: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer obs.Close(ctx)
+
+	obs.RideCollector.RecordRideRequested("standard", "maputo")
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	want := map[string]string{
+		"deployment_environment": "staging",
+		"service_name":           "test-service",
+		"region":                 "maputo",
+		"team":                   "rides",
+	}
+	for _, mf := range families {
+		if mf.GetName() != "txova_test_resource_labels_rides_requested_total" {
+			continue
+		}
+		got := make(map[string]string)
+		for _, lp := range mf.GetMetric()[0].GetLabel() {
+			got[lp.GetName()] = lp.GetValue()
+		}
+		for name, value := range want {
+			if got[name] != value {
+				t.Errorf("label %s = %q, want %q", name, got[name], value)
+			}
+		}
+		for _, name := range []string{"service_instance_id", "process_pid", "telemetry_sdk_name"} {
+			if _, ok := got[name]; ok {
+				t.Errorf("label %s should not be applied", name)
+			}
+		}
+		return
+	}
+	t.Error("rides_requested_total metric not found")
+}
+
+func TestResourceLabels(t *testing.T) {
+	t.Parallel()
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", "ride-service"),
+		attribute.String("k8s.namespace.name", "rides"),
+		attribute.String("k8s.cluster.name", "maputo-1"),
+		attribute.String("k8s.pod.name", "ride-service-7d9f"),
+		attribute.String("k8s.pod.uid", "0d4f76f5"),
+		attribute.String("container.id", "126dc0a8"),
+		attribute.String("host.name", "node-3"),
+		attribute.String("region", "maputo"),
+	)
+
+	got := resourceLabels(res, map[string]string{"region": "maputo"})
+	want := prometheus.Labels{
+		"service_name":       "ride-service",
+		"k8s_namespace_name": "rides",
+		"k8s_cluster_name":   "maputo-1",
+		"region":             "maputo",
+	}
+	if len(got) != len(want) {
+		t.Errorf("resourceLabels() = %v, want %v", got, want)
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("label %s = %q, want %q", name, got[name], value)
+		}
+	}
+}
+
+func TestSanitizeLabelName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"k8s.namespace.name": "k8s_namespace_name",
+		"3scale.app":         "_3scale_app",
+		"region":             "region",
+	}
+	for name, want := range tests {
+		if got := sanitizeLabelName(name); got != want {
+			t.Errorf("sanitizeLabelName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNew_TracingCollector(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := &Config{
+		Metrics: metrics.DefaultConfig().
+			WithRegistry(prometheus.NewRegistry()).
+			WithSubsystem("test_tracing_collector"),
+		Tracing: tracing.Config{
+			ServiceName: "test-service",
+			Exporter:    tracing.ExporterNone,
+		},
+		MetricsEnabled: true,
+		TracingEnabled: true,
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer obs.Close(ctx)
+
+	if obs.TracingCollector == nil {
+		t.Fatal("TracingCollector should be created when metrics and tracing are enabled")
+	}
+	if obs.Tracer.Config().Metrics != obs.TracingCollector {
+		t.Error("tracer should record self-metrics on the TracingCollector")
+	}
+}
+
+func TestDefaultSkipPaths(t *testing.T) {
+	t.Parallel()
+
+	// tracing does not import health, so its default list must follow the routes.
+	if !slices.Equal(tracing.DefaultSkipPaths, health.Paths()) {
+		t.Errorf("tracing.DefaultSkipPaths = %v, want health.Paths() %v", tracing.DefaultSkipPaths, health.Paths())
+	}
+	for _, path := range health.Paths() {
+		if tracing.DefaultRequestFilter(httptest.NewRequest(http.MethodGet, path, nil)) {
+			t.Errorf("tracing.DefaultRequestFilter(%s) = true, want health routes skipped", path)
+		}
+	}
+}
+
+func TestObservability_HTTPMiddleware_RequestFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := prometheus.NewRegistry()
+	cfg := &Config{
+		Metrics:        metrics.DefaultConfig().WithRegistry(registry).WithSubsystem("test_mw_filter"),
+		MetricsEnabled: true,
+		PathLabeler:    func(r *http.Request) string { return r.URL.Path },
+	}
+
+	obs, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer obs.Close(ctx)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		opts   []MiddlewareOption
+		path   string
+		record bool
+	}{
+		{"default skips health", nil, health.PathLive, false},
+		{"default records api", nil, "/api/default", true},
+		{"custom filter", []MiddlewareOption{WithRequestFilter(tracing.SkipPaths("/metrics"))}, "/metrics", false},
+		{"nil filter records health", []MiddlewareOption{WithRequestFilter(nil)}, health.PathReady, true},
+	}
+
+	for _, tt := range tests {
+		wrapped := obs.HTTPMiddleware(tt.opts...)(handler)
+		wrapped.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Gather() error = %v", err)
+		}
+		found := false
+		for _, mf := range families {
+			if mf.GetName() != "txova_test_mw_filter_http_requests_total" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				for _, lp := range m.GetLabel() {
+					if lp.GetName() == "path" && lp.GetValue() == tt.path {
+						found = true
+					}
+				}
+			}
+		}
+		if found != tt.record {
+			t.Errorf("%s: recorded = %v, want %v", tt.name, found, tt.record)
+		}
+	}
+}
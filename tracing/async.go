@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartDetached starts a span for background work that outlives the current
+// request. The returned context keeps the values of ctx, such as baggage and
+// the request ID, but is never canceled with it. The span starts a new trace
+// linked to the current span, so the request trace ends when the request does.
+func (t *Tracer) StartDetached(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return t.startLinked(context.WithoutCancel(ctx), trace.SpanContextFromContext(ctx), name, opts)
+}
+
+// StartLinked resumes deferred or queued work captured with
+// SpanContextFromContext. It restores the baggage and request ID of sc into
+// ctx and starts a span in a new trace linked to the span that queued the work.
+// An empty sc starts an unlinked root span.
+func (t *Tracer) StartLinked(ctx context.Context, sc SpanContext, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) { //nolint:gocritic // sc passed by value for API simplicity
+	remote := sc.extract(context.Background())
+	if b := baggage.FromContext(remote); b.Len() > 0 {
+		ctx = baggage.ContextWithBaggage(ctx, b)
+	}
+	if sc.RequestID != "" && RequestIDFromContext(ctx) == "" {
+		ctx = ContextWithRequestID(ctx, sc.RequestID)
+	}
+	return t.startLinked(ctx, trace.SpanContextFromContext(remote), name, opts)
+}
+
+// startLinked starts a root span linked to parent, if valid.
+func (t *Tracer) startLinked(ctx context.Context, parent trace.SpanContext, name string, opts []trace.SpanStartOption) (context.Context, trace.Span) {
+	opts = append([]trace.SpanStartOption{trace.WithNewRoot()}, opts...)
+	if parent.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: parent}))
+	}
+	return t.tracer.Start(ctx, name, opts...)
+}
+
+// Group runs goroutines as child spans of the span in the group's context,
+// in the style of errgroup. The first error cancels the group's context and
+// is returned by Wait.
+type Group struct {
+	tracer *Tracer
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup returns a Group and a derived context that is canceled when a
+// goroutine in the group fails or Wait returns.
+func (t *Tracer) NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{tracer: t, ctx: ctx, cancel: cancel}, ctx
+}
+
+// SetLimit limits the number of goroutines running at once; Go blocks until
+// one finishes. A limit of zero or less removes the limit. It must not be
+// called while goroutines are running.
+func (g *Group) SetLimit(n int) {
+	if n <= 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs fn in a new goroutine within a child span named name. An error
+// returned by fn is recorded on the span.
+func (g *Group) Go(name string, fn func(ctx context.Context) error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+
+	go func() {
+		defer g.done()
+
+		ctx, span := g.tracer.Start(g.ctx, name)
+		defer span.End()
+
+		if err := fn(ctx); err != nil {
+			g.tracer.errors.record(span, err, nil, 0)
+			g.errOnce.Do(func() {
+				g.err = err
+				g.cancel(err)
+			})
+		}
+	}()
+}
+
+// done releases the goroutine's slot.
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// Wait blocks until all goroutines have returned and returns the first error.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(g.err)
+	return g.err
+}
@@ -0,0 +1,207 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracer_StartDetached(t *testing.T) {
+	t.Parallel()
+
+	tracer, spans := newRecordingTracer(t)
+
+	ctx := contextWithBaggage(t, map[string]string{"ride.id": "r-1"})
+	ctx = ContextWithRequestID(ctx, "req-1")
+	ctx, cancel := context.WithCancel(ctx)
+	ctx, parent := tracer.Start(ctx, "request")
+
+	detachedCtx, span := tracer.StartDetached(ctx, "notify.driver")
+	cancel()
+	parent.End()
+
+	if detachedCtx.Err() != nil {
+		t.Errorf("detached context error = %v, want nil after parent cancellation", detachedCtx.Err())
+	}
+	if got := baggage.FromContext(detachedCtx).Member("ride.id").Value(); got != "r-1" {
+		t.Errorf("baggage ride.id = %q, want r-1", got)
+	}
+	if got := RequestIDFromContext(detachedCtx); got != "req-1" {
+		t.Errorf("request ID = %q, want req-1", got)
+	}
+	span.End()
+
+	s := findSpan(spans.Ended(), "notify.driver")
+	if s == nil {
+		t.Fatal("detached span not found")
+	}
+	if s.Parent().IsValid() {
+		t.Error("detached span should start a new trace")
+	}
+	if s.SpanContext().TraceID() == parent.SpanContext().TraceID() {
+		t.Error("detached span shares the request trace ID")
+	}
+	if len(s.Links()) != 1 || s.Links()[0].SpanContext.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("links = %v, want link to the request span", s.Links())
+	}
+}
+
+func TestTracer_StartLinked(t *testing.T) {
+	t.Parallel()
+
+	tracer, spans := newRecordingTracer(t)
+
+	ctx := contextWithBaggage(t, map[string]string{"ride.id": "r-1"})
+	ctx = ContextWithRequestID(ctx, "req-1")
+	ctx, producer := tracer.Start(ctx, "enqueue")
+	sc := SpanContextFromContext(ctx)
+	producer.End()
+
+	if sc.Baggage != "ride.id=r-1" || sc.RequestID != "req-1" {
+		t.Errorf("SpanContextFromContext() = %+v, want baggage and request ID", sc)
+	}
+
+	workerCtx, span := tracer.StartLinked(context.Background(), sc, "process")
+	span.End()
+
+	if got := baggage.FromContext(workerCtx).Member("ride.id").Value(); got != "r-1" {
+		t.Errorf("baggage ride.id = %q, want r-1", got)
+	}
+	if got := RequestIDFromContext(workerCtx); got != "req-1" {
+		t.Errorf("request ID = %q, want req-1", got)
+	}
+
+	s := findSpan(spans.Ended(), "process")
+	if s == nil {
+		t.Fatal("linked span not found")
+	}
+	if s.Parent().IsValid() {
+		t.Error("linked span should start a new trace")
+	}
+	if len(s.Links()) != 1 || s.Links()[0].SpanContext.SpanID() != producer.SpanContext().SpanID() {
+		t.Errorf("links = %v, want link to the enqueue span", s.Links())
+	}
+}
+
+func TestTracer_StartLinked_Empty(t *testing.T) {
+	t.Parallel()
+
+	tracer, spans := newRecordingTracer(t)
+
+	ctx := ContextWithRequestID(context.Background(), "worker-req")
+	ctx, span := tracer.StartLinked(ctx, SpanContext{RequestID: "queued-req"}, "process")
+	span.End()
+
+	if got := RequestIDFromContext(ctx); got != "worker-req" {
+		t.Errorf("request ID = %q, want the worker's own request ID", got)
+	}
+	s := findSpan(spans.Ended(), "process")
+	if s == nil {
+		t.Fatal("span not found")
+	}
+	if len(s.Links()) != 0 {
+		t.Errorf("links = %v, want none for an empty span context", s.Links())
+	}
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	tracer, spans := newRecordingTracer(t)
+	ctx, parent := tracer.Start(context.Background(), "match")
+
+	g, gctx := tracer.NewGroup(ctx)
+	for _, name := range []string{"match.nearby", "match.pricing"} {
+		g.Go(name, func(ctx context.Context) error {
+			if trace.SpanContextFromContext(ctx).SpanID() == parent.SpanContext().SpanID() {
+				t.Errorf("%s: goroutine context carries the parent span", name)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	parent.End()
+
+	if gctx.Err() == nil {
+		t.Error("group context should be canceled after Wait")
+	}
+	for _, name := range []string{"match.nearby", "match.pricing"} {
+		s := findSpan(spans.Ended(), name)
+		if s == nil {
+			t.Fatalf("span %s not found", name)
+		}
+		if s.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("%s parent = %s, want %s", name, s.Parent().SpanID(), parent.SpanContext().SpanID())
+		}
+	}
+}
+
+func TestGroup_Error(t *testing.T) {
+	t.Parallel()
+
+	tracer, spans := newRecordingTracer(t)
+	errNoDrivers := errors.New("no drivers")
+
+	g, ctx := tracer.NewGroup(context.Background())
+	g.Go("match.nearby", func(context.Context) error {
+		return errNoDrivers
+	})
+	g.Go("match.wait", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if err := g.Wait(); !errors.Is(err, errNoDrivers) {
+		t.Errorf("Wait() error = %v, want %v", err, errNoDrivers)
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, errNoDrivers) {
+		t.Errorf("context cause = %v, want %v", cause, errNoDrivers)
+	}
+
+	s := findSpan(spans.Ended(), "match.nearby")
+	if s == nil {
+		t.Fatal("span not found")
+	}
+	if s.Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error", s.Status().Code)
+	}
+	exceptionEvent(t, s)
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	t.Parallel()
+
+	tracer, _ := newRecordingTracer(t)
+	g, _ := tracer.NewGroup(context.Background())
+	g.SetLimit(2)
+
+	var running, peak atomic.Int32
+	for range 6 {
+		g.Go("work", func(context.Context) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if got := peak.Load(); got > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", got)
+	}
+}
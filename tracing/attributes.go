@@ -10,6 +10,9 @@ const (
 	AttrServiceName    = "service.name"
 	AttrServiceVersion = "service.version"
 
+	// Deployment attributes.
+	AttrDeploymentEnvironment = "deployment.environment"
+
 	// User attributes.
 	AttrUserID = "user.id"
 
@@ -27,6 +30,17 @@ const (
 	AttrHTTPUserAgent  = "http.user_agent"
 	AttrHTTPClientIP   = "http.client_ip"
 
+	// HTTP attributes from the stable semantic conventions.
+	// See SemConvMode for emitting them instead of, or alongside, the legacy keys.
+	AttrHTTPRequestMethod      = "http.request.method"
+	AttrHTTPResponseStatusCode = "http.response.status_code"
+	AttrURLFull                = "url.full"
+	AttrURLPath                = "url.path"
+	AttrURLScheme              = "url.scheme"
+	AttrServerAddress          = "server.address"
+	AttrUserAgentOriginal      = "user_agent.original"
+	AttrClientAddress          = "client.address"
+
 	// Database attributes.
 	AttrDBSystem    = "db.system"
 	AttrDBOperation = "db.operation"
@@ -40,6 +54,16 @@ const (
 	AttrMessagingMessageID   = "messaging.message_id"
 	AttrMessagingConsumer    = "messaging.consumer.group"
 
+	// Messaging attributes from the stable semantic conventions.
+	AttrMessagingDestinationName = "messaging.destination.name"
+	AttrMessagingMessageIDStable = "messaging.message.id"
+
+	// RPC attributes.
+	AttrRPCSystem         = "rpc.system"
+	AttrRPCService        = "rpc.service"
+	AttrRPCMethod         = "rpc.method"
+	AttrRPCGRPCStatusCode = "rpc.grpc.status_code"
+
 	// Error attributes.
 	AttrErrorType    = "error.type"
 	AttrErrorMessage = "error.message"
@@ -63,6 +87,11 @@ func ServiceVersion(version string) attribute.KeyValue {
 	return attribute.String(AttrServiceVersion, version)
 }
 
+// DeploymentEnvironment creates a deployment environment attribute.
+func DeploymentEnvironment(environment string) attribute.KeyValue {
+	return attribute.String(AttrDeploymentEnvironment, environment)
+}
+
 // UserID creates a user ID attribute.
 func UserID(id string) attribute.KeyValue {
 	return attribute.String(AttrUserID, id)
@@ -118,6 +147,46 @@ func HTTPClientIP(ip string) attribute.KeyValue {
 	return attribute.String(AttrHTTPClientIP, ip)
 }
 
+// HTTPRequestMethod creates a stable HTTP request method attribute.
+func HTTPRequestMethod(method string) attribute.KeyValue {
+	return attribute.String(AttrHTTPRequestMethod, method)
+}
+
+// HTTPResponseStatusCode creates a stable HTTP response status code attribute.
+func HTTPResponseStatusCode(code int) attribute.KeyValue {
+	return attribute.Int(AttrHTTPResponseStatusCode, code)
+}
+
+// URLFull creates a full URL attribute.
+func URLFull(url string) attribute.KeyValue {
+	return attribute.String(AttrURLFull, url)
+}
+
+// URLPath creates a URL path attribute.
+func URLPath(path string) attribute.KeyValue {
+	return attribute.String(AttrURLPath, path)
+}
+
+// URLScheme creates a URL scheme attribute.
+func URLScheme(scheme string) attribute.KeyValue {
+	return attribute.String(AttrURLScheme, scheme)
+}
+
+// ServerAddress creates a server address attribute.
+func ServerAddress(address string) attribute.KeyValue {
+	return attribute.String(AttrServerAddress, address)
+}
+
+// UserAgentOriginal creates a user agent attribute.
+func UserAgentOriginal(userAgent string) attribute.KeyValue {
+	return attribute.String(AttrUserAgentOriginal, userAgent)
+}
+
+// ClientAddress creates a client address attribute.
+func ClientAddress(address string) attribute.KeyValue {
+	return attribute.String(AttrClientAddress, address)
+}
+
 // DBSystem creates a database system attribute.
 func DBSystem(system string) attribute.KeyValue {
 	return attribute.String(AttrDBSystem, system)
@@ -148,6 +217,11 @@ func MessagingDestination(destination string) attribute.KeyValue {
 	return attribute.String(AttrMessagingDestination, destination)
 }
 
+// MessagingDestinationName creates a stable messaging destination name attribute.
+func MessagingDestinationName(destination string) attribute.KeyValue {
+	return attribute.String(AttrMessagingDestinationName, destination)
+}
+
 // MessagingOperation creates a messaging operation attribute.
 func MessagingOperation(operation string) attribute.KeyValue {
 	return attribute.String(AttrMessagingOperation, operation)
@@ -158,11 +232,36 @@ func MessagingMessageID(id string) attribute.KeyValue {
 	return attribute.String(AttrMessagingMessageID, id)
 }
 
+// MessagingMessageIDStable creates a stable messaging message ID attribute.
+func MessagingMessageIDStable(id string) attribute.KeyValue {
+	return attribute.String(AttrMessagingMessageIDStable, id)
+}
+
 // MessagingConsumer creates a messaging consumer group attribute.
 func MessagingConsumer(group string) attribute.KeyValue {
 	return attribute.String(AttrMessagingConsumer, group)
 }
 
+// RPCSystem creates an RPC system attribute (e.g., "grpc").
+func RPCSystem(system string) attribute.KeyValue {
+	return attribute.String(AttrRPCSystem, system)
+}
+
+// RPCService creates an RPC service attribute.
+func RPCService(service string) attribute.KeyValue {
+	return attribute.String(AttrRPCService, service)
+}
+
+// RPCMethod creates an RPC method attribute.
+func RPCMethod(method string) attribute.KeyValue {
+	return attribute.String(AttrRPCMethod, method)
+}
+
+// RPCGRPCStatusCode creates a gRPC status code attribute.
+func RPCGRPCStatusCode(code int) attribute.KeyValue {
+	return attribute.Int(AttrRPCGRPCStatusCode, code)
+}
+
 // ErrorType creates an error type attribute.
 func ErrorType(errType string) attribute.KeyValue {
 	return attribute.String(AttrErrorType, errType)
@@ -32,6 +32,14 @@ func TestAttributeConstants(t *testing.T) {
 		{"AttrHTTPHost", AttrHTTPHost, "http.host"},
 		{"AttrHTTPUserAgent", AttrHTTPUserAgent, "http.user_agent"},
 		{"AttrHTTPClientIP", AttrHTTPClientIP, "http.client_ip"},
+		{"AttrHTTPRequestMethod", AttrHTTPRequestMethod, "http.request.method"},
+		{"AttrHTTPResponseStatusCode", AttrHTTPResponseStatusCode, "http.response.status_code"},
+		{"AttrURLFull", AttrURLFull, "url.full"},
+		{"AttrURLPath", AttrURLPath, "url.path"},
+		{"AttrURLScheme", AttrURLScheme, "url.scheme"},
+		{"AttrServerAddress", AttrServerAddress, "server.address"},
+		{"AttrUserAgentOriginal", AttrUserAgentOriginal, "user_agent.original"},
+		{"AttrClientAddress", AttrClientAddress, "client.address"},
 
 		// Database attributes
 		{"AttrDBSystem", AttrDBSystem, "db.system"},
@@ -45,6 +53,14 @@ func TestAttributeConstants(t *testing.T) {
 		{"AttrMessagingOperation", AttrMessagingOperation, "messaging.operation"},
 		{"AttrMessagingMessageID", AttrMessagingMessageID, "messaging.message_id"},
 		{"AttrMessagingConsumer", AttrMessagingConsumer, "messaging.consumer.group"},
+		{"AttrMessagingDestinationName", AttrMessagingDestinationName, "messaging.destination.name"},
+		{"AttrMessagingMessageIDStable", AttrMessagingMessageIDStable, "messaging.message.id"},
+
+		// RPC attributes
+		{"AttrRPCSystem", AttrRPCSystem, "rpc.system"},
+		{"AttrRPCService", AttrRPCService, "rpc.service"},
+		{"AttrRPCMethod", AttrRPCMethod, "rpc.method"},
+		{"AttrRPCGRPCStatusCode", AttrRPCGRPCStatusCode, "rpc.grpc.status_code"},
 
 		// Error attributes
 		{"AttrErrorType", AttrErrorType, "error.type"},
@@ -225,6 +241,18 @@ func TestMessagingDestination(t *testing.T) {
 	}
 }
 
+func TestRPCGRPCStatusCode(t *testing.T) {
+	t.Parallel()
+
+	attr := RPCGRPCStatusCode(5)
+	if string(attr.Key) != AttrRPCGRPCStatusCode {
+		t.Errorf("Key = %v, want %v", attr.Key, AttrRPCGRPCStatusCode)
+	}
+	if attr.Value.AsInt64() != 5 {
+		t.Errorf("Value = %v, want 5", attr.Value.AsInt64())
+	}
+}
+
 func TestErrorType(t *testing.T) {
 	t.Parallel()
 
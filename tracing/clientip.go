@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Client IP headers consulted when the request comes from a trusted proxy.
+const (
+	HeaderForwarded     = "Forwarded"
+	HeaderXForwardedFor = "X-Forwarded-For"
+	HeaderXRealIP       = "X-Real-IP"
+)
+
+// DefaultTrustedProxies are the proxy ranges trusted when Config.TrustedProxies
+// is nil: loopback only, for sidecar proxies.
+var DefaultTrustedProxies = []string{
+	"127.0.0.0/8",
+	"::1/128",
+}
+
+// PrivateTrustedProxies are the private network ranges used by in-cluster
+// load balancers and ingress controllers. They are not trusted by default, as
+// any host in a shared network could then forge client IPs; opt in with
+//
+//	cfg.WithTrustedProxies(append(tracing.DefaultTrustedProxies, tracing.PrivateTrustedProxies...)...)
+var PrivateTrustedProxies = []string{
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+}
+
+// ClientIPResolver determines the originating client IP of a request.
+// Forwarding headers are only honoured when the immediate peer is a trusted
+// proxy, and are walked right to left, skipping trusted proxies, so a client
+// cannot spoof its address by prepending entries.
+type ClientIPResolver struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIPResolver creates a resolver trusting the given proxies, given as
+// CIDRs (e.g., "10.0.0.0/8") or single IP addresses. With no proxies, forwarding
+// headers are ignored and the peer address is always used.
+func NewClientIPResolver(trustedProxies []string) (*ClientIPResolver, error) {
+	trusted := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, p := range trustedProxies {
+		prefix, err := parseTrustedProxy(p)
+		if err != nil {
+			return nil, err
+		}
+		trusted = append(trusted, prefix)
+	}
+	return &ClientIPResolver{trusted: trusted}, nil
+}
+
+// parseTrustedProxy parses a CIDR or a single IP address as a prefix.
+func parseTrustedProxy(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid trusted proxy %q: %w", s, err)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid trusted proxy %q: %w", s, err)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// ClientIP returns the client IP of the request without port. Forwarded
+// (RFC 7239) takes precedence over X-Forwarded-For; X-Real-IP is only used
+// when neither is present. If the peer is not trusted or no header yields an
+// address, the peer address is returned. Returns an empty string if no
+// address can be determined.
+func (r *ClientIPResolver) ClientIP(req *http.Request) string {
+	remote, ok := parseIP(req.RemoteAddr)
+	if !ok {
+		return ""
+	}
+	if !r.isTrusted(remote) {
+		return remote.String()
+	}
+
+	if chain := forwardedFor(req.Header.Values(HeaderForwarded)); len(chain) > 0 {
+		return r.walk(chain, remote).String()
+	}
+	if chain := splitList(req.Header.Values(HeaderXForwardedFor)); len(chain) > 0 {
+		return r.walk(chain, remote).String()
+	}
+	if ip, ok := parseIP(req.Header.Get(HeaderXRealIP)); ok {
+		return ip.String()
+	}
+	return remote.String()
+}
+
+// walk returns the rightmost untrusted address in a forwarding chain reached
+// through the trusted peer, or the leftmost address if every hop is trusted.
+// The walk stops at the first entry that is not an IP address, such as
+// "unknown" or a malformed value, and returns the last trusted hop: entries
+// left of it were not written by a trusted proxy.
+func (r *ClientIPResolver) walk(chain []string, peer netip.Addr) netip.Addr {
+	last := peer
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip, ok := parseIP(chain[i])
+		if !ok {
+			return last
+		}
+		if !r.isTrusted(ip) {
+			return ip
+		}
+		last = ip
+	}
+	return last
+}
+
+// isTrusted reports whether ip belongs to a trusted proxy range.
+func (r *ClientIPResolver) isTrusted(ip netip.Addr) bool {
+	for _, p := range r.trusted {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits comma-separated header values into trimmed entries.
+func splitList(values []string) []string {
+	var entries []string
+	for _, v := range values {
+		for _, e := range strings.Split(v, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				entries = append(entries, e)
+			}
+		}
+	}
+	return entries
+}
+
+// forwardedFor returns the "for" parameter of each Forwarded element, in order.
+func forwardedFor(values []string) []string {
+	var entries []string
+	for _, element := range splitList(values) {
+		for _, pair := range strings.Split(element, ";") {
+			name, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+			if found && strings.EqualFold(name, "for") {
+				entries = append(entries, strings.Trim(value, `"`))
+			}
+		}
+	}
+	return entries
+}
+
+// parseIP parses an address that may carry a port or IPv6 brackets,
+// e.g. "192.0.2.1:443", "[2001:db8::1]:443" or "2001:db8::1".
+func parseIP(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
@@ -0,0 +1,221 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClientIPResolver_ClientIP(t *testing.T) {
+	t.Parallel()
+
+	resolver, err := NewClientIPResolver(append(DefaultTrustedProxies, PrivateTrustedProxies...))
+	if err != nil {
+		t.Fatalf("NewClientIPResolver() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		headers    map[string][]string
+		remoteAddr string
+		expected   string
+	}{
+		{
+			name:       "X-Forwarded-For from trusted proxy",
+			headers:    map[string][]string{HeaderXForwardedFor: {"203.0.113.7"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "X-Forwarded-For walked right to left",
+			headers:    map[string][]string{HeaderXForwardedFor: {"198.51.100.1, 203.0.113.7, 10.0.0.5"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "X-Forwarded-For across header lines",
+			headers:    map[string][]string{HeaderXForwardedFor: {"198.51.100.1", "203.0.113.7:8080"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "all hops trusted uses leftmost",
+			headers:    map[string][]string{HeaderXForwardedFor: {"192.168.1.1, 10.0.0.5"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "192.168.1.1",
+		},
+		{
+			name:       "headers ignored from untrusted peer",
+			headers:    map[string][]string{HeaderXForwardedFor: {"198.51.100.1"}, HeaderXRealIP: {"198.51.100.2"}},
+			remoteAddr: "203.0.113.9:1234",
+			expected:   "203.0.113.9",
+		},
+		{
+			name:       "Forwarded takes precedence",
+			headers:    map[string][]string{HeaderForwarded: {`for=198.51.100.1;proto=https, for="[2001:db8::1]:4711"`}, HeaderXForwardedFor: {"203.0.113.7"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "2001:db8::1",
+		},
+		{
+			name:       "unknown Forwarded entry stops at the trusted peer",
+			headers:    map[string][]string{HeaderForwarded: {"for=unknown"}, HeaderXForwardedFor: {"203.0.113.7"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "10.0.0.1",
+		},
+		{
+			name:       "malformed X-Forwarded-For does not fall back to X-Real-IP",
+			headers:    map[string][]string{HeaderXForwardedFor: {"not-an-ip"}, HeaderXRealIP: {"198.51.100.66"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "10.0.0.1",
+		},
+		{
+			name:       "malformed hop returns the last trusted hop",
+			headers:    map[string][]string{HeaderXForwardedFor: {"203.0.113.7, bogus, 10.0.0.5"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "10.0.0.5",
+		},
+		{
+			name:       "X-Real-IP",
+			headers:    map[string][]string{HeaderXRealIP: {"192.168.1.2"}},
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "192.168.1.2",
+		},
+		{
+			name:       "RemoteAddr fallback without port",
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "10.0.0.1",
+		},
+		{
+			name:       "IPv6 RemoteAddr",
+			remoteAddr: "[2001:db8::2]:443",
+			expected:   "2001:db8::2",
+		},
+		{
+			name:       "invalid RemoteAddr",
+			remoteAddr: "pipe",
+			expected:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for k, values := range tt.headers {
+				for _, v := range values {
+					req.Header.Add(k, v)
+				}
+			}
+
+			if got := resolver.ClientIP(req); got != tt.expected {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClientIPResolver_DefaultTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	resolver, err := NewClientIPResolver(DefaultTrustedProxies)
+	if err != nil {
+		t.Fatalf("NewClientIPResolver() error = %v", err)
+	}
+
+	tests := []struct {
+		remoteAddr string
+		expected   string
+	}{
+		{"127.0.0.1:1234", "203.0.113.7"},
+		{"[::1]:1234", "203.0.113.7"},
+		{"10.0.0.1:1234", "10.0.0.1"},
+		{"192.168.1.1:1234", "192.168.1.1"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = tt.remoteAddr
+		req.Header.Set(HeaderXForwardedFor, "203.0.113.7")
+
+		if got := resolver.ClientIP(req); got != tt.expected {
+			t.Errorf("ClientIP() from %s = %q, want %q", tt.remoteAddr, got, tt.expected)
+		}
+	}
+}
+
+func TestClientIPResolver_NoTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	resolver, err := NewClientIPResolver(nil)
+	if err != nil {
+		t.Fatalf("NewClientIPResolver() error = %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "127.0.0.1:1234"
+	req.Header.Set(HeaderXForwardedFor, "203.0.113.7")
+
+	if got := resolver.ClientIP(req); got != "127.0.0.1" {
+		t.Errorf("ClientIP() = %q, want 127.0.0.1", got)
+	}
+}
+
+func TestNewClientIPResolver_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, proxy := range []string{"10.0.0.0/33", "not-an-ip", ""} {
+		if _, err := NewClientIPResolver([]string{proxy}); err == nil {
+			t.Errorf("NewClientIPResolver(%q) should fail", proxy)
+		}
+	}
+
+	if _, err := NewClientIPResolver([]string{"203.0.113.10", "2001:db8::/32"}); err != nil {
+		t.Errorf("NewClientIPResolver() error = %v", err)
+	}
+}
+
+func TestConfig_Validate_TrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.WithServiceName("test-service").WithTrustedProxies("10.0.0.0/8", "bogus")
+
+	if err := cfg.Validate(); err == nil || !contains(err.Error(), "invalid trusted proxy") {
+		t.Errorf("Validate() error = %v, want invalid trusted proxy", err)
+	}
+}
+
+func TestMiddleware_ClientIPAttribute(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := testConfig("test-service")
+	cfg.SampleRate = 1.0
+	cfg.WithTrustedProxies("192.0.2.0/24").WithSemConvMode(SemConvNew)
+
+	tracer, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer.Provider().RegisterSpanProcessor(recorder)
+
+	req := httptest.NewRequest("GET", "/api/v1/rides", nil) // RemoteAddr 192.0.2.1:1234
+	req.Header.Set(HeaderXForwardedFor, "198.51.100.1, 203.0.113.7")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	Middleware(tracer)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	attrs := attribute.NewSet(recorder.Ended()[0].Attributes()...)
+	if v, _ := attrs.Value(AttrClientAddress); v.AsString() != "203.0.113.7" {
+		t.Errorf("client IP attribute = %q, want 203.0.113.7", v.AsString())
+	}
+}
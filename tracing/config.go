@@ -1,7 +1,15 @@
 package tracing
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
 )
 
 // PropagationType defines the trace context propagation format.
@@ -10,10 +18,24 @@ type PropagationType string
 const (
 	// PropagationW3C uses W3C trace context format (traceparent, tracestate).
 	PropagationW3C PropagationType = "w3c"
-	// PropagationB3 uses B3 propagation format.
+	// PropagationB3 uses B3 propagation format, injecting multiple X-B3-* headers.
+	// Kept for compatibility; equivalent to PropagationB3Multi.
 	PropagationB3 PropagationType = "b3"
+	// PropagationB3Single uses the B3 single "b3" header.
+	PropagationB3Single PropagationType = "b3single"
+	// PropagationB3Multi uses the B3 multiple X-B3-* headers.
+	PropagationB3Multi PropagationType = "b3multi"
+	// PropagationJaeger uses the Jaeger uber-trace-id header.
+	PropagationJaeger PropagationType = "jaeger"
+	// PropagationBaggage propagates W3C baggage.
+	PropagationBaggage PropagationType = "baggage"
+	// PropagationNone disables context propagation. It cannot be combined with other types.
+	PropagationNone PropagationType = "none"
 )
 
+// DefaultPropagation is the propagation used when none is configured.
+var DefaultPropagation = []PropagationType{PropagationW3C, PropagationBaggage}
+
 // ExporterType defines the trace exporter type.
 type ExporterType string
 
@@ -26,6 +48,187 @@ const (
 	ExporterNone ExporterType = "none"
 )
 
+// CompressionType defines the compression applied to exported payloads.
+type CompressionType string
+
+const (
+	// CompressionNone sends export payloads uncompressed.
+	CompressionNone CompressionType = "none"
+	// CompressionGzip compresses export payloads with gzip.
+	CompressionGzip CompressionType = "gzip"
+)
+
+// TLSConfig holds TLS settings for the exporter connection.
+// When all fields are empty, the system root CAs are used.
+type TLSConfig struct {
+	// CAFile is the path to a PEM-encoded CA bundle used to verify the collector.
+	CAFile string
+
+	// CertFile is the path to a PEM-encoded client certificate for mTLS.
+	CertFile string
+
+	// KeyFile is the path to the PEM-encoded private key for CertFile.
+	KeyFile string
+
+	// ServerName overrides the server name used to verify the collector certificate.
+	ServerName string
+}
+
+// IsSet returns true if any TLS setting has been configured.
+func (t TLSConfig) IsSet() bool {
+	return t.CAFile != "" || t.CertFile != "" || t.KeyFile != "" || t.ServerName != ""
+}
+
+// HasClientCert returns true if a client certificate or key is configured.
+func (t TLSConfig) HasClientCert() bool {
+	return t.CertFile != "" || t.KeyFile != ""
+}
+
+// ClientConfig builds a *tls.Config from the configured files.
+func (t TLSConfig) ClientConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: t.ServerName,
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in CA file %s", t.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if t.HasClientCert() {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+// RetryConfig configures retries for failed exports.
+// Zero intervals use the SDK defaults (5s initial, 30s max, 1m elapsed).
+type RetryConfig struct {
+	// Disabled turns off retries entirely.
+	Disabled bool
+
+	// InitialInterval is the wait time before the first retry.
+	InitialInterval time.Duration
+
+	// MaxInterval is the upper bound on the backoff interval.
+	MaxInterval time.Duration
+
+	// MaxElapsedTime is the maximum time spent retrying a single export.
+	MaxElapsedTime time.Duration
+}
+
+// IsSet returns true if the retry policy differs from the SDK defaults.
+func (r RetryConfig) IsSet() bool {
+	return r.Disabled || r.InitialInterval != 0 || r.MaxInterval != 0 || r.MaxElapsedTime != 0
+}
+
+// Default retry settings, matching the OTLP exporter defaults.
+const (
+	defaultRetryInitialInterval = 5 * time.Second
+	defaultRetryMaxInterval     = 30 * time.Second
+	defaultRetryMaxElapsedTime  = time.Minute
+)
+
+// WithDefaults returns a copy with zero intervals replaced by the SDK defaults.
+func (r RetryConfig) WithDefaults() RetryConfig {
+	if r.InitialInterval == 0 {
+		r.InitialInterval = defaultRetryInitialInterval
+	}
+	if r.MaxInterval == 0 {
+		r.MaxInterval = defaultRetryMaxInterval
+	}
+	if r.MaxElapsedTime == 0 {
+		r.MaxElapsedTime = defaultRetryMaxElapsedTime
+	}
+	return r
+}
+
+// validate checks that the retry intervals are consistent.
+func (r RetryConfig) validate() error {
+	if r.InitialInterval < 0 || r.MaxInterval < 0 || r.MaxElapsedTime < 0 {
+		return fmt.Errorf("retry intervals must not be negative")
+	}
+	if r.InitialInterval > 0 && r.MaxInterval > 0 && r.InitialInterval > r.MaxInterval {
+		return fmt.Errorf("retry initial interval %s exceeds max interval %s", r.InitialInterval, r.MaxInterval)
+	}
+	return nil
+}
+
+// BatchConfig tunes the batch span processor.
+// Zero values use the SDK defaults.
+type BatchConfig struct {
+	// MaxQueueSize is the maximum number of spans buffered before new spans are dropped.
+	// SDK default: 2048.
+	MaxQueueSize int
+
+	// MaxExportBatchSize is the maximum number of spans sent in a single export.
+	// Must not exceed MaxQueueSize. SDK default: 512.
+	MaxExportBatchSize int
+
+	// ScheduleDelay is the maximum delay between two consecutive exports.
+	// SDK default: 5s.
+	ScheduleDelay time.Duration
+
+	// ExportTimeout is the maximum time the processor waits for an export to finish.
+	// SDK default: 30s.
+	ExportTimeout time.Duration
+}
+
+// validate checks that the batch settings are consistent.
+func (b BatchConfig) validate() error {
+	if b.MaxQueueSize < 0 || b.MaxExportBatchSize < 0 {
+		return fmt.Errorf("batch queue and export batch sizes must not be negative")
+	}
+	if b.ScheduleDelay < 0 || b.ExportTimeout < 0 {
+		return fmt.Errorf("batch schedule delay and export timeout must not be negative")
+	}
+	if b.MaxQueueSize > 0 && b.MaxExportBatchSize > b.MaxQueueSize {
+		return fmt.Errorf("max export batch size %d exceeds max queue size %d", b.MaxExportBatchSize, b.MaxQueueSize)
+	}
+	return nil
+}
+
+// SpanLimitsConfig bounds the data recorded on each span.
+// Zero values use the SDK defaults.
+type SpanLimitsConfig struct {
+	// AttributeCountLimit is the maximum number of attributes per span.
+	// SDK default: 128.
+	AttributeCountLimit int
+
+	// AttributeValueLengthLimit is the maximum length of string attribute values.
+	// SDK default: unlimited.
+	AttributeValueLengthLimit int
+
+	// EventCountLimit is the maximum number of events per span.
+	// SDK default: 128.
+	EventCountLimit int
+
+	// LinkCountLimit is the maximum number of links per span.
+	// SDK default: 128.
+	LinkCountLimit int
+}
+
+// validate checks that no span limit is negative.
+func (l SpanLimitsConfig) validate() error {
+	if l.AttributeCountLimit < 0 || l.AttributeValueLengthLimit < 0 || l.EventCountLimit < 0 || l.LinkCountLimit < 0 {
+		return fmt.Errorf("span limits must not be negative")
+	}
+	return nil
+}
+
 // Config holds configuration for the tracing setup.
 type Config struct {
 	// ServiceName is the name of the service being traced.
@@ -41,8 +244,24 @@ type Config struct {
 	// 0.0 means no traces, 1.0 means all traces.
 	SampleRate float64
 
-	// Propagation defines the trace context propagation format.
-	Propagation PropagationType
+	// ParentBased makes sampling follow the parent span's decision when one
+	// exists, applying SampleRate only to root spans.
+	ParentBased bool
+
+	// Propagation lists the trace context propagation formats. Incoming context
+	// is extracted from any of them, with earlier formats taking precedence when
+	// several are present. Empty uses DefaultPropagation. The list is used as
+	// is; WithPropagation adds PropagationBaggage automatically.
+	Propagation []PropagationType
+
+	// InjectPropagation is the subset of Propagation written to outgoing
+	// requests and messages. Empty injects every format in Propagation.
+	InjectPropagation []PropagationType
+
+	// DisableGlobalPropagator keeps the propagator local to the Tracer instead
+	// of installing it with otel.SetTextMapPropagator. Middleware and
+	// RoundTripper always use the Tracer's propagator.
+	DisableGlobalPropagator bool
 
 	// Exporter defines the trace exporter type.
 	Exporter ExporterType
@@ -52,6 +271,82 @@ type Config struct {
 
 	// Headers are additional headers to send with exports.
 	Headers map[string]string
+
+	// TLS holds CA and client certificate settings for the exporter connection.
+	// Must be empty when Insecure is true.
+	TLS TLSConfig
+
+	// Compression is the compression applied to exported payloads.
+	// Empty means no compression.
+	Compression CompressionType
+
+	// Timeout is the maximum time a single export may take.
+	// Zero uses the SDK default of 10s.
+	Timeout time.Duration
+
+	// Retry configures the retry policy for failed exports.
+	Retry RetryConfig
+
+	// URLPath overrides the URL path for the OTLP HTTP exporter.
+	// Empty uses the default "/v1/traces". Not valid for the gRPC exporter.
+	URLPath string
+
+	// Batch tunes the batch span processor.
+	Batch BatchConfig
+
+	// SpanLimits bounds the attributes, events and links recorded per span.
+	SpanLimits SpanLimitsConfig
+
+	// Environment is the deployment environment (e.g., "production", "staging"),
+	// recorded as the deployment.environment resource attribute.
+	Environment string
+
+	// ServiceInstanceID uniquely identifies this service instance.
+	// If empty, a random UUID is generated at startup.
+	ServiceInstanceID string
+
+	// ResourceAttributes are additional attributes added to the resource.
+	ResourceAttributes map[string]string
+
+	// DisableResourceDetection skips detection of host, process runtime,
+	// container and Kubernetes resource attributes.
+	DisableResourceDetection bool
+
+	// Logger is used to report the effective tracing settings on startup.
+	// If nil, slog.Default() is used.
+	Logger *slog.Logger
+
+	// RequestIDGenerator creates request IDs in Middleware for requests that
+	// arrive without a valid X-Request-ID. If nil, NewRequestID (UUIDv7) is used.
+	// Use TraceRequestID to derive request IDs from the trace ID.
+	RequestIDGenerator RequestIDGenerator
+
+	// CorrelationHeaders lists request headers captured into baggage by
+	// Middleware and re-emitted by RoundTripper and InjectToKafka.
+	CorrelationHeaders []CorrelationHeader
+
+	// BaggageSpanAttributes lists baggage keys copied onto every span started
+	// in a context carrying them (e.g., AttrCity, AttrServiceType).
+	BaggageSpanAttributes []string
+
+	// Redaction holds the PII redaction rules applied to spans before export.
+	Redaction RedactionConfig
+
+	// Errors configures how RecordError classifies and records errors.
+	Errors ErrorConfig
+
+	// Metrics receives tracing self-metrics such as redaction and error counts.
+	// If nil, self-metrics are not recorded.
+	Metrics MetricsRecorder
+
+	// TrustedProxies lists proxy CIDRs or IPs whose forwarding headers are used
+	// to resolve the client IP. Nil uses DefaultTrustedProxies; an empty
+	// non-nil slice trusts no proxy.
+	TrustedProxies []string
+
+	// SemConvMode selects legacy, stable or both semantic convention keys for
+	// HTTP attributes recorded by the middlewares. Empty uses DefaultSemConvMode.
+	SemConvMode SemConvMode
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -61,7 +356,7 @@ func DefaultConfig() Config {
 		ServiceVersion: "unknown",
 		Endpoint:       "localhost:4318",
 		SampleRate:     1.0,
-		Propagation:    PropagationW3C,
+		Propagation:    append([]PropagationType(nil), DefaultPropagation...),
 		Exporter:       ExporterOTLPHTTP,
 		Insecure:       true,
 		Headers:        make(map[string]string),
@@ -92,12 +387,39 @@ func (c *Config) WithSampleRate(rate float64) *Config {
 	return c
 }
 
-// WithPropagation sets the trace context propagation format.
-func (c *Config) WithPropagation(propagation PropagationType) *Config {
-	c.Propagation = propagation
+// WithPropagation sets the trace context propagation formats. Baggage is
+// always propagated alongside them, as correlation headers travel in it,
+// unless PropagationNone is given. Assign Propagation directly to use
+// exactly the listed formats.
+func (c *Config) WithPropagation(propagation ...PropagationType) *Config {
+	c.Propagation = withBaggage(propagation)
 	return c
 }
 
+// WithInjectPropagation sets the subset of formats injected into outgoing
+// carriers. Baggage is injected too when it is a configured format; assign
+// InjectPropagation directly to inject exactly the listed formats.
+func (c *Config) WithInjectPropagation(propagation ...PropagationType) *Config {
+	configured := c.Propagation
+	if len(configured) == 0 {
+		configured = DefaultPropagation
+	}
+	if slices.Contains(configured, PropagationBaggage) {
+		propagation = withBaggage(propagation)
+	}
+	c.InjectPropagation = propagation
+	return c
+}
+
+// withBaggage appends PropagationBaggage to types unless it is already
+// present, types is empty or it disables propagation.
+func withBaggage(types []PropagationType) []PropagationType {
+	if len(types) == 0 || slices.Contains(types, PropagationBaggage) || slices.Contains(types, PropagationNone) {
+		return types
+	}
+	return append(slices.Clone(types), PropagationBaggage)
+}
+
 // WithExporter sets the trace exporter type.
 func (c *Config) WithExporter(exporter ExporterType) *Config {
 	c.Exporter = exporter
@@ -116,6 +438,123 @@ func (c *Config) WithHeaders(headers map[string]string) *Config {
 	return c
 }
 
+// WithTLS sets the TLS settings for the exporter connection.
+func (c *Config) WithTLS(tlsCfg TLSConfig) *Config {
+	c.TLS = tlsCfg
+	return c
+}
+
+// WithCompression sets the export payload compression.
+func (c *Config) WithCompression(compression CompressionType) *Config {
+	c.Compression = compression
+	return c
+}
+
+// WithTimeout sets the export timeout.
+func (c *Config) WithTimeout(timeout time.Duration) *Config {
+	c.Timeout = timeout
+	return c
+}
+
+// WithRetry sets the export retry policy.
+func (c *Config) WithRetry(retry RetryConfig) *Config {
+	c.Retry = retry
+	return c
+}
+
+// WithURLPath sets the URL path for the OTLP HTTP exporter.
+func (c *Config) WithURLPath(path string) *Config {
+	c.URLPath = path
+	return c
+}
+
+// WithBatch sets the batch span processor settings.
+func (c *Config) WithBatch(batch BatchConfig) *Config {
+	c.Batch = batch
+	return c
+}
+
+// WithSpanLimits sets the per-span limits.
+func (c *Config) WithSpanLimits(limits SpanLimitsConfig) *Config {
+	c.SpanLimits = limits
+	return c
+}
+
+// WithEnvironment sets the deployment environment.
+func (c *Config) WithEnvironment(environment string) *Config {
+	c.Environment = environment
+	return c
+}
+
+// WithServiceInstanceID sets the service instance ID.
+func (c *Config) WithServiceInstanceID(id string) *Config {
+	c.ServiceInstanceID = id
+	return c
+}
+
+// WithResourceAttributes sets additional resource attributes.
+func (c *Config) WithResourceAttributes(attrs map[string]string) *Config {
+	c.ResourceAttributes = attrs
+	return c
+}
+
+// WithLogger sets the logger used to report tracing settings.
+func (c *Config) WithLogger(logger *slog.Logger) *Config {
+	c.Logger = logger
+	return c
+}
+
+// WithRequestIDGenerator sets the generator for missing request IDs.
+func (c *Config) WithRequestIDGenerator(generate RequestIDGenerator) *Config {
+	c.RequestIDGenerator = generate
+	return c
+}
+
+// WithCorrelationHeaders sets the headers propagated across services in baggage.
+func (c *Config) WithCorrelationHeaders(headers ...CorrelationHeader) *Config {
+	c.CorrelationHeaders = headers
+	return c
+}
+
+// WithBaggageSpanAttributes sets the baggage keys recorded on every span.
+func (c *Config) WithBaggageSpanAttributes(keys ...string) *Config {
+	c.BaggageSpanAttributes = keys
+	return c
+}
+
+// WithRedaction sets the PII redaction rules.
+func (c *Config) WithRedaction(redaction RedactionConfig) *Config { //nolint:gocritic // redaction passed by value for API simplicity
+	c.Redaction = redaction
+	return c
+}
+
+// WithErrors sets the error recording configuration.
+func (c *Config) WithErrors(errors ErrorConfig) *Config { //nolint:gocritic // errors passed by value for API simplicity
+	c.Errors = errors
+	return c
+}
+
+// WithMetrics sets the recorder for tracing self-metrics.
+func (c *Config) WithMetrics(recorder MetricsRecorder) *Config {
+	c.Metrics = recorder
+	return c
+}
+
+// WithSemConvMode sets the semantic convention mode for HTTP attributes.
+func (c *Config) WithSemConvMode(mode SemConvMode) *Config {
+	c.SemConvMode = mode
+	return c
+}
+
+// WithTrustedProxies sets the proxies trusted for client IP resolution.
+func (c *Config) WithTrustedProxies(proxies ...string) *Config {
+	if proxies == nil {
+		proxies = []string{}
+	}
+	c.TrustedProxies = proxies
+	return c
+}
+
 // Validate validates the configuration.
 func (c *Config) Validate() error {
 	if c.ServiceName == "" {
@@ -126,13 +565,16 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("sample rate must be between 0.0 and 1.0, got %f", c.SampleRate)
 	}
 
-	switch c.Propagation {
-	case PropagationW3C, PropagationB3:
-		// Valid
-	case "":
-		// Default to W3C
-	default:
-		return fmt.Errorf("invalid propagation type: %s", c.Propagation)
+	if err := c.validatePropagation(); err != nil {
+		return err
+	}
+
+	if err := c.validateCorrelationHeaders(); err != nil {
+		return err
+	}
+
+	if err := c.validateContext(); err != nil {
+		return err
 	}
 
 	switch c.Exporter {
@@ -144,5 +586,126 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid exporter type: %s", c.Exporter)
 	}
 
+	if err := c.Batch.validate(); err != nil {
+		return err
+	}
+
+	if err := c.SpanLimits.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Redaction.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Errors.validate(); err != nil {
+		return err
+	}
+
+	if err := c.SemConvMode.validate(); err != nil {
+		return err
+	}
+
+	return c.validateExport()
+}
+
+// validatePropagation checks the propagation formats and that the inject
+// subset only contains configured formats.
+func (c *Config) validatePropagation() error {
+	propagation := c.Propagation
+	if len(propagation) == 0 {
+		propagation = DefaultPropagation
+	}
+
+	configured := make(map[PropagationType]bool, len(propagation))
+	for _, p := range propagation {
+		switch p {
+		case PropagationW3C, PropagationB3, PropagationB3Single, PropagationB3Multi,
+			PropagationJaeger, PropagationBaggage:
+			// Valid
+		case PropagationNone:
+			if len(propagation) > 1 {
+				return fmt.Errorf("propagation type %s cannot be combined with other types", PropagationNone)
+			}
+		default:
+			return fmt.Errorf("invalid propagation type: %s", p)
+		}
+		configured[p] = true
+	}
+
+	for _, p := range c.InjectPropagation {
+		if !configured[p] {
+			return fmt.Errorf("inject propagation type %s is not in the configured propagation", p)
+		}
+	}
+
+	return nil
+}
+
+// validateContext checks the baggage span attributes and trusted proxies.
+func (c *Config) validateContext() error {
+	for _, key := range c.BaggageSpanAttributes {
+		if key == "" {
+			return fmt.Errorf("baggage span attribute key must not be empty")
+		}
+	}
+	for _, proxy := range c.TrustedProxies {
+		if _, err := parseTrustedProxy(proxy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCorrelationHeaders checks each correlation header and rejects duplicates.
+func (c *Config) validateCorrelationHeaders() error {
+	seen := make(map[string]bool, len(c.CorrelationHeaders))
+	for _, h := range c.CorrelationHeaders {
+		if err := h.validate(); err != nil {
+			return err
+		}
+		name := http.CanonicalHeaderKey(h.Name)
+		if seen[name] {
+			return fmt.Errorf("duplicate correlation header: %s", h.Name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// validateExport validates the exporter connection settings.
+func (c *Config) validateExport() error {
+	if c.Insecure && c.TLS.IsSet() {
+		return fmt.Errorf("TLS settings cannot be combined with insecure connection")
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return fmt.Errorf("client certificate and key must be set together")
+	}
+
+	switch c.Compression {
+	case CompressionNone, CompressionGzip, "":
+		// Valid
+	default:
+		return fmt.Errorf("invalid compression type: %s", c.Compression)
+	}
+
+	if c.Timeout < 0 {
+		return fmt.Errorf("export timeout must not be negative, got %s", c.Timeout)
+	}
+
+	if err := c.Retry.validate(); err != nil {
+		return err
+	}
+
+	if c.URLPath != "" {
+		if c.Exporter == ExporterOTLPGRPC {
+			return fmt.Errorf("URL path is only supported by the %s exporter", ExporterOTLPHTTP)
+		}
+		if !strings.HasPrefix(c.URLPath, "/") {
+			return fmt.Errorf("URL path must start with /, got %s", c.URLPath)
+		}
+	}
+
 	return nil
 }
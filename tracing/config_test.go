@@ -1,7 +1,18 @@
 package tracing
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -21,8 +32,8 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.SampleRate != 1.0 {
 		t.Errorf("SampleRate = %v, want 1.0", cfg.SampleRate)
 	}
-	if cfg.Propagation != PropagationW3C {
-		t.Errorf("Propagation = %v, want %v", cfg.Propagation, PropagationW3C)
+	if !slices.Equal(cfg.Propagation, DefaultPropagation) {
+		t.Errorf("Propagation = %v, want %v", cfg.Propagation, DefaultPropagation)
 	}
 	if cfg.Exporter != ExporterOTLPHTTP {
 		t.Errorf("Exporter = %v, want %v", cfg.Exporter, ExporterOTLPHTTP)
@@ -83,10 +94,34 @@ func TestConfig_WithPropagation(t *testing.T) {
 	t.Parallel()
 
 	cfg := DefaultConfig()
-	cfg.WithPropagation(PropagationB3)
+	cfg.WithPropagation(PropagationB3Single, PropagationW3C).WithInjectPropagation(PropagationB3Single)
 
-	if cfg.Propagation != PropagationB3 {
-		t.Errorf("Propagation = %v, want %v", cfg.Propagation, PropagationB3)
+	if !slices.Equal(cfg.Propagation, []PropagationType{PropagationB3Single, PropagationW3C, PropagationBaggage}) {
+		t.Errorf("Propagation = %v, want [b3single w3c baggage]", cfg.Propagation)
+	}
+	if !slices.Equal(cfg.InjectPropagation, []PropagationType{PropagationB3Single, PropagationBaggage}) {
+		t.Errorf("InjectPropagation = %v, want [b3single baggage]", cfg.InjectPropagation)
+	}
+}
+
+func TestConfig_WithPropagation_Baggage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		types []PropagationType
+		want  []PropagationType
+	}{
+		{"adds baggage", []PropagationType{PropagationB3}, []PropagationType{PropagationB3, PropagationBaggage}},
+		{"keeps explicit baggage", []PropagationType{PropagationBaggage, PropagationW3C}, []PropagationType{PropagationBaggage, PropagationW3C}},
+		{"none", []PropagationType{PropagationNone}, []PropagationType{PropagationNone}},
+	}
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.WithPropagation(tt.types...)
+		if !slices.Equal(cfg.Propagation, tt.want) {
+			t.Errorf("%s: Propagation = %v, want %v", tt.name, cfg.Propagation, tt.want)
+		}
 	}
 }
 
@@ -176,7 +211,7 @@ func TestConfig_Validate_Valid(t *testing.T) {
 				ServiceVersion: "v1.0.0",
 				Endpoint:       "localhost:4318",
 				SampleRate:     0.5,
-				Propagation:    PropagationW3C,
+				Propagation:    []PropagationType{PropagationW3C},
 				Exporter:       ExporterOTLPHTTP,
 			},
 		},
@@ -198,7 +233,7 @@ func TestConfig_Validate_Valid(t *testing.T) {
 			name: "empty propagation defaults",
 			cfg: Config{
 				ServiceName: "test",
-				Propagation: "",
+				Propagation: nil,
 			},
 		},
 		{
@@ -208,6 +243,45 @@ func TestConfig_Validate_Valid(t *testing.T) {
 				Exporter:    "",
 			},
 		},
+		{
+			name: "secure export with mTLS, gzip, timeout and retry",
+			cfg: Config{
+				ServiceName: "test",
+				TLS:         TLSConfig{CAFile: "ca.pem", CertFile: "client.pem", KeyFile: "client-key.pem"},
+				Compression: CompressionGzip,
+				Timeout:     5 * time.Second,
+				Retry:       RetryConfig{InitialInterval: time.Second, MaxInterval: 10 * time.Second},
+				URLPath:     "/custom/v1/traces",
+			},
+		},
+		{
+			name: "batch and span limits",
+			cfg: Config{
+				ServiceName: "test",
+				Batch: BatchConfig{
+					MaxQueueSize:       8192,
+					MaxExportBatchSize: 1024,
+					ScheduleDelay:      time.Second,
+					ExportTimeout:      10 * time.Second,
+				},
+				SpanLimits: SpanLimitsConfig{AttributeCountLimit: 64, AttributeValueLengthLimit: 1024},
+			},
+		},
+		{
+			name: "multiple propagation with inject subset",
+			cfg: Config{
+				ServiceName:       "test",
+				Propagation:       []PropagationType{PropagationW3C, PropagationB3Multi, PropagationJaeger, PropagationBaggage},
+				InjectPropagation: []PropagationType{PropagationW3C, PropagationBaggage},
+			},
+		},
+		{
+			name: "retry disabled",
+			cfg: Config{
+				ServiceName: "test",
+				Retry:       RetryConfig{Disabled: true},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -245,14 +319,100 @@ func TestConfig_Validate_Invalid(t *testing.T) {
 		},
 		{
 			name:    "invalid propagation type",
-			cfg:     Config{ServiceName: "test", Propagation: "invalid"},
+			cfg:     Config{ServiceName: "test", Propagation: []PropagationType{"invalid"}},
 			wantErr: "invalid propagation type",
 		},
+		{
+			name:    "none combined with other propagation",
+			cfg:     Config{ServiceName: "test", Propagation: []PropagationType{PropagationNone, PropagationW3C}},
+			wantErr: "cannot be combined",
+		},
+		{
+			name: "inject propagation not configured",
+			cfg: Config{
+				ServiceName:       "test",
+				Propagation:       []PropagationType{PropagationW3C},
+				InjectPropagation: []PropagationType{PropagationB3},
+			},
+			wantErr: "not in the configured propagation",
+		},
 		{
 			name:    "invalid exporter type",
 			cfg:     Config{ServiceName: "test", Exporter: "invalid"},
 			wantErr: "invalid exporter type",
 		},
+		{
+			name: "insecure with client certificate",
+			cfg: Config{
+				ServiceName: "test",
+				Insecure:    true,
+				TLS:         TLSConfig{CertFile: "client.pem", KeyFile: "client-key.pem"},
+			},
+			wantErr: "cannot be combined with insecure",
+		},
+		{
+			name:    "insecure with CA file",
+			cfg:     Config{ServiceName: "test", Insecure: true, TLS: TLSConfig{CAFile: "ca.pem"}},
+			wantErr: "cannot be combined with insecure",
+		},
+		{
+			name:    "client certificate without key",
+			cfg:     Config{ServiceName: "test", TLS: TLSConfig{CertFile: "client.pem"}},
+			wantErr: "must be set together",
+		},
+		{
+			name:    "invalid compression",
+			cfg:     Config{ServiceName: "test", Compression: "zstd"},
+			wantErr: "invalid compression type",
+		},
+		{
+			name:    "negative timeout",
+			cfg:     Config{ServiceName: "test", Timeout: -time.Second},
+			wantErr: "export timeout must not be negative",
+		},
+		{
+			name:    "negative retry interval",
+			cfg:     Config{ServiceName: "test", Retry: RetryConfig{MaxElapsedTime: -time.Second}},
+			wantErr: "retry intervals must not be negative",
+		},
+		{
+			name: "retry initial interval exceeds max",
+			cfg: Config{
+				ServiceName: "test",
+				Retry:       RetryConfig{InitialInterval: time.Minute, MaxInterval: time.Second},
+			},
+			wantErr: "exceeds max interval",
+		},
+		{
+			name:    "negative batch queue size",
+			cfg:     Config{ServiceName: "test", Batch: BatchConfig{MaxQueueSize: -1}},
+			wantErr: "sizes must not be negative",
+		},
+		{
+			name:    "negative batch schedule delay",
+			cfg:     Config{ServiceName: "test", Batch: BatchConfig{ScheduleDelay: -time.Second}},
+			wantErr: "must not be negative",
+		},
+		{
+			name:    "export batch larger than queue",
+			cfg:     Config{ServiceName: "test", Batch: BatchConfig{MaxQueueSize: 100, MaxExportBatchSize: 200}},
+			wantErr: "exceeds max queue size",
+		},
+		{
+			name:    "negative span limit",
+			cfg:     Config{ServiceName: "test", SpanLimits: SpanLimitsConfig{EventCountLimit: -1}},
+			wantErr: "span limits must not be negative",
+		},
+		{
+			name:    "URL path with gRPC exporter",
+			cfg:     Config{ServiceName: "test", Exporter: ExporterOTLPGRPC, URLPath: "/v1/traces"},
+			wantErr: "URL path is only supported",
+		},
+		{
+			name:    "URL path without leading slash",
+			cfg:     Config{ServiceName: "test", URLPath: "v1/traces"},
+			wantErr: "URL path must start with /",
+		},
 	}
 
 	for _, tt := range tests {
@@ -295,6 +455,18 @@ func TestPropagationType_Constants(t *testing.T) {
 	if PropagationB3 != "b3" {
 		t.Errorf("PropagationB3 = %v, want b3", PropagationB3)
 	}
+	if PropagationB3Single != "b3single" {
+		t.Errorf("PropagationB3Single = %v, want b3single", PropagationB3Single)
+	}
+	if PropagationB3Multi != "b3multi" {
+		t.Errorf("PropagationB3Multi = %v, want b3multi", PropagationB3Multi)
+	}
+	if PropagationJaeger != "jaeger" {
+		t.Errorf("PropagationJaeger = %v, want jaeger", PropagationJaeger)
+	}
+	if PropagationBaggage != "baggage" {
+		t.Errorf("PropagationBaggage = %v, want baggage", PropagationBaggage)
+	}
 }
 
 func TestExporterType_Constants(t *testing.T) {
@@ -310,3 +482,147 @@ func TestExporterType_Constants(t *testing.T) {
 		t.Errorf("ExporterNone = %v, want none", ExporterNone)
 	}
 }
+
+func TestConfig_ExportSetters(t *testing.T) {
+	t.Parallel()
+
+	tlsCfg := TLSConfig{CAFile: "ca.pem", ServerName: "collector.internal"}
+	retry := RetryConfig{InitialInterval: time.Second}
+
+	cfg := DefaultConfig()
+	cfg.WithInsecure(false).
+		WithTLS(tlsCfg).
+		WithCompression(CompressionGzip).
+		WithTimeout(3 * time.Second).
+		WithRetry(retry).
+		WithURLPath("/otlp/v1/traces")
+
+	if cfg.TLS != tlsCfg {
+		t.Errorf("TLS = %+v, want %+v", cfg.TLS, tlsCfg)
+	}
+	if cfg.Compression != CompressionGzip {
+		t.Errorf("Compression = %v, want %v", cfg.Compression, CompressionGzip)
+	}
+	if cfg.Timeout != 3*time.Second {
+		t.Errorf("Timeout = %v, want 3s", cfg.Timeout)
+	}
+	if cfg.Retry != retry {
+		t.Errorf("Retry = %+v, want %+v", cfg.Retry, retry)
+	}
+	if cfg.URLPath != "/otlp/v1/traces" {
+		t.Errorf("URLPath = %v, want /otlp/v1/traces", cfg.URLPath)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
+
+func TestRetryConfig_WithDefaults(t *testing.T) {
+	t.Parallel()
+
+	retry := RetryConfig{MaxInterval: 10 * time.Second}.WithDefaults()
+
+	if retry.InitialInterval != defaultRetryInitialInterval {
+		t.Errorf("InitialInterval = %v, want %v", retry.InitialInterval, defaultRetryInitialInterval)
+	}
+	if retry.MaxInterval != 10*time.Second {
+		t.Errorf("MaxInterval = %v, want 10s", retry.MaxInterval)
+	}
+	if retry.MaxElapsedTime != defaultRetryMaxElapsedTime {
+		t.Errorf("MaxElapsedTime = %v, want %v", retry.MaxElapsedTime, defaultRetryMaxElapsedTime)
+	}
+}
+
+func TestTLSConfig_ClientConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	tlsCfg, err := TLSConfig{
+		CAFile:     certFile,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		ServerName: "collector.internal",
+	}.ClientConfig()
+	if err != nil {
+		t.Fatalf("ClientConfig() error = %v", err)
+	}
+	if tlsCfg.RootCAs == nil {
+		t.Error("RootCAs should be set from CA file")
+	}
+	if len(tlsCfg.Certificates) != 1 {
+		t.Errorf("Certificates length = %d, want 1", len(tlsCfg.Certificates))
+	}
+	if tlsCfg.ServerName != "collector.internal" {
+		t.Errorf("ServerName = %v, want collector.internal", tlsCfg.ServerName)
+	}
+}
+
+func TestTLSConfig_ClientConfig_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	garbage := filepath.Join(dir, "garbage.pem")
+	if err := os.WriteFile(garbage, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     TLSConfig
+		wantErr string
+	}{
+		{"missing CA file", TLSConfig{CAFile: filepath.Join(dir, "missing.pem")}, "failed to read CA file"},
+		{"invalid CA file", TLSConfig{CAFile: garbage}, "no valid certificates"},
+		{"invalid key pair", TLSConfig{CertFile: garbage, KeyFile: garbage}, "failed to load client certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tt.cfg.ClientConfig()
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("ClientConfig() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and key to dir.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "collector.internal"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey() error = %v", err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return certFile, keyFile
+}
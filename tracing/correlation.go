@@ -0,0 +1,196 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultCorrelationMaxLength is the maximum correlation header value length
+// used when CorrelationHeader.MaxLength is zero.
+const DefaultCorrelationMaxLength = 256
+
+// CorrelationHeader configures a request header, such as X-Device-ID, that is
+// carried in baggage and re-emitted on every downstream request and message.
+type CorrelationHeader struct {
+	// Name is the header name (e.g., "X-Device-ID").
+	Name string
+
+	// BaggageKey is the baggage member key holding the value.
+	// Empty uses the lower-cased header name.
+	BaggageKey string
+
+	// SpanAttribute also records the value on server spans, keyed by BaggageKey.
+	SpanAttribute bool
+
+	// MaxLength truncates longer values, in bytes.
+	// Zero uses DefaultCorrelationMaxLength.
+	MaxLength int
+
+	// Redact transforms the value before it is propagated or recorded,
+	// e.g. to mask it. Returning an empty string drops the value.
+	Redact func(value string) string
+}
+
+// key returns the baggage key for the header.
+func (h CorrelationHeader) key() string {
+	if h.BaggageKey != "" {
+		return h.BaggageKey
+	}
+	return strings.ToLower(h.Name)
+}
+
+// normalize applies redaction and the length limit to an incoming header value.
+func (h CorrelationHeader) normalize(value string) string {
+	if h.Redact != nil {
+		value = h.Redact(value)
+	}
+	return h.truncate(value)
+}
+
+// truncate applies the length limit to a value.
+func (h CorrelationHeader) truncate(value string) string {
+	maxLength := h.MaxLength
+	if maxLength == 0 {
+		maxLength = DefaultCorrelationMaxLength
+	}
+	return truncateString(value, maxLength)
+}
+
+// truncateString cuts value to at most maxLength bytes without splitting a
+// multi-byte rune.
+func truncateString(value string, maxLength int) string {
+	if len(value) <= maxLength {
+		return value
+	}
+	value = value[:maxLength]
+	for !utf8.ValidString(value) {
+		value = value[:len(value)-1]
+	}
+	return value
+}
+
+// validate checks the header configuration.
+func (h CorrelationHeader) validate() error {
+	if h.Name == "" {
+		return fmt.Errorf("correlation header name is required")
+	}
+	if h.MaxLength < 0 {
+		return fmt.Errorf("correlation header %s: max length must be non-negative, got %d", h.Name, h.MaxLength)
+	}
+	if !isToken(h.Name) {
+		return fmt.Errorf("invalid correlation header name %q", h.Name)
+	}
+	if !isToken(h.key()) {
+		return fmt.Errorf("correlation header %s: invalid baggage key %q", h.Name, h.key())
+	}
+	return nil
+}
+
+// isToken reports whether s is an RFC 7230 token, as required for both header
+// names and baggage keys.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// MaskRedactor returns a redaction function that replaces all but the last
+// visible characters of a value with '*'.
+func MaskRedactor(visible int) func(string) string {
+	return func(value string) string {
+		runes := []rune(value)
+		masked := len(runes) - visible
+		if masked <= 0 {
+			return value
+		}
+		return strings.Repeat("*", masked) + string(runes[masked:])
+	}
+}
+
+// correlationPropagator copies configured headers into baggage on extraction
+// and writes them back as headers on injection.
+type correlationPropagator struct {
+	headers []CorrelationHeader
+}
+
+// Extract implements propagation.TextMapPropagator. Header values are merged
+// into any baggage already extracted, taking precedence over it.
+func (p correlationPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	bag := baggage.FromContext(ctx)
+	changed := false
+	for _, h := range p.headers {
+		value := carrier.Get(h.Name)
+		if value == "" {
+			continue
+		}
+		if value = h.normalize(value); value == "" {
+			continue
+		}
+		member, err := baggage.NewMemberRaw(h.key(), value)
+		if err != nil {
+			continue
+		}
+		if bag, err = bag.SetMember(member); err == nil {
+			changed = true
+		}
+	}
+	if !changed {
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+// Inject implements propagation.TextMapPropagator. Values were redacted on
+// extraction, so only the length limit is applied. Headers already set on the
+// carrier are left untouched.
+func (p correlationPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	bag := baggage.FromContext(ctx)
+	for _, h := range p.headers {
+		if carrier.Get(h.Name) != "" {
+			continue
+		}
+		if value := h.truncate(bag.Member(h.key()).Value()); value != "" {
+			carrier.Set(h.Name, value)
+		}
+	}
+}
+
+// Fields implements propagation.TextMapPropagator.
+func (p correlationPropagator) Fields() []string {
+	fields := make([]string, 0, len(p.headers))
+	for _, h := range p.headers {
+		fields = append(fields, h.Name)
+	}
+	return fields
+}
+
+// setCorrelationAttributes records correlation values from baggage on the span
+// for headers with SpanAttribute set.
+func setCorrelationAttributes(ctx context.Context, span trace.Span, headers []CorrelationHeader) {
+	bag := baggage.FromContext(ctx)
+	for _, h := range headers {
+		if !h.SpanAttribute {
+			continue
+		}
+		if value := bag.Member(h.key()).Value(); value != "" {
+			span.SetAttributes(attribute.String(h.key(), value))
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCorrelationHeader_Normalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header CorrelationHeader
+		value  string
+		want   string
+	}{
+		{"unchanged", CorrelationHeader{Name: "X-Device-ID"}, "device-1", "device-1"},
+		{"truncated", CorrelationHeader{Name: "X-Device-ID", MaxLength: 4}, "device-1", "devi"},
+		{"default limit", CorrelationHeader{Name: "X-Device-ID"}, strings.Repeat("a", 300), strings.Repeat("a", DefaultCorrelationMaxLength)},
+		{"keeps runes whole", CorrelationHeader{Name: "X-Device-ID", MaxLength: 2}, "aé", "a"},
+		{"redacted", CorrelationHeader{Name: "X-Session-ID", Redact: MaskRedactor(2)}, "secret", "****et"},
+		{"dropped by redactor", CorrelationHeader{Name: "X-Session-ID", Redact: func(string) string { return "" }}, "secret", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.header.normalize(tt.value); got != tt.want {
+				t.Errorf("normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskRedactor(t *testing.T) {
+	t.Parallel()
+
+	mask := MaskRedactor(4)
+	if got := mask("258841234567"); got != "********4567" {
+		t.Errorf("mask() = %q, want ********4567", got)
+	}
+	if got := mask("abc"); got != "abc" {
+		t.Errorf("mask() = %q, want abc", got)
+	}
+}
+
+func TestConfig_Validate_CorrelationHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		headers []CorrelationHeader
+		wantErr string
+	}{
+		{"valid", []CorrelationHeader{{Name: "X-Device-ID"}, {Name: "X-App-Version", BaggageKey: "app.version"}}, ""},
+		{"missing name", []CorrelationHeader{{BaggageKey: "device.id"}}, "name is required"},
+		{"negative max length", []CorrelationHeader{{Name: "X-Device-ID", MaxLength: -1}}, "max length"},
+		{"invalid name", []CorrelationHeader{{Name: "X-Device ID"}}, "invalid correlation header name"},
+		{"invalid baggage key", []CorrelationHeader{{Name: "X-Device-ID", BaggageKey: "device id"}}, "invalid baggage key"},
+		{"duplicate", []CorrelationHeader{{Name: "X-Device-ID"}, {Name: "x-device-id"}}, "duplicate correlation header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := DefaultConfig()
+			cfg.WithServiceName("test-service").WithCorrelationHeaders(tt.headers...)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCorrelationPropagator(t *testing.T) {
+	t.Parallel()
+
+	prop := correlationPropagator{headers: []CorrelationHeader{
+		{Name: "X-Device-ID", BaggageKey: "device.id"},
+		{Name: "X-Session-ID", Redact: MaskRedactor(2)},
+	}}
+
+	// Existing baggage is kept and header values take precedence.
+	existing, _ := baggage.NewMemberRaw("device.id", "stale")
+	city, _ := baggage.NewMemberRaw("city", "maputo")
+	bag, _ := baggage.New(existing, city)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	in := http.Header{}
+	in.Set("X-Device-ID", "device-1")
+	in.Set("X-Session-ID", "session")
+	ctx = prop.Extract(ctx, HTTPCarrier(in))
+
+	got := baggage.FromContext(ctx)
+	if v := got.Member("device.id").Value(); v != "device-1" {
+		t.Errorf("device.id = %q, want device-1", v)
+	}
+	if v := got.Member("x-session-id").Value(); v != "*****on" {
+		t.Errorf("x-session-id = %q, want *****on", v)
+	}
+	if v := got.Member("city").Value(); v != "maputo" {
+		t.Errorf("city = %q, want maputo", v)
+	}
+
+	out := http.Header{}
+	out.Set("X-Device-ID", "explicit")
+	prop.Inject(ctx, HTTPCarrier(out))
+
+	if v := out.Get("X-Device-ID"); v != "explicit" {
+		t.Errorf("X-Device-ID = %q, explicit header should be kept", v)
+	}
+	if v := out.Get("X-Session-ID"); v != "*****on" {
+		t.Errorf("X-Session-ID = %q, want *****on", v)
+	}
+
+	if fields := prop.Fields(); len(fields) != 2 {
+		t.Errorf("Fields() = %v, want 2 headers", fields)
+	}
+}
+
+func TestCorrelationHeaders_EndToEnd(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := testConfig("test-service")
+	cfg.SampleRate = 1.0
+	cfg.DisableGlobalPropagator = true
+	cfg.WithCorrelationHeaders(
+		CorrelationHeader{Name: "X-Device-ID", BaggageKey: "device.id", SpanAttribute: true},
+		CorrelationHeader{Name: "X-App-Version"},
+	)
+
+	tracer, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer.Provider().RegisterSpanProcessor(recorder)
+
+	var forwarded http.Header
+	downstream := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Clone()
+	}))
+	defer downstream.Close()
+
+	client := &http.Client{Transport: RoundTripper(tracer, nil)}
+	handler := Middleware(tracer)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, downstream.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("Do() error = %v", err)
+			return
+		}
+		resp.Body.Close()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/rides", nil)
+	req.Header.Set("X-Device-ID", "device-1")
+	req.Header.Set("X-App-Version", "3.2.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if v := forwarded.Get("X-Device-ID"); v != "device-1" {
+		t.Errorf("forwarded X-Device-ID = %q, want device-1", v)
+	}
+	if v := forwarded.Get("X-App-Version"); v != "3.2.1" {
+		t.Errorf("forwarded X-App-Version = %q, want 3.2.1", v)
+	}
+
+	server := findSpan(recorder.Ended(), "GET /api/v1/rides")
+	if server == nil {
+		t.Fatal("server span not recorded")
+	}
+	attrs := attribute.NewSet(server.Attributes()...)
+	if v, ok := attrs.Value("device.id"); !ok || v.AsString() != "device-1" {
+		t.Errorf("device.id attribute = %v, want device-1", v)
+	}
+	if _, ok := attrs.Value("x-app-version"); ok {
+		t.Error("x-app-version should not be recorded as a span attribute")
+	}
+}
+
+// findSpan returns the first span with the given name.
+func findSpan(spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, s := range spans {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,412 @@
+package tracing
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenTelemetry SDK environment variables understood by ConfigFromEnv.
+// Signal-specific OTEL_EXPORTER_OTLP_TRACES_* variables take precedence over
+// their generic OTEL_EXPORTER_OTLP_* counterparts.
+const (
+	EnvServiceName        = "OTEL_SERVICE_NAME"
+	EnvResourceAttributes = "OTEL_RESOURCE_ATTRIBUTES"
+	EnvTracesExporter     = "OTEL_TRACES_EXPORTER"
+	EnvTracesSampler      = "OTEL_TRACES_SAMPLER"
+	EnvTracesSamplerArg   = "OTEL_TRACES_SAMPLER_ARG"
+	EnvPropagators        = "OTEL_PROPAGATORS"
+
+	EnvExporterEndpoint          = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvExporterTracesEndpoint    = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	EnvExporterProtocol          = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	EnvExporterTracesProtocol    = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
+	EnvExporterHeaders           = "OTEL_EXPORTER_OTLP_HEADERS"
+	EnvExporterTracesHeaders     = "OTEL_EXPORTER_OTLP_TRACES_HEADERS"
+	EnvExporterInsecure          = "OTEL_EXPORTER_OTLP_INSECURE"
+	EnvExporterTracesInsecure    = "OTEL_EXPORTER_OTLP_TRACES_INSECURE"
+	EnvExporterCertificate       = "OTEL_EXPORTER_OTLP_CERTIFICATE"
+	EnvExporterTracesCertificate = "OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE"
+	EnvExporterClientCert        = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
+	EnvExporterTracesClientCert  = "OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE"
+	EnvExporterClientKey         = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
+	EnvExporterTracesClientKey   = "OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY"
+	EnvExporterCompression       = "OTEL_EXPORTER_OTLP_COMPRESSION"
+	EnvExporterTracesCompression = "OTEL_EXPORTER_OTLP_TRACES_COMPRESSION"
+	EnvExporterTimeout           = "OTEL_EXPORTER_OTLP_TIMEOUT"
+	EnvExporterTracesTimeout     = "OTEL_EXPORTER_OTLP_TRACES_TIMEOUT"
+
+	EnvBSPScheduleDelay      = "OTEL_BSP_SCHEDULE_DELAY"
+	EnvBSPExportTimeout      = "OTEL_BSP_EXPORT_TIMEOUT"
+	EnvBSPMaxQueueSize       = "OTEL_BSP_MAX_QUEUE_SIZE"
+	EnvBSPMaxExportBatchSize = "OTEL_BSP_MAX_EXPORT_BATCH_SIZE"
+
+	EnvSpanAttributeCountLimit       = "OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT"
+	EnvSpanAttributeValueLengthLimit = "OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT"
+	EnvSpanEventCountLimit           = "OTEL_SPAN_EVENT_COUNT_LIMIT"
+	EnvSpanLinkCountLimit            = "OTEL_SPAN_LINK_COUNT_LIMIT"
+
+	// EnvSemConvStabilityOptIn selects the HTTP semantic convention keys:
+	// "http" for the stable keys and "http/dup" for both stable and legacy.
+	EnvSemConvStabilityOptIn = "OTEL_SEMCONV_STABILITY_OPT_IN"
+)
+
+// defaultTracesURLPath is the OTLP HTTP path appended to a generic endpoint.
+const defaultTracesURLPath = "/v1/traces"
+
+// envLookup looks up an environment variable, like os.LookupEnv.
+type envLookup func(key string) (string, bool)
+
+// ConfigFromEnv returns DefaultConfig overlaid with the standard OTEL_*
+// environment variables defined by the OpenTelemetry SDK specification.
+//
+// Precedence, from highest to lowest:
+//  1. Programmatic settings applied to the returned Config (e.g., With* methods).
+//  2. Signal-specific variables (OTEL_EXPORTER_OTLP_TRACES_*).
+//  3. Generic variables (OTEL_EXPORTER_OTLP_*), and OTEL_SERVICE_NAME over
+//     service.name in OTEL_RESOURCE_ATTRIBUTES.
+//  4. DefaultConfig values.
+//
+// Invalid values return an error naming the offending variable.
+func ConfigFromEnv() (Config, error) {
+	return configFromEnv(os.LookupEnv)
+}
+
+// configFromEnv builds a Config using the given environment lookup.
+func configFromEnv(lookup envLookup) (Config, error) {
+	cfg := DefaultConfig()
+	e := envReader{lookup: lookup}
+
+	e.resource(&cfg)
+	e.exporter(&cfg)
+	e.connection(&cfg)
+	e.sampler(&cfg)
+	e.propagators(&cfg)
+	e.batch(&cfg)
+	e.spanLimits(&cfg)
+	e.semConv(&cfg)
+
+	if e.err != nil {
+		return Config{}, e.err
+	}
+	return cfg, nil
+}
+
+// envReader reads environment variables, keeping the first parse error.
+type envReader struct {
+	lookup envLookup
+	err    error
+}
+
+// get returns the trimmed value of the first non-empty variable among keys,
+// along with the name of that variable.
+func (e *envReader) get(keys ...string) (value, key string) {
+	for _, k := range keys {
+		if v, ok := e.lookup(k); ok && strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v), k
+		}
+	}
+	return "", ""
+}
+
+// fail records an error for the named variable if none has been recorded yet.
+func (e *envReader) fail(key string, err error) {
+	if e.err == nil {
+		e.err = fmt.Errorf("invalid %s: %w", key, err)
+	}
+}
+
+// setString assigns the first non-empty variable to dst.
+func (e *envReader) setString(dst *string, keys ...string) {
+	if v, _ := e.get(keys...); v != "" {
+		*dst = v
+	}
+}
+
+// setBool parses the first non-empty variable as a bool into dst.
+func (e *envReader) setBool(dst *bool, keys ...string) {
+	v, key := e.get(keys...)
+	if v == "" {
+		return
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.fail(key, err)
+		return
+	}
+	*dst = b
+}
+
+// setInt parses the first non-empty variable as a non-negative int into dst.
+func (e *envReader) setInt(dst *int, keys ...string) {
+	v, key := e.get(keys...)
+	if v == "" {
+		return
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		e.fail(key, err)
+		return
+	}
+	if n < 0 {
+		e.fail(key, fmt.Errorf("must not be negative, got %d", n))
+		return
+	}
+	*dst = n
+}
+
+// setMillis parses the first non-empty variable as milliseconds into dst.
+func (e *envReader) setMillis(dst *time.Duration, keys ...string) {
+	var ms int
+	e.setInt(&ms, keys...)
+	if ms > 0 {
+		*dst = time.Duration(ms) * time.Millisecond
+	}
+}
+
+// resource reads the service name and resource attributes.
+func (e *envReader) resource(cfg *Config) {
+	if v, key := e.get(EnvResourceAttributes); v != "" {
+		attrs, err := parseKeyValues(v)
+		if err != nil {
+			e.fail(key, err)
+			return
+		}
+		for k, val := range attrs {
+			switch k {
+			case AttrServiceName:
+				cfg.ServiceName = val
+			case AttrServiceVersion:
+				cfg.ServiceVersion = val
+			case AttrDeploymentEnvironment, "deployment.environment.name":
+				cfg.Environment = val
+			case "service.instance.id":
+				cfg.ServiceInstanceID = val
+			default:
+				if cfg.ResourceAttributes == nil {
+					cfg.ResourceAttributes = make(map[string]string)
+				}
+				cfg.ResourceAttributes[k] = val
+			}
+		}
+	}
+
+	e.setString(&cfg.ServiceName, EnvServiceName)
+}
+
+// exporter reads the exporter type and endpoint.
+func (e *envReader) exporter(cfg *Config) {
+	if v, key := e.get(EnvTracesExporter); v != "" {
+		switch strings.ToLower(v) {
+		case "otlp":
+			// Protocol decides between HTTP and gRPC.
+		case "none":
+			cfg.Exporter = ExporterNone
+		default:
+			e.fail(key, fmt.Errorf("unsupported exporter %q", v))
+		}
+	}
+
+	if v, key := e.get(EnvExporterTracesProtocol, EnvExporterProtocol); v != "" && cfg.Exporter != ExporterNone {
+		switch v {
+		case "grpc":
+			cfg.Exporter = ExporterOTLPGRPC
+		case "http/protobuf":
+			cfg.Exporter = ExporterOTLPHTTP
+		default:
+			e.fail(key, fmt.Errorf("unsupported protocol %q", v))
+		}
+	}
+
+	if v, key := e.get(EnvExporterTracesEndpoint); v != "" {
+		e.endpoint(cfg, key, v, false)
+	} else if v, key := e.get(EnvExporterEndpoint); v != "" {
+		e.endpoint(cfg, key, v, true)
+	}
+}
+
+// connection reads the exporter headers, TLS, compression and timeout.
+func (e *envReader) connection(cfg *Config) {
+	if v, key := e.get(EnvExporterTracesHeaders, EnvExporterHeaders); v != "" {
+		headers, err := parseKeyValues(v)
+		if err != nil {
+			e.fail(key, err)
+		} else {
+			cfg.Headers = headers
+		}
+	}
+
+	e.setBool(&cfg.Insecure, EnvExporterTracesInsecure, EnvExporterInsecure)
+	e.setString(&cfg.TLS.CAFile, EnvExporterTracesCertificate, EnvExporterCertificate)
+	e.setString(&cfg.TLS.CertFile, EnvExporterTracesClientCert, EnvExporterClientCert)
+	e.setString(&cfg.TLS.KeyFile, EnvExporterTracesClientKey, EnvExporterClientKey)
+	if cfg.TLS.IsSet() {
+		cfg.Insecure = false
+	}
+
+	if v, key := e.get(EnvExporterTracesCompression, EnvExporterCompression); v != "" {
+		switch CompressionType(v) {
+		case CompressionGzip, CompressionNone:
+			cfg.Compression = CompressionType(v)
+		default:
+			e.fail(key, fmt.Errorf("unsupported compression %q", v))
+		}
+	}
+
+	e.setMillis(&cfg.Timeout, EnvExporterTracesTimeout, EnvExporterTimeout)
+}
+
+// endpoint parses an OTLP endpoint URL into Endpoint, Insecure and URLPath.
+// A generic endpoint is a base URL to which the traces path is appended;
+// a signal-specific endpoint is used as-is.
+func (e *envReader) endpoint(cfg *Config, key, value string, generic bool) {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		e.fail(key, fmt.Errorf("endpoint must be an absolute URL, got %q", value))
+		return
+	}
+
+	switch u.Scheme {
+	case "http":
+		cfg.Insecure = true
+	case "https":
+		cfg.Insecure = false
+	default:
+		e.fail(key, fmt.Errorf("unsupported endpoint scheme %q", u.Scheme))
+		return
+	}
+	cfg.Endpoint = u.Host
+
+	if cfg.Exporter == ExporterOTLPGRPC {
+		return
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	if generic {
+		path += defaultTracesURLPath
+	}
+	if path != "" && path != defaultTracesURLPath {
+		cfg.URLPath = path
+	}
+}
+
+// sampler reads OTEL_TRACES_SAMPLER and its argument.
+func (e *envReader) sampler(cfg *Config) {
+	v, key := e.get(EnvTracesSampler)
+	if v == "" {
+		return
+	}
+
+	cfg.ParentBased = strings.HasPrefix(v, "parentbased_")
+	switch strings.TrimPrefix(v, "parentbased_") {
+	case "always_on":
+		cfg.SampleRate = 1
+	case "always_off":
+		cfg.SampleRate = 0
+	case "traceidratio":
+		cfg.SampleRate = 1
+		arg, argKey := e.get(EnvTracesSamplerArg)
+		if arg == "" {
+			return
+		}
+		rate, err := strconv.ParseFloat(arg, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			e.fail(argKey, fmt.Errorf("sampler ratio must be between 0.0 and 1.0, got %q", arg))
+			return
+		}
+		cfg.SampleRate = rate
+	default:
+		e.fail(key, fmt.Errorf("unsupported sampler %q", v))
+	}
+}
+
+// propagators reads OTEL_PROPAGATORS.
+func (e *envReader) propagators(cfg *Config) {
+	v, key := e.get(EnvPropagators)
+	if v == "" {
+		return
+	}
+
+	names := map[string]PropagationType{
+		"tracecontext": PropagationW3C,
+		"baggage":      PropagationBaggage,
+		"b3":           PropagationB3Single,
+		"b3multi":      PropagationB3Multi,
+		"jaeger":       PropagationJaeger,
+		"none":         PropagationNone,
+	}
+
+	var types []PropagationType
+	for _, name := range strings.Split(v, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		propagationType, ok := names[name]
+		if !ok {
+			e.fail(key, fmt.Errorf("unsupported propagator %q", name))
+			return
+		}
+		types = append(types, propagationType)
+	}
+	if len(types) > 0 {
+		cfg.Propagation = types
+	}
+}
+
+// batch reads the OTEL_BSP_* batch span processor settings.
+func (e *envReader) batch(cfg *Config) {
+	e.setMillis(&cfg.Batch.ScheduleDelay, EnvBSPScheduleDelay)
+	e.setMillis(&cfg.Batch.ExportTimeout, EnvBSPExportTimeout)
+	e.setInt(&cfg.Batch.MaxQueueSize, EnvBSPMaxQueueSize)
+	e.setInt(&cfg.Batch.MaxExportBatchSize, EnvBSPMaxExportBatchSize)
+}
+
+// spanLimits reads the OTEL_SPAN_* limits.
+func (e *envReader) spanLimits(cfg *Config) {
+	e.setInt(&cfg.SpanLimits.AttributeCountLimit, EnvSpanAttributeCountLimit)
+	e.setInt(&cfg.SpanLimits.AttributeValueLengthLimit, EnvSpanAttributeValueLengthLimit)
+	e.setInt(&cfg.SpanLimits.EventCountLimit, EnvSpanEventCountLimit)
+	e.setInt(&cfg.SpanLimits.LinkCountLimit, EnvSpanLinkCountLimit)
+}
+
+// semConv reads the semantic convention stability opt-in. Other signals in the
+// comma-separated list (e.g., "database") are ignored.
+func (e *envReader) semConv(cfg *Config) {
+	v, _ := e.get(EnvSemConvStabilityOptIn)
+	for _, name := range strings.Split(v, ",") {
+		switch strings.TrimSpace(name) {
+		case "http/dup":
+			cfg.SemConvMode = SemConvBoth
+		case "http":
+			if cfg.SemConvMode != SemConvBoth {
+				cfg.SemConvMode = SemConvNew
+			}
+		}
+	}
+}
+
+// parseKeyValues parses a W3C-baggage-style "key1=value1,key2=value2" list
+// with percent-encoded values, as used by OTEL_RESOURCE_ATTRIBUTES and
+// OTEL_EXPORTER_OTLP_HEADERS.
+func parseKeyValues(s string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("malformed key-value pair %q", pair)
+		}
+		decoded, err := url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("malformed value for key %q: %w", k, err)
+		}
+		result[k] = decoded
+	}
+	return result, nil
+}
@@ -0,0 +1,279 @@
+package tracing
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mapLookup returns an envLookup backed by a map.
+func mapLookup(env map[string]string) envLookup {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestConfigFromEnv_Empty(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := configFromEnv(mapLookup(nil))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+
+	def := DefaultConfig()
+	if cfg.ServiceName != def.ServiceName || cfg.Endpoint != def.Endpoint || cfg.Exporter != def.Exporter {
+		t.Errorf("configFromEnv() = %+v, want defaults", cfg)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := configFromEnv(mapLookup(map[string]string{
+		EnvServiceName:                   "ride-service",
+		EnvResourceAttributes:            "service.name=ignored,service.version=v2.0.0,deployment.environment=staging,team=rides%20core",
+		EnvExporterEndpoint:              "https://collector.internal:4318/otlp",
+		EnvExporterHeaders:               "Authorization=Bearer%20token,X-Tenant=txova",
+		EnvExporterCompression:           "gzip",
+		EnvExporterTimeout:               "2500",
+		EnvExporterCertificate:           "/etc/otel/ca.pem",
+		EnvTracesSampler:                 "parentbased_traceidratio",
+		EnvTracesSamplerArg:              "0.25",
+		EnvPropagators:                   "tracecontext,baggage,b3",
+		EnvBSPScheduleDelay:              "1000",
+		EnvBSPMaxQueueSize:               "4096",
+		EnvSpanAttributeValueLengthLimit: "512",
+	}))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+
+	if cfg.ServiceName != "ride-service" {
+		t.Errorf("ServiceName = %v, want ride-service (OTEL_SERVICE_NAME wins)", cfg.ServiceName)
+	}
+	if cfg.ServiceVersion != "v2.0.0" {
+		t.Errorf("ServiceVersion = %v, want v2.0.0", cfg.ServiceVersion)
+	}
+	if cfg.Environment != "staging" {
+		t.Errorf("Environment = %v, want staging", cfg.Environment)
+	}
+	if cfg.ResourceAttributes["team"] != "rides core" {
+		t.Errorf("ResourceAttributes[team] = %q, want %q", cfg.ResourceAttributes["team"], "rides core")
+	}
+	if cfg.Endpoint != "collector.internal:4318" {
+		t.Errorf("Endpoint = %v, want collector.internal:4318", cfg.Endpoint)
+	}
+	if cfg.Insecure {
+		t.Error("Insecure should be false for an https endpoint")
+	}
+	if cfg.URLPath != "/otlp/v1/traces" {
+		t.Errorf("URLPath = %v, want /otlp/v1/traces", cfg.URLPath)
+	}
+	if cfg.Headers["Authorization"] != "Bearer token" || cfg.Headers["X-Tenant"] != "txova" {
+		t.Errorf("Headers = %v", cfg.Headers)
+	}
+	if cfg.Compression != CompressionGzip {
+		t.Errorf("Compression = %v, want gzip", cfg.Compression)
+	}
+	if cfg.Timeout != 2500*time.Millisecond {
+		t.Errorf("Timeout = %v, want 2.5s", cfg.Timeout)
+	}
+	if cfg.TLS.CAFile != "/etc/otel/ca.pem" {
+		t.Errorf("TLS.CAFile = %v, want /etc/otel/ca.pem", cfg.TLS.CAFile)
+	}
+	if cfg.SampleRate != 0.25 || !cfg.ParentBased {
+		t.Errorf("SampleRate = %v, ParentBased = %v, want 0.25 parent-based", cfg.SampleRate, cfg.ParentBased)
+	}
+	wantPropagation := []PropagationType{PropagationW3C, PropagationBaggage, PropagationB3Single}
+	if !slices.Equal(cfg.Propagation, wantPropagation) {
+		t.Errorf("Propagation = %v, want %v", cfg.Propagation, wantPropagation)
+	}
+	if cfg.Batch.ScheduleDelay != time.Second || cfg.Batch.MaxQueueSize != 4096 {
+		t.Errorf("Batch = %+v", cfg.Batch)
+	}
+	if cfg.SpanLimits.AttributeValueLengthLimit != 512 {
+		t.Errorf("SpanLimits.AttributeValueLengthLimit = %d, want 512", cfg.SpanLimits.AttributeValueLengthLimit)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestConfigFromEnv_SignalSpecificPrecedence(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := configFromEnv(mapLookup(map[string]string{
+		EnvExporterProtocol:       "http/protobuf",
+		EnvExporterTracesProtocol: "grpc",
+		EnvExporterEndpoint:       "https://generic:4318",
+		EnvExporterTracesEndpoint: "http://traces:4317",
+		EnvExporterTimeout:        "1000",
+		EnvExporterTracesTimeout:  "3000",
+	}))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+
+	if cfg.Exporter != ExporterOTLPGRPC {
+		t.Errorf("Exporter = %v, want %v", cfg.Exporter, ExporterOTLPGRPC)
+	}
+	if cfg.Endpoint != "traces:4317" {
+		t.Errorf("Endpoint = %v, want traces:4317", cfg.Endpoint)
+	}
+	if !cfg.Insecure {
+		t.Error("Insecure should be true for an http endpoint")
+	}
+	if cfg.URLPath != "" {
+		t.Errorf("URLPath = %v, want empty for gRPC", cfg.URLPath)
+	}
+	if cfg.Timeout != 3*time.Second {
+		t.Errorf("Timeout = %v, want 3s", cfg.Timeout)
+	}
+}
+
+func TestConfigFromEnv_Samplers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sampler     string
+		arg         string
+		wantRate    float64
+		wantParent  bool
+		description string
+	}{
+		{"always_on", "", 1, false, "always on"},
+		{"always_off", "", 0, false, "always off"},
+		{"traceidratio", "0.1", 0.1, false, "ratio"},
+		{"traceidratio", "", 1, false, "ratio without argument"},
+		{"parentbased_always_off", "", 0, true, "parent-based always off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+
+			env := map[string]string{EnvTracesSampler: tt.sampler}
+			if tt.arg != "" {
+				env[EnvTracesSamplerArg] = tt.arg
+			}
+			cfg, err := configFromEnv(mapLookup(env))
+			if err != nil {
+				t.Fatalf("configFromEnv() error = %v", err)
+			}
+			if cfg.SampleRate != tt.wantRate || cfg.ParentBased != tt.wantParent {
+				t.Errorf("SampleRate = %v, ParentBased = %v, want %v, %v", cfg.SampleRate, cfg.ParentBased, tt.wantRate, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnv_ExporterNone(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := configFromEnv(mapLookup(map[string]string{
+		EnvTracesExporter:   "none",
+		EnvExporterProtocol: "grpc",
+	}))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+	if cfg.Exporter != ExporterNone {
+		t.Errorf("Exporter = %v, want %v", cfg.Exporter, ExporterNone)
+	}
+}
+
+func TestConfigFromEnv_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{EnvTracesExporter, "zipkin"},
+		{EnvExporterProtocol, "http/json"},
+		{EnvExporterEndpoint, "collector:4318"},
+		{EnvExporterTracesEndpoint, "ftp://collector:4318"},
+		{EnvExporterHeaders, "no-equals-sign"},
+		{EnvExporterInsecure, "maybe"},
+		{EnvExporterCompression, "zstd"},
+		{EnvExporterTimeout, "10s"},
+		{EnvTracesSampler, "jaeger_remote"},
+		{EnvPropagators, "xray"},
+		{EnvBSPMaxQueueSize, "-1"},
+		{EnvSpanLinkCountLimit, "many"},
+		{EnvResourceAttributes, "=value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := configFromEnv(mapLookup(map[string]string{tt.key: tt.value}))
+			if err == nil {
+				t.Fatalf("configFromEnv() error = nil, want error for %s=%s", tt.key, tt.value)
+			}
+			if !strings.Contains(err.Error(), tt.key) {
+				t.Errorf("error %q does not name %s", err, tt.key)
+			}
+		})
+	}
+
+	_, err := configFromEnv(mapLookup(map[string]string{
+		EnvTracesSampler:    "traceidratio",
+		EnvTracesSamplerArg: "1.5",
+	}))
+	if err == nil || !strings.Contains(err.Error(), EnvTracesSamplerArg) {
+		t.Errorf("configFromEnv() error = %v, want error naming %s", err, EnvTracesSamplerArg)
+	}
+}
+
+func TestConfigFromEnv_ProgrammaticOverride(t *testing.T) {
+	t.Setenv(EnvServiceName, "from-env")
+	t.Setenv(EnvTracesSampler, "always_off")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.ServiceName != "from-env" {
+		t.Errorf("ServiceName = %v, want from-env", cfg.ServiceName)
+	}
+
+	cfg.WithServiceName("from-code").WithSampleRate(0.5)
+	if cfg.ServiceName != "from-code" || cfg.SampleRate != 0.5 {
+		t.Errorf("programmatic settings should override environment, got %v, %v", cfg.ServiceName, cfg.SampleRate)
+	}
+}
+
+func TestConfigFromEnv_SemConvStabilityOptIn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  SemConvMode
+	}{
+		{"", ""},
+		{"http", SemConvNew},
+		{"http/dup", SemConvBoth},
+		{"database, http/dup", SemConvBoth},
+		{"http/dup,http", SemConvBoth},
+		{"database", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := configFromEnv(mapLookup(map[string]string{EnvSemConvStabilityOptIn: tt.value}))
+			if err != nil {
+				t.Fatalf("configFromEnv() error = %v", err)
+			}
+			if cfg.SemConvMode != tt.want {
+				t.Errorf("SemConvMode = %q, want %q", cfg.SemConvMode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AttrErrorExpected marks exception events for expected errors.
+const AttrErrorExpected = "error.expected"
+
+// Well-known error.type values assigned by ClassifyError.
+const (
+	ErrorTypeTimeout  = "timeout"
+	ErrorTypeCanceled = "canceled"
+	ErrorTypeOther    = "_OTHER"
+)
+
+// Default error recording limits.
+const (
+	DefaultMaxErrorMessageLength = 1024
+	DefaultMaxStackTraceLength   = 8192
+)
+
+// maxStackDepth bounds the frames captured for a stack trace.
+const maxStackDepth = 32
+
+// ErrorTyper is implemented by errors that name their own error.type,
+// e.g. domain errors such as "ride_not_found".
+type ErrorTyper interface {
+	ErrorType() string
+}
+
+// ErrorClassifier returns the error.type for err, or an empty string to fall
+// back to the built-in classification.
+type ErrorClassifier func(err error) string
+
+// SentinelError assigns an error.type to errors matching Err with errors.Is.
+type SentinelError struct {
+	Err  error
+	Type string
+}
+
+// ErrorConfig configures how RecordError records errors on spans.
+type ErrorConfig struct {
+	// Classify, if set, is consulted before Sentinels and ClassifyError.
+	Classify ErrorClassifier
+
+	// Sentinels map sentinel errors to error types. The first match wins.
+	Sentinels []SentinelError
+
+	// IsExpected reports errors that are part of normal operation (e.g., a
+	// rider cancelling a request). Expected errors are recorded as events but
+	// do not set the span status to error.
+	IsExpected func(err error) bool
+
+	// DisableStackTrace stops capturing the call site stack trace.
+	DisableStackTrace bool
+
+	// MaxMessageLength bounds the recorded error message and status description.
+	// Zero uses DefaultMaxErrorMessageLength.
+	MaxMessageLength int
+
+	// MaxStackTraceLength bounds the recorded stack trace.
+	// Zero uses DefaultMaxStackTraceLength.
+	MaxStackTraceLength int
+}
+
+// validate checks the error recording limits.
+func (c ErrorConfig) validate() error {
+	if c.MaxMessageLength < 0 || c.MaxStackTraceLength < 0 {
+		return fmt.Errorf("error message and stack trace lengths must be non-negative")
+	}
+	for _, s := range c.Sentinels {
+		if s.Err == nil || s.Type == "" {
+			return fmt.Errorf("sentinel errors require an error and a type")
+		}
+	}
+	return nil
+}
+
+// ErrorOption configures a single RecordError call.
+type ErrorOption func(*errorOptions)
+
+// errorOptions holds the RecordError options.
+type errorOptions struct {
+	expected  bool
+	errorType string
+}
+
+// Expected marks the error as expected: it is recorded as an event but does
+// not set the span status to error.
+func Expected() ErrorOption {
+	return func(o *errorOptions) {
+		o.expected = true
+	}
+}
+
+// WithErrorType sets the error.type instead of deriving it from the error.
+func WithErrorType(errorType string) ErrorOption {
+	return func(o *errorOptions) {
+		o.errorType = errorType
+	}
+}
+
+// ClassifyError derives the error.type of err from its chain: an ErrorTyper,
+// a gRPC status code (e.g., "NotFound"), an HTTP status code exposed by a
+// StatusCode() int method (e.g., "503"), context cancellation and timeouts,
+// and finally the type name of the root cause. Plain errors created with
+// errors.New or fmt.Errorf are reported as ErrorTypeOther.
+func ClassifyError(err error) string {
+	var typer ErrorTyper
+	if errors.As(err, &typer) {
+		if t := typer.ErrorType(); t != "" {
+			return t
+		}
+	}
+
+	if s, ok := status.FromError(err); ok && s.Code() != grpccodes.OK {
+		return s.Code().String()
+	}
+
+	var httpErr interface{ StatusCode() int }
+	if errors.As(err, &httpErr) {
+		return strconv.Itoa(httpErr.StatusCode())
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorTypeTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrorTypeCanceled
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorTypeTimeout
+	}
+
+	return rootCauseType(err)
+}
+
+// rootCauseType returns the type name of the innermost error in the chain,
+// or ErrorTypeOther for the standard library's anonymous error types.
+func rootCauseType(err error) string {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			break
+		}
+		err = next
+	}
+
+	switch t := fmt.Sprintf("%T", err); t {
+	case "*errors.errorString", "*fmt.wrapError", "*fmt.wrapErrors", "*errors.joinError":
+		return ErrorTypeOther
+	default:
+		return t
+	}
+}
+
+// errorRecorder records errors on spans with the configured classification,
+// limits and metrics.
+type errorRecorder struct {
+	config  ErrorConfig
+	metrics MetricsRecorder
+}
+
+// newErrorRecorder applies the defaults to cfg. recorder may be nil.
+func newErrorRecorder(cfg ErrorConfig, recorder MetricsRecorder) *errorRecorder { //nolint:gocritic // cfg passed by value for API simplicity
+	if cfg.MaxMessageLength == 0 {
+		cfg.MaxMessageLength = DefaultMaxErrorMessageLength
+	}
+	if cfg.MaxStackTraceLength == 0 {
+		cfg.MaxStackTraceLength = DefaultMaxStackTraceLength
+	}
+	return &errorRecorder{config: cfg, metrics: recorder}
+}
+
+var (
+	// errorRecorders maps tracer providers created by New to their recorders,
+	// so RecordError can find the configuration from the span alone.
+	errorRecorders sync.Map
+
+	// defaultErrorRecorder is used for spans from other providers.
+	defaultErrorRecorder = newErrorRecorder(ErrorConfig{}, nil)
+)
+
+// errorRecorderFor returns the recorder registered for the span's provider.
+func errorRecorderFor(span trace.Span) *errorRecorder {
+	if v, ok := errorRecorders.Load(span.TracerProvider()); ok {
+		if r, ok := v.(*errorRecorder); ok {
+			return r
+		}
+	}
+	return defaultErrorRecorder
+}
+
+// classify returns the error.type for err.
+func (r *errorRecorder) classify(err error) string {
+	if r.config.Classify != nil {
+		if t := r.config.Classify(err); t != "" {
+			return t
+		}
+	}
+	for _, s := range r.config.Sentinels {
+		if errors.Is(err, s.Err) {
+			return s.Type
+		}
+	}
+	return ClassifyError(err)
+}
+
+// record adds an exception event for err to span and, unless the error is
+// expected, sets error.type and the error status. skip is the number of
+// frames above the caller of record to omit from the stack trace.
+func (r *errorRecorder) record(span trace.Span, err error, opts []ErrorOption, skip int) {
+	if err == nil {
+		return
+	}
+
+	var o errorOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	errorType := o.errorType
+	if errorType == "" {
+		errorType = r.classify(err)
+	}
+	expected := o.expected || (r.config.IsExpected != nil && r.config.IsExpected(err))
+	message := truncateString(err.Error(), r.config.MaxMessageLength)
+
+	attrs := []attribute.KeyValue{
+		semconv.ExceptionType(fmt.Sprintf("%T", err)),
+		semconv.ExceptionMessage(message),
+		ErrorType(errorType),
+	}
+	if !r.config.DisableStackTrace {
+		stack := truncateString(captureStack(skip), r.config.MaxStackTraceLength)
+		attrs = append(attrs, semconv.ExceptionStacktrace(stack))
+	}
+	if expected {
+		attrs = append(attrs, attribute.Bool(AttrErrorExpected, true))
+	}
+	span.AddEvent(semconv.ExceptionEventName, trace.WithAttributes(attrs...))
+
+	if !expected {
+		span.SetAttributes(ErrorType(errorType))
+		span.SetStatus(codes.Error, message)
+	}
+
+	if r.metrics != nil {
+		r.metrics.RecordError(errorType, expected)
+	}
+}
+
+// captureStack formats the stack starting at the caller of its caller, skipping
+// skip more frames, with one "function\n\tfile:line" entry per frame.
+func captureStack(skip int) string {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
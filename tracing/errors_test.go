@@ -0,0 +1,334 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// domainError names its own error type.
+type domainError struct{ kind string }
+
+func (e *domainError) Error() string     { return e.kind }
+func (e *domainError) ErrorType() string { return e.kind }
+
+// httpError exposes an HTTP status code.
+type httpError struct{ code int }
+
+func (e *httpError) Error() string   { return fmt.Sprintf("http %d", e.code) }
+func (e *httpError) StatusCode() int { return e.code }
+
+// rootError is a named error type without classification hints.
+type rootError struct{}
+
+func (rootError) Error() string { return "root" }
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return false }
+
+var _ net.Error = timeoutError{}
+
+var errRideCancelled = errors.New("ride cancelled")
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"error typer", fmt.Errorf("wrapped: %w", &domainError{kind: "ride_not_found"}), "ride_not_found"},
+		{"grpc status", status.Error(grpccodes.NotFound, "missing"), "NotFound"},
+		{"http status", fmt.Errorf("call: %w", &httpError{code: 503}), "503"},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), ErrorTypeTimeout},
+		{"canceled", context.Canceled, ErrorTypeCanceled},
+		{"net timeout", &net.OpError{Op: "dial", Err: timeoutError{}}, ErrorTypeTimeout},
+		{"root cause type", fmt.Errorf("outer: %w", rootError{}), "tracing.rootError"},
+		{"plain error", errors.New("boom"), ErrorTypeOther},
+		{"wrapped plain error", fmt.Errorf("outer: %w", errors.New("boom")), ErrorTypeOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     ErrorConfig
+		wantErr bool
+	}{
+		{"empty", ErrorConfig{}, false},
+		{"sentinel", ErrorConfig{Sentinels: []SentinelError{{Err: errRideCancelled, Type: "ride_cancelled"}}}, false},
+		{"negative message length", ErrorConfig{MaxMessageLength: -1}, true},
+		{"negative stack length", ErrorConfig{MaxStackTraceLength: -1}, true},
+		{"sentinel without type", ErrorConfig{Sentinels: []SentinelError{{Err: errRideCancelled}}}, true},
+		{"sentinel without error", ErrorConfig{Sentinels: []SentinelError{{Type: "ride_cancelled"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testConfig("test-service")
+			cfg.Errors = tt.cfg
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// recordErrorSpan records err on a new span from a tracer configured with
+// cfg and returns the exported span.
+func recordErrorSpan(t *testing.T, cfg ErrorConfig, recorder MetricsRecorder, err error, opts ...ErrorOption) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	ctx := context.Background()
+	tracerCfg := testConfig("test-service")
+	tracerCfg.SampleRate = 1.0
+	tracerCfg.Errors = cfg
+	tracerCfg.Metrics = recorder
+
+	tracer, newErr := New(ctx, tracerCfg)
+	if newErr != nil {
+		t.Fatalf("New() error = %v", newErr)
+	}
+	t.Cleanup(func() { tracer.Shutdown(ctx) })
+
+	spans := tracetest.NewSpanRecorder()
+	tracer.Provider().RegisterSpanProcessor(spans)
+
+	spanCtx, span := tracer.Start(ctx, "operation")
+	RecordError(spanCtx, err, opts...)
+	span.End()
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("spans = %d, want 1", len(ended))
+	}
+	return ended[0]
+}
+
+// exceptionEvent returns the attributes of the span's exception event.
+func exceptionEvent(t *testing.T, span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	t.Helper()
+
+	for _, event := range span.Events() {
+		if event.Name == semconv.ExceptionEventName {
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, kv := range event.Attributes {
+				attrs[kv.Key] = kv.Value
+			}
+			return attrs
+		}
+	}
+	t.Fatal("exception event not found")
+	return nil
+}
+
+func TestRecordError_Unexpected(t *testing.T) {
+	t.Parallel()
+
+	recorder := countingRecorder{}
+	span := recordErrorSpan(t, ErrorConfig{}, recorder, fmt.Errorf("query: %w", context.DeadlineExceeded))
+
+	if span.Status().Code != codes.Error {
+		t.Errorf("status = %v, want Error", span.Status().Code)
+	}
+	if span.Status().Description != "query: context deadline exceeded" {
+		t.Errorf("status description = %q", span.Status().Description)
+	}
+	if !hasAttribute(span.Attributes(), ErrorType(ErrorTypeTimeout)) {
+		t.Errorf("span attributes = %v, want error.type=timeout", span.Attributes())
+	}
+
+	attrs := exceptionEvent(t, span)
+	if got := attrs[semconv.ExceptionTypeKey].AsString(); got != "*fmt.wrapError" {
+		t.Errorf("exception.type = %q, want *fmt.wrapError", got)
+	}
+	if got := attrs[semconv.ErrorTypeKey].AsString(); got != ErrorTypeTimeout {
+		t.Errorf("event error.type = %q, want %q", got, ErrorTypeTimeout)
+	}
+	if _, ok := attrs[AttrErrorExpected]; ok {
+		t.Error("unexpected error should not carry error.expected")
+	}
+	stack := attrs[semconv.ExceptionStacktraceKey].AsString()
+	if !strings.Contains(stack, "recordErrorSpan") {
+		t.Errorf("stack trace does not start at the caller:\n%s", stack)
+	}
+	if strings.Contains(stack, "errorRecorder") {
+		t.Errorf("stack trace includes recorder internals:\n%s", stack)
+	}
+
+	if recorder["error:"+ErrorTypeTimeout] != 1 {
+		t.Errorf("recorded errors = %v, want one timeout", recorder)
+	}
+}
+
+func TestRecordError_Expected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  ErrorConfig
+		opts []ErrorOption
+	}{
+		{"option", ErrorConfig{}, []ErrorOption{Expected()}},
+		{"config", ErrorConfig{IsExpected: func(err error) bool { return errors.Is(err, errRideCancelled) }}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := countingRecorder{}
+			span := recordErrorSpan(t, tt.cfg, recorder, errRideCancelled, tt.opts...)
+
+			if span.Status().Code == codes.Error {
+				t.Error("expected error should not set the error status")
+			}
+			if hasAttribute(span.Attributes(), ErrorType(ErrorTypeOther)) {
+				t.Error("expected error should not set error.type on the span")
+			}
+			attrs := exceptionEvent(t, span)
+			if !attrs[AttrErrorExpected].AsBool() {
+				t.Error("exception event missing error.expected=true")
+			}
+			if recorder["error:"+ErrorTypeOther+":expected"] != 1 {
+				t.Errorf("recorded errors = %v, want one expected", recorder)
+			}
+		})
+	}
+}
+
+func TestRecordError_Classification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  ErrorConfig
+		err  error
+		opts []ErrorOption
+		want string
+	}{
+		{
+			name: "sentinel",
+			cfg:  ErrorConfig{Sentinels: []SentinelError{{Err: errRideCancelled, Type: "ride_cancelled"}}},
+			err:  fmt.Errorf("dispatch: %w", errRideCancelled),
+			want: "ride_cancelled",
+		},
+		{
+			name: "classifier before sentinels",
+			cfg: ErrorConfig{
+				Classify:  func(error) string { return "custom" },
+				Sentinels: []SentinelError{{Err: errRideCancelled, Type: "ride_cancelled"}},
+			},
+			err:  errRideCancelled,
+			want: "custom",
+		},
+		{
+			name: "classifier falls back",
+			cfg:  ErrorConfig{Classify: func(error) string { return "" }},
+			err:  context.Canceled,
+			want: ErrorTypeCanceled,
+		},
+		{
+			name: "option overrides",
+			cfg:  ErrorConfig{Classify: func(error) string { return "custom" }},
+			err:  errRideCancelled,
+			opts: []ErrorOption{WithErrorType("payment_declined")},
+			want: "payment_declined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			span := recordErrorSpan(t, tt.cfg, nil, tt.err, tt.opts...)
+			if got := exceptionEvent(t, span)[semconv.ErrorTypeKey].AsString(); got != tt.want {
+				t.Errorf("error.type = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordError_Limits(t *testing.T) {
+	t.Parallel()
+
+	cfg := ErrorConfig{MaxMessageLength: 8, DisableStackTrace: true}
+	span := recordErrorSpan(t, cfg, nil, errors.New(strings.Repeat("x", 100)))
+
+	attrs := exceptionEvent(t, span)
+	if got := attrs[semconv.ExceptionMessageKey].AsString(); got != strings.Repeat("x", 8) {
+		t.Errorf("exception.message = %q, want 8 characters", got)
+	}
+	if span.Status().Description != strings.Repeat("x", 8) {
+		t.Errorf("status description = %q, want 8 characters", span.Status().Description)
+	}
+	if _, ok := attrs[semconv.ExceptionStacktraceKey]; ok {
+		t.Error("stack trace recorded despite DisableStackTrace")
+	}
+
+	span = recordErrorSpan(t, ErrorConfig{MaxStackTraceLength: 16}, nil, errors.New("boom"))
+	if got := exceptionEvent(t, span)[semconv.ExceptionStacktraceKey].AsString(); len(got) > 16 {
+		t.Errorf("stack trace length = %d, want at most 16", len(got))
+	}
+}
+
+func TestTracer_RecordError(t *testing.T) {
+	t.Parallel()
+
+	tracer, spans := newRecordingTracer(t)
+	ctx, span := tracer.Start(context.Background(), "operation")
+	tracer.RecordError(ctx, nil)
+	tracer.RecordError(ctx, status.Error(grpccodes.Unavailable, "down"))
+	span.End()
+
+	s := findSpan(spans.Ended(), "operation")
+	if s == nil {
+		t.Fatal("span not found")
+	}
+	if len(s.Events()) != 1 {
+		t.Errorf("events = %d, want 1 (nil errors are ignored)", len(s.Events()))
+	}
+	if !hasAttribute(s.Attributes(), ErrorType("Unavailable")) {
+		t.Errorf("span attributes = %v, want error.type=Unavailable", s.Attributes())
+	}
+}
+
+// hasAttribute reports whether attrs contains want.
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,366 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataRequestID is the gRPC metadata key carrying the request ID.
+// gRPC metadata keys are lowercase.
+var metadataRequestID = strings.ToLower(HeaderRequestID)
+
+// MetadataCarrier wraps gRPC metadata to implement propagation.TextMapCarrier.
+type MetadataCarrier metadata.MD
+
+// Get returns the first value associated with the passed key.
+func (c MetadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set stores the key-value pair.
+func (c MetadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns all keys in the carrier.
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// SplitGRPCMethod splits a full gRPC method name ("/pkg.Service/Method")
+// into the service ("pkg.Service") and method ("Method") names.
+func SplitGRPCMethod(fullMethod string) (service, method string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "", fullMethod
+}
+
+// GRPCFilter reports whether a call to the full method name should be instrumented.
+type GRPCFilter func(fullMethod string) bool
+
+// SkipGRPCServices returns a GRPCFilter that skips calls to the given services.
+func SkipGRPCServices(services ...string) GRPCFilter {
+	skip := make(map[string]bool, len(services))
+	for _, s := range services {
+		skip[s] = true
+	}
+	return func(fullMethod string) bool {
+		service, _ := SplitGRPCMethod(fullMethod)
+		return !skip[service]
+	}
+}
+
+// DefaultGRPCFilter skips the gRPC health checking service, so probes do
+// not produce spans.
+var DefaultGRPCFilter = SkipGRPCServices("grpc.health.v1.Health")
+
+// GRPCOption configures the gRPC interceptors.
+type GRPCOption func(*grpcConfig)
+
+// grpcConfig holds the gRPC interceptor options.
+type grpcConfig struct {
+	filter GRPCFilter
+}
+
+// newGRPCConfig applies the options over the defaults.
+func newGRPCConfig(opts []GRPCOption) grpcConfig {
+	cfg := grpcConfig{filter: DefaultGRPCFilter}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// traced reports whether calls to fullMethod are traced.
+func (c grpcConfig) traced(fullMethod string) bool {
+	return c.filter == nil || c.filter(fullMethod)
+}
+
+// WithGRPCFilter sets the filter deciding which calls are traced.
+// It replaces DefaultGRPCFilter; nil traces every call.
+func WithGRPCFilter(filter GRPCFilter) GRPCOption {
+	return func(c *grpcConfig) {
+		c.filter = filter
+	}
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor that creates spans for
+// incoming unary calls. By default, health checks are not traced; see
+// WithGRPCFilter.
+func UnaryServerInterceptor(tracer *Tracer, opts ...GRPCOption) grpc.UnaryServerInterceptor {
+	cfg := newGRPCConfig(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !cfg.traced(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, span := startGRPCServerSpan(ctx, tracer, info.FullMethod)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		endGRPCSpan(tracer, span, trace.SpanKindServer, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor that creates a span
+// covering each incoming stream. By default, health checks are not traced;
+// see WithGRPCFilter.
+func StreamServerInterceptor(tracer *Tracer, opts ...GRPCOption) grpc.StreamServerInterceptor {
+	cfg := newGRPCConfig(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !cfg.traced(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, span := startGRPCServerSpan(ss.Context(), tracer, info.FullMethod)
+		defer span.End()
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		endGRPCSpan(tracer, span, trace.SpanKindServer, err)
+		return err
+	}
+}
+
+// startGRPCServerSpan extracts the trace context and request ID from the
+// incoming metadata and starts the server span.
+func startGRPCServerSpan(ctx context.Context, tracer *Tracer, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	// Extract trace context from incoming metadata.
+	ctx = tracer.Propagator().Extract(ctx, MetadataCarrier(md))
+
+	ctx, span := tracer.Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+	)
+	setGRPCAttributes(span, fullMethod)
+
+	// Resolve or generate the request ID, store it in the context and echo it.
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = MetadataCarrier(md).Get(metadataRequestID)
+		if !validRequestID(requestID) {
+			requestID = tracer.config.RequestIDGenerator(ctx)
+		}
+		ctx = ContextWithRequestID(ctx, requestID)
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, requestID))
+	span.SetAttributes(RequestID(requestID))
+
+	// Record configured correlation headers carried in baggage.
+	setCorrelationAttributes(ctx, span, tracer.config.CorrelationHeaders)
+
+	return ctx, span
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the server span.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor returns a gRPC interceptor that creates spans for
+// outgoing unary calls and propagates the trace context and request ID in
+// the outgoing metadata.
+func UnaryClientInterceptor(tracer *Tracer, opts ...GRPCOption) grpc.UnaryClientInterceptor {
+	cfg := newGRPCConfig(opts)
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		if !cfg.traced(method) {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+
+		ctx, span := startGRPCClientSpan(ctx, tracer, method)
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		endGRPCSpan(tracer, span, trace.SpanKindClient, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a gRPC interceptor that creates a span for
+// each outgoing stream and propagates the trace context and request ID in
+// the outgoing metadata. The span ends when the stream fails, RecvMsg
+// returns io.EOF, the single response of a client-streaming call is
+// received, or the stream context is done.
+func StreamClientInterceptor(tracer *Tracer, opts ...GRPCOption) grpc.StreamClientInterceptor {
+	cfg := newGRPCConfig(opts)
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if !cfg.traced(method) {
+			return streamer(ctx, desc, cc, method, callOpts...)
+		}
+
+		ctx, span := startGRPCClientSpan(ctx, tracer, method)
+		finish := func(err error) {
+			endGRPCSpan(tracer, span, trace.SpanKindClient, err)
+			span.End()
+		}
+
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			finish(err)
+			return nil, err
+		}
+		return NewClientStream(ctx, cs, desc, finish), nil
+	}
+}
+
+// startGRPCClientSpan starts the client span and injects the trace context
+// and request ID into the outgoing metadata.
+func startGRPCClientSpan(ctx context.Context, tracer *Tracer, fullMethod string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	setGRPCAttributes(span, fullMethod)
+
+	// Copy the outgoing metadata to avoid modifying the caller's.
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+
+	// Inject trace context into outgoing metadata.
+	tracer.Propagator().Inject(ctx, MetadataCarrier(md))
+
+	// Forward the request ID unless the caller set one explicitly.
+	if len(md.Get(metadataRequestID)) == 0 {
+		if requestID := RequestIDFromContext(ctx); requestID != "" {
+			md.Set(metadataRequestID, requestID)
+		}
+	}
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// setGRPCAttributes adds the standard RPC attributes.
+func setGRPCAttributes(span trace.Span, fullMethod string) {
+	service, method := SplitGRPCMethod(fullMethod)
+	span.SetAttributes(RPCSystem("grpc"), RPCService(service), RPCMethod(method))
+}
+
+// endGRPCSpan records the status code of a finished call. Following the
+// semantic conventions, server spans are only marked as error for codes
+// indicating a server fault; other errors are recorded as expected.
+func endGRPCSpan(tracer *Tracer, span trace.Span, kind trace.SpanKind, err error) {
+	code := status.Code(err)
+	span.SetAttributes(RPCGRPCStatusCode(int(code)))
+
+	if err == nil {
+		span.SetStatus(codes.Ok, "")
+		return
+	}
+
+	var opts []ErrorOption
+	if kind == trace.SpanKindServer && !isGRPCServerFault(code) {
+		opts = append(opts, Expected())
+	}
+	tracer.errors.record(span, err, opts, 0)
+}
+
+// isGRPCServerFault reports whether a status code indicates a server fault.
+func isGRPCServerFault(code grpccodes.Code) bool {
+	switch code {
+	case grpccodes.Unknown, grpccodes.DeadlineExceeded, grpccodes.Unimplemented,
+		grpccodes.Internal, grpccodes.Unavailable, grpccodes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewClientStream wraps a grpc.ClientStream to call finish once, with the
+// final error (nil on success), when the stream completes: when a call
+// fails, when RecvMsg returns io.EOF, after the single response of a
+// stream without server streaming, or when ctx, the context the stream was
+// created with, is done. The latter covers callers that cancel a stream
+// without calling RecvMsg again.
+func NewClientStream(ctx context.Context, cs grpc.ClientStream, desc *grpc.StreamDesc, finish func(error)) grpc.ClientStream {
+	s := &clientStream{ClientStream: cs, desc: desc, finish: finish, finished: make(chan struct{})}
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				s.done(status.FromContextError(ctx.Err()).Err())
+			case <-s.finished:
+			}
+		}()
+	}
+	return s
+}
+
+// clientStream reports the completion of a grpc.ClientStream.
+type clientStream struct {
+	grpc.ClientStream
+	desc     *grpc.StreamDesc
+	finish   func(error)
+	once     sync.Once
+	finished chan struct{}
+}
+
+// done calls finish once.
+func (s *clientStream) done(err error) {
+	s.once.Do(func() {
+		s.finish(err)
+		close(s.finished)
+	})
+}
+
+// Header returns the header metadata, finishing the stream on error.
+func (s *clientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	if err != nil {
+		s.done(err)
+	}
+	return md, err
+}
+
+// SendMsg sends a message, finishing the stream on error. io.EOF means the
+// stream was terminated and the status is reported by RecvMsg.
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.done(err)
+	}
+	return err
+}
+
+// RecvMsg receives a message, finishing the stream at its end.
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case errors.Is(err, io.EOF):
+		s.done(nil)
+	case err != nil:
+		s.done(err)
+	case !s.desc.ServerStreams:
+		s.done(nil)
+	}
+	return err
+}
@@ -0,0 +1,282 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startGRPCTest serves the standard health service with the tracing
+// interceptors and returns a client connection using them.
+func startGRPCTest(t *testing.T, tracer *Tracer, opts ...GRPCOption) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(tracer, opts...)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(tracer, opts...)),
+	)
+	hs := health.NewServer()
+	hs.SetServingStatus("rides", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor(tracer, opts...)),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor(tracer, opts...)),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+// spanByKind returns the ended span of the given kind.
+func spanByKind(t *testing.T, recorder *tracetest.SpanRecorder, kind trace.SpanKind) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, s := range recorder.Ended() {
+			if s.SpanKind() == kind {
+				return s
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("no ended %v span", kind)
+	return nil
+}
+
+// spanAttribute returns the value of the attribute with the given key.
+func spanAttribute(s sdktrace.ReadOnlySpan, key string) (attribute.Value, bool) {
+	for _, kv := range s.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestGRPCInterceptors_Unary(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	client := startGRPCTest(t, tracer, WithGRPCFilter(nil))
+
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	var header metadata.MD
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "rides"}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	server := spanByKind(t, recorder, trace.SpanKindServer)
+	clientSpan := spanByKind(t, recorder, trace.SpanKindClient)
+
+	if server.Name() != "grpc.health.v1.Health/Check" {
+		t.Errorf("server span name = %q", server.Name())
+	}
+	if server.Parent().SpanID() != clientSpan.SpanContext().SpanID() {
+		t.Error("server span is not a child of the client span")
+	}
+
+	for _, s := range []sdktrace.ReadOnlySpan{server, clientSpan} {
+		want := map[string]attribute.Value{
+			AttrRPCSystem:         attribute.StringValue("grpc"),
+			AttrRPCService:        attribute.StringValue("grpc.health.v1.Health"),
+			AttrRPCMethod:         attribute.StringValue("Check"),
+			AttrRPCGRPCStatusCode: attribute.IntValue(int(grpccodes.OK)),
+		}
+		for key, v := range want {
+			if got, ok := spanAttribute(s, key); !ok || got != v {
+				t.Errorf("%v span %s = %v, want %v", s.SpanKind(), key, got.Emit(), v.Emit())
+			}
+		}
+		if s.Status().Code != codes.Ok {
+			t.Errorf("%v span status = %v, want Ok", s.SpanKind(), s.Status().Code)
+		}
+	}
+
+	if got, _ := spanAttribute(server, AttrRequestID); got.AsString() != "req-123" {
+		t.Errorf("server span request.id = %q, want req-123", got.AsString())
+	}
+	if got := header.Get(metadataRequestID); len(got) != 1 || got[0] != "req-123" {
+		t.Errorf("response header %s = %v, want [req-123]", metadataRequestID, got)
+	}
+}
+
+func TestGRPCInterceptors_UnaryError(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	client := startGRPCTest(t, tracer, WithGRPCFilter(nil))
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	if status.Code(err) != grpccodes.NotFound {
+		t.Fatalf("Check() error = %v, want NotFound", err)
+	}
+
+	// NotFound is a client error: the server span is not marked as failed.
+	server := spanByKind(t, recorder, trace.SpanKindServer)
+	if server.Status().Code == codes.Error {
+		t.Error("server span status = Error, want unset for NotFound")
+	}
+	if got, _ := spanAttribute(server, AttrRPCGRPCStatusCode); got.AsInt64() != int64(grpccodes.NotFound) {
+		t.Errorf("server span status code = %d, want %d", got.AsInt64(), grpccodes.NotFound)
+	}
+
+	clientSpan := spanByKind(t, recorder, trace.SpanKindClient)
+	if clientSpan.Status().Code != codes.Error {
+		t.Errorf("client span status = %v, want Error", clientSpan.Status().Code)
+	}
+}
+
+func TestGRPCInterceptors_Stream(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	client := startGRPCTest(t, tracer, WithGRPCFilter(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "rides"})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("status = %v, want SERVING", resp.GetStatus())
+	}
+	if len(recorder.Ended()) != 0 {
+		t.Error("spans ended while the stream is open")
+	}
+
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != grpccodes.Canceled {
+		t.Fatalf("Recv() after cancel error = %v, want Canceled", err)
+	}
+
+	clientSpan := spanByKind(t, recorder, trace.SpanKindClient)
+	if clientSpan.Name() != "grpc.health.v1.Health/Watch" {
+		t.Errorf("client span name = %q", clientSpan.Name())
+	}
+	if got, _ := spanAttribute(clientSpan, AttrRPCGRPCStatusCode); got.AsInt64() != int64(grpccodes.Canceled) {
+		t.Errorf("client span status code = %d, want %d", got.AsInt64(), grpccodes.Canceled)
+	}
+
+	server := spanByKind(t, recorder, trace.SpanKindServer)
+	if server.Parent().TraceID() != clientSpan.SpanContext().TraceID() {
+		t.Error("server stream span is not in the client trace")
+	}
+}
+
+func TestGRPCInterceptors_StreamCanceledWithoutRecv(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	client := startGRPCTest(t, tracer, WithGRPCFilter(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "rides"})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+
+	// The caller abandons the stream without calling Recv again.
+	cancel()
+
+	clientSpan := spanByKind(t, recorder, trace.SpanKindClient)
+	if got, _ := spanAttribute(clientSpan, AttrRPCGRPCStatusCode); got.AsInt64() != int64(grpccodes.Canceled) {
+		t.Errorf("client span status code = %d, want %d", got.AsInt64(), grpccodes.Canceled)
+	}
+}
+
+func TestGRPCInterceptors_DefaultFilter(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	client := startGRPCTest(t, tracer)
+
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "rides"}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if n := len(recorder.Ended()); n != 0 {
+		t.Errorf("ended spans = %d, want 0 for health checks", n)
+	}
+}
+
+func TestSplitGRPCMethod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fullMethod  string
+		wantService string
+		wantMethod  string
+	}{
+		{"/txova.rides.v1.RideService/GetRide", "txova.rides.v1.RideService", "GetRide"},
+		{"grpc.health.v1.Health/Check", "grpc.health.v1.Health", "Check"},
+		{"Check", "", "Check"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		service, method := SplitGRPCMethod(tt.fullMethod)
+		if service != tt.wantService || method != tt.wantMethod {
+			t.Errorf("SplitGRPCMethod(%q) = %q, %q, want %q, %q", tt.fullMethod, service, method, tt.wantService, tt.wantMethod)
+		}
+	}
+}
+
+func TestSkipGRPCServices(t *testing.T) {
+	t.Parallel()
+
+	filter := SkipGRPCServices("grpc.health.v1.Health")
+	if filter("/grpc.health.v1.Health/Check") {
+		t.Error("filter traces the skipped service")
+	}
+	if !filter("/txova.rides.v1.RideService/GetRide") {
+		t.Error("filter skips other services")
+	}
+}
+
+func TestMetadataCarrier(t *testing.T) {
+	t.Parallel()
+
+	carrier := MetadataCarrier(metadata.MD{})
+	carrier.Set("Traceparent", "00-abc-def-01")
+
+	if got := carrier.Get("traceparent"); got != "00-abc-def-01" {
+		t.Errorf("Get() = %q, want 00-abc-def-01", got)
+	}
+	if got := carrier.Get("missing"); got != "" {
+		t.Errorf("Get(missing) = %q, want empty", got)
+	}
+	if keys := carrier.Keys(); len(keys) != 1 || keys[0] != "traceparent" {
+		t.Errorf("Keys() = %v, want [traceparent]", keys)
+	}
+}
@@ -3,8 +3,11 @@ package tracing
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -20,45 +23,80 @@ func sanitizeURL(u *url.URL) string {
 	return sanitized.String()
 }
 
+// requestScheme returns the scheme of an incoming request, which net/http
+// leaves empty in r.URL for server requests.
+func requestScheme(r *http.Request) string {
+	if r.URL.Scheme != "" {
+		return r.URL.Scheme
+	}
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// hostname returns host without port.
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+}
+
 // Middleware returns an HTTP middleware that creates spans for incoming requests.
-func Middleware(tracer *Tracer) func(http.Handler) http.Handler {
+// By default, requests to the health endpoints are not traced; see WithFilter.
+func Middleware(tracer *Tracer, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	cfg := newMiddlewareConfig(opts)
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cfg.filter != nil && !cfg.filter(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			start := time.Now()
+
 			// Extract trace context from incoming headers.
-			ctx := Extract(r.Context(), r.Header)
+			ctx := tracer.Propagator().Extract(r.Context(), HTTPCarrier(r.Header))
 
 			// Get the route pattern if available, otherwise use the URL path.
 			route := r.URL.Path
 
 			// Start a new span for this request.
-			spanName := fmt.Sprintf("%s %s", r.Method, route)
-			ctx, span := tracer.Start(ctx, spanName,
+			ctx, span := tracer.Start(ctx, cfg.spanName(r),
 				trace.WithSpanKind(trace.SpanKindServer),
 			)
 			defer span.End()
 
 			// Add standard HTTP attributes.
 			// Use sanitized URL to prevent PII leakage from query parameters.
-			span.SetAttributes(
-				HTTPMethod(r.Method),
-				HTTPRoute(route),
-				HTTPURL(sanitizeURL(r.URL)),
-				HTTPScheme(r.URL.Scheme),
-				HTTPHost(r.Host),
-			)
+			mode := tracer.config.SemConvMode
+			span.SetAttributes(HTTPRoute(route))
+			span.SetAttributes(mode.HTTPMethod(r.Method)...)
+			span.SetAttributes(mode.attrs(HTTPURL(sanitizeURL(r.URL)), URLPath(r.URL.Path))...)
+			span.SetAttributes(mode.HTTPScheme(requestScheme(r))...)
+			span.SetAttributes(mode.attrs(HTTPHost(r.Host), ServerAddress(hostname(r.Host)))...)
 
 			// Add optional attributes.
 			if userAgent := r.Header.Get("User-Agent"); userAgent != "" {
-				span.SetAttributes(HTTPUserAgent(userAgent))
+				span.SetAttributes(mode.HTTPUserAgent(userAgent)...)
 			}
 
-			if clientIP := getClientIP(r); clientIP != "" {
-				span.SetAttributes(HTTPClientIP(clientIP))
+			if clientIP := tracer.ClientIPResolver().ClientIP(r); clientIP != "" {
+				span.SetAttributes(mode.HTTPClientIP(clientIP)...)
 			}
 
-			// Extract and propagate request ID.
-			if requestID := ExtractRequestID(r.Header); requestID != "" {
-				span.SetAttributes(RequestID(requestID))
+			// Resolve or generate the request ID, store it in the context and echo it.
+			ctx = ensureRequestID(ctx, w, r.Header, tracer.config.RequestIDGenerator)
+			span.SetAttributes(RequestID(RequestIDFromContext(ctx)))
+
+			// Record configured correlation headers carried in baggage.
+			setCorrelationAttributes(ctx, span, tracer.config.CorrelationHeaders)
+
+			// Record captured request headers and custom attributes.
+			span.SetAttributes(headerAttributes(AttrHTTPRequestHeaderPrefix, r.Header, cfg.requestHeaders)...)
+			if cfg.attributeHook != nil {
+				span.SetAttributes(cfg.attributeHook(r)...)
 			}
 
 			// Create a response writer wrapper to capture the status code.
@@ -67,11 +105,24 @@ func Middleware(tracer *Tracer) func(http.Handler) http.Handler {
 				statusCode:     http.StatusOK,
 			}
 
+			// Set traceresponse and Server-Timing just before the headers are written.
+			if cfg.writesResponseHeaders() {
+				var timing *serverTiming
+				if cfg.serverTiming {
+					ctx, timing = contextWithServerTiming(ctx)
+				}
+				rw.beforeWrite = func() {
+					cfg.setResponseHeaders(w.Header(), span.SpanContext(), start, timing)
+				}
+			}
+
 			// Serve the request with the updated context.
 			next.ServeHTTP(rw, r.WithContext(ctx))
+			rw.prepare()
 
-			// Record the status code.
-			span.SetAttributes(HTTPStatusCode(rw.statusCode))
+			// Record the status code and captured response headers.
+			span.SetAttributes(mode.HTTPStatusCode(rw.statusCode)...)
+			span.SetAttributes(headerAttributes(AttrHTTPResponseHeaderPrefix, rw.Header(), cfg.responseHeaders)...)
 
 			// Mark the span as error if status code indicates an error.
 			if rw.statusCode >= 400 {
@@ -88,10 +139,25 @@ type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
 	written    bool
+
+	// beforeWrite, if set, runs once before the headers are written.
+	beforeWrite func()
+	prepared    bool
+}
+
+// prepare runs beforeWrite once. It is also called after the handler returns,
+// for handlers that write nothing.
+func (rw *responseWriter) prepare() {
+	if rw.prepared || rw.beforeWrite == nil {
+		return
+	}
+	rw.prepared = true
+	rw.beforeWrite()
 }
 
 // WriteHeader captures the status code and writes it.
 func (rw *responseWriter) WriteHeader(statusCode int) {
+	rw.prepare()
 	if !rw.written {
 		rw.statusCode = statusCode
 		rw.written = true
@@ -101,6 +167,7 @@ func (rw *responseWriter) WriteHeader(statusCode int) {
 
 // Write writes the response body.
 func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.prepare()
 	if !rw.written {
 		rw.statusCode = http.StatusOK
 		rw.written = true
@@ -113,20 +180,21 @@ func (rw *responseWriter) Unwrap() http.ResponseWriter {
 	return rw.ResponseWriter
 }
 
-// getClientIP extracts the client IP from the request.
-func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header first.
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		return xff
-	}
+// Flush implements http.Flusher, setting the response headers first when
+// nothing has been written yet, as flushing sends them.
+func (rw *responseWriter) Flush() {
+	_ = rw.FlushError()
+}
 
-	// Check X-Real-IP header.
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		return xri
+// FlushError flushes the response like Flush and returns the error of the
+// wrapped ResponseWriter. http.ResponseController prefers it over Flush.
+func (rw *responseWriter) FlushError() error {
+	rw.prepare()
+	if !rw.written {
+		rw.statusCode = http.StatusOK
+		rw.written = true
 	}
-
-	// Fall back to RemoteAddr.
-	return r.RemoteAddr
+	return http.NewResponseController(rw.ResponseWriter).Flush()
 }
 
 // RoundTripper returns an http.RoundTripper that propagates trace context.
@@ -159,28 +227,31 @@ func (rt *tracingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error
 
 	// Add HTTP attributes.
 	// Use sanitized URL to prevent PII leakage from query parameters.
-	span.SetAttributes(
-		HTTPMethod(r.Method),
-		HTTPURL(sanitizeURL(r.URL)),
-		HTTPHost(r.Host),
-	)
+	mode := rt.tracer.config.SemConvMode
+	span.SetAttributes(mode.HTTPMethod(r.Method)...)
+	span.SetAttributes(mode.HTTPURL(sanitizeURL(r.URL))...)
+	span.SetAttributes(mode.attrs(HTTPHost(r.Host), ServerAddress(r.URL.Hostname()))...)
 
 	// Clone the request to avoid modifying the original.
 	req := r.Clone(ctx)
 
 	// Inject trace context into outgoing headers.
-	Inject(ctx, req.Header)
+	rt.tracer.Propagator().Inject(ctx, HTTPCarrier(req.Header))
+
+	// Forward the request ID unless the caller set one explicitly.
+	if req.Header.Get(HeaderRequestID) == "" {
+		InjectRequestID(req.Header, RequestIDFromContext(ctx))
+	}
 
 	// Make the request.
 	resp, err := rt.base.RoundTrip(req)
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
+		rt.tracer.errors.record(span, err, nil, 0)
 		return nil, err
 	}
 
 	// Record the response status.
-	span.SetAttributes(HTTPStatusCode(resp.StatusCode))
+	span.SetAttributes(mode.HTTPStatusCode(resp.StatusCode)...)
 
 	if resp.StatusCode >= 400 {
 		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
@@ -202,9 +273,11 @@ func AddSpanAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
 	span.SetAttributes(attrs...)
 }
 
-// RecordError records an error on the current span in context.
-func RecordError(ctx context.Context, err error) {
+// RecordError records an error on the current span in context as an exception
+// event with error.type, a bounded message and the call site stack trace, and
+// sets the span status to error unless the error is expected. The settings of
+// the Tracer that created the span apply; see Config.Errors.
+func RecordError(ctx context.Context, err error, opts ...ErrorOption) {
 	span := trace.SpanFromContext(ctx)
-	span.RecordError(err)
-	span.SetStatus(codes.Error, err.Error())
+	errorRecorderFor(span).record(span, err, opts, 1)
 }
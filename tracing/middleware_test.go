@@ -397,59 +397,6 @@ func TestRecordError(t *testing.T) {
 	RecordError(spanCtx, testErr)
 }
 
-func TestGetClientIP(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name     string
-		headers  map[string]string
-		remoteIP string
-		expected string
-	}{
-		{
-			name:     "X-Forwarded-For",
-			headers:  map[string]string{"X-Forwarded-For": "192.168.1.1"},
-			remoteIP: "10.0.0.1:1234",
-			expected: "192.168.1.1",
-		},
-		{
-			name:     "X-Real-IP",
-			headers:  map[string]string{"X-Real-IP": "192.168.1.2"},
-			remoteIP: "10.0.0.1:1234",
-			expected: "192.168.1.2",
-		},
-		{
-			name:     "RemoteAddr fallback",
-			headers:  map[string]string{},
-			remoteIP: "10.0.0.1:1234",
-			expected: "10.0.0.1:1234",
-		},
-		{
-			name:     "X-Forwarded-For takes precedence",
-			headers:  map[string]string{"X-Forwarded-For": "192.168.1.1", "X-Real-IP": "192.168.1.2"},
-			remoteIP: "10.0.0.1:1234",
-			expected: "192.168.1.1",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			req := httptest.NewRequest("GET", "/", nil)
-			req.RemoteAddr = tt.remoteIP
-			for k, v := range tt.headers {
-				req.Header.Set(k, v)
-			}
-
-			got := getClientIP(req)
-			if got != tt.expected {
-				t.Errorf("getClientIP() = %v, want %v", got, tt.expected)
-			}
-		})
-	}
-}
-
 func TestMiddleware_WriteWithoutHeader(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,165 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute key prefixes for captured HTTP headers.
+const (
+	AttrHTTPRequestHeaderPrefix  = "http.request.header."
+	AttrHTTPResponseHeaderPrefix = "http.response.header."
+)
+
+// RequestFilter reports whether a request should be instrumented.
+type RequestFilter func(r *http.Request) bool
+
+// SpanNameFormatter returns the span name for an incoming request.
+type SpanNameFormatter func(r *http.Request) string
+
+// AttributeHook returns additional attributes to record on the request span.
+type AttributeHook func(r *http.Request) []attribute.KeyValue
+
+// SkipPaths returns a RequestFilter that skips requests for the given paths.
+func SkipPaths(paths ...string) RequestFilter {
+	skip := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		skip[p] = true
+	}
+	return func(r *http.Request) bool {
+		return !skip[r.URL.Path]
+	}
+}
+
+// DefaultSkipPaths are the health endpoints registered by
+// health.Handler.RegisterRoutes. A test in the root package keeps them in
+// sync with health.Paths.
+var DefaultSkipPaths = []string{"/health/live", "/health/ready", "/health/startup", "/health"}
+
+// DefaultRequestFilter skips DefaultSkipPaths, so probes do not produce
+// spans. It reads DefaultSkipPaths on each request, so changes to the
+// slice take effect.
+var DefaultRequestFilter RequestFilter = func(r *http.Request) bool {
+	return !slices.Contains(DefaultSkipPaths, r.URL.Path)
+}
+
+// defaultSpanName names server spans "METHOD /path".
+func defaultSpanName(r *http.Request) string {
+	return fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+}
+
+// MiddlewareOption configures Middleware.
+type MiddlewareOption func(*middlewareConfig)
+
+// middlewareConfig holds the Middleware options.
+type middlewareConfig struct {
+	filter          RequestFilter
+	spanName        SpanNameFormatter
+	requestHeaders  []string
+	responseHeaders []string
+	attributeHook   AttributeHook
+	traceResponse   bool
+	serverTiming    bool
+}
+
+// newMiddlewareConfig applies the options over the defaults.
+func newMiddlewareConfig(opts []MiddlewareOption) middlewareConfig {
+	cfg := middlewareConfig{
+		filter:   DefaultRequestFilter,
+		spanName: defaultSpanName,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithFilter sets the filter deciding which requests are traced.
+// It replaces DefaultRequestFilter; nil traces every request.
+func WithFilter(filter RequestFilter) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.filter = filter
+	}
+}
+
+// WithSpanNameFormatter sets the function naming server spans.
+func WithSpanNameFormatter(formatter SpanNameFormatter) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		if formatter != nil {
+			c.spanName = formatter
+		}
+	}
+}
+
+// WithRequestHeaders records the given request headers as
+// http.request.header.<name> attributes.
+func WithRequestHeaders(headers ...string) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.requestHeaders = append(c.requestHeaders, headers...)
+	}
+}
+
+// WithResponseHeaders records the given response headers as
+// http.response.header.<name> attributes.
+func WithResponseHeaders(headers ...string) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.responseHeaders = append(c.responseHeaders, headers...)
+	}
+}
+
+// WithAttributeHook sets a function adding custom attributes from the request.
+func WithAttributeHook(hook AttributeHook) MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.attributeHook = hook
+	}
+}
+
+// WithTraceResponse sets the W3C Trace Context Level 2 traceresponse header
+// on responses, exposing the server span to the caller.
+func WithTraceResponse() MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.traceResponse = true
+	}
+}
+
+// WithServerTiming sets the Server-Timing header on responses with the total
+// duration, the phases added with AddServerTiming and the trace parent.
+func WithServerTiming() MiddlewareOption {
+	return func(c *middlewareConfig) {
+		c.serverTiming = true
+	}
+}
+
+// writesResponseHeaders reports whether any response header option is enabled.
+func (c middlewareConfig) writesResponseHeaders() bool {
+	return c.traceResponse || c.serverTiming
+}
+
+// setResponseHeaders sets the enabled response headers. timing is nil unless
+// WithServerTiming is set.
+func (c middlewareConfig) setResponseHeaders(h http.Header, sc trace.SpanContext, start time.Time, timing *serverTiming) {
+	if c.traceResponse && sc.IsValid() {
+		h.Set(HeaderTraceResponse, traceParent(sc))
+	}
+	if timing != nil {
+		h.Set(HeaderServerTiming, timing.header(time.Since(start), sc))
+	}
+}
+
+// headerAttributes returns captured header values keyed by prefix and the
+// lower-cased header name. Absent headers are skipped.
+func headerAttributes(prefix string, headers http.Header, names []string) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for _, name := range names {
+		if values := headers.Values(name); len(values) > 0 {
+			attrs = append(attrs, attribute.StringSlice(prefix+strings.ToLower(name), values))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newRecordingTracer returns a sampling tracer and a recorder of its ended spans.
+func newRecordingTracer(t *testing.T) (*Tracer, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	ctx := context.Background()
+	cfg := testConfig("test-service")
+	cfg.SampleRate = 1.0
+
+	tracer, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { tracer.Shutdown(ctx) })
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer.Provider().RegisterSpanProcessor(recorder)
+	return tracer, recorder
+}
+
+func TestSkipPaths(t *testing.T) {
+	t.Parallel()
+
+	filter := SkipPaths("/metrics", "/health/live")
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/metrics", false},
+		{"/health/live", false},
+		{"/api/v1/rides", true},
+		{"/metrics/extra", true},
+	}
+	for _, tt := range tests {
+		if got := filter(httptest.NewRequest(http.MethodGet, tt.path, nil)); got != tt.want {
+			t.Errorf("filter(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultRequestFilter(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range DefaultSkipPaths {
+		if DefaultRequestFilter(httptest.NewRequest(http.MethodGet, path, nil)) {
+			t.Errorf("DefaultRequestFilter(%s) = true, want false", path)
+		}
+	}
+	if !DefaultRequestFilter(httptest.NewRequest(http.MethodGet, "/api/v1/rides", nil)) {
+		t.Error("DefaultRequestFilter(/api/v1/rides) = false, want true")
+	}
+}
+
+func TestMiddleware_Filter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      []MiddlewareOption
+		path      string
+		wantSpans int
+	}{
+		{"default skips health", nil, "/health/live", 0},
+		{"default traces api", nil, "/api/v1/rides", 1},
+		{"custom filter", []MiddlewareOption{WithFilter(SkipPaths("/metrics"))}, "/metrics", 0},
+		{"custom filter replaces default", []MiddlewareOption{WithFilter(SkipPaths("/metrics"))}, "/health/live", 1},
+		{"nil filter traces everything", []MiddlewareOption{WithFilter(nil)}, "/health/ready", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracer, recorder := newRecordingTracer(t)
+
+			called := false
+			handler := Middleware(tracer, tt.opts...)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				called = true
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if !called {
+				t.Error("handler was not called")
+			}
+			if got := len(recorder.Ended()); got != tt.wantSpans {
+				t.Errorf("spans = %d, want %d", got, tt.wantSpans)
+			}
+		})
+	}
+}
+
+func TestMiddleware_SpanNameHeadersAndHook(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+
+	handler := Middleware(tracer,
+		WithSpanNameFormatter(func(r *http.Request) string { return "rides.create" }),
+		WithRequestHeaders("X-App-Version", "X-Missing"),
+		WithResponseHeaders("Content-Type"),
+		WithAttributeHook(func(r *http.Request) []attribute.KeyValue {
+			return []attribute.KeyValue{City(r.URL.Query().Get("city"))}
+		}),
+	)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/rides?city=maputo", nil)
+	req.Header.Add("X-App-Version", "3.2.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("spans = %d, want 1", len(spans))
+	}
+	if spans[0].Name() != "rides.create" {
+		t.Errorf("span name = %q, want rides.create", spans[0].Name())
+	}
+
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	if v, _ := attrs.Value("http.request.header.x-app-version"); v.Emit() != `["3.2.1"]` {
+		t.Errorf("request header attribute = %s", v.Emit())
+	}
+	if attrs.HasValue("http.request.header.x-missing") {
+		t.Error("absent headers should not be recorded")
+	}
+	if v, _ := attrs.Value("http.response.header.content-type"); v.Emit() != `["application/json"]` {
+		t.Errorf("response header attribute = %s", v.Emit())
+	}
+	if v, _ := attrs.Value(AttrCity); v.AsString() != "maputo" {
+		t.Errorf("city = %q, want maputo", v.AsString())
+	}
+}
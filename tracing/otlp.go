@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OTLPOptionFuncs holds the option constructors of an OTLP exporter package
+// (otlptracehttp, otlploggrpc, otlpmetrichttp, ...), so the trace, log and
+// metric exporters apply the shared connection settings the same way.
+type OTLPOptionFuncs[O any] struct {
+	Endpoint func(endpoint string) O
+	Insecure func() O
+	Headers  func(headers map[string]string) O
+	TLS      func(cfg *tls.Config) O
+	Gzip     func() O
+	Timeout  func(timeout time.Duration) O
+
+	// Retry receives the retry policy with SDK defaults applied.
+	Retry func(retry RetryConfig) O
+}
+
+// OTLPOptions returns the exporter options for the endpoint, insecure, headers,
+// TLS, compression, timeout and retry settings of cfg. Settings left at their
+// zero value are not set, keeping the exporter defaults.
+func OTLPOptions[O any](cfg *Config, funcs OTLPOptionFuncs[O]) ([]O, error) {
+	opts := []O{funcs.Endpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, funcs.Insecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, funcs.Headers(cfg.Headers))
+	}
+	if cfg.TLS.IsSet() {
+		tlsCfg, err := cfg.TLS.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, funcs.TLS(tlsCfg))
+	}
+	if cfg.Compression == CompressionGzip {
+		opts = append(opts, funcs.Gzip())
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, funcs.Timeout(cfg.Timeout))
+	}
+	if cfg.Retry.IsSet() {
+		opts = append(opts, funcs.Retry(cfg.Retry.WithDefaults()))
+	}
+	return opts, nil
+}
+
+// ErrNoExporter is returned by NewOTLPExporter for ExporterNone.
+var ErrNoExporter = errors.New("no exporter configured")
+
+// OTLPExporterFuncs holds the constructors of a signal's OTLP HTTP and gRPC
+// exporters.
+type OTLPExporterFuncs[E any] struct {
+	HTTP func(ctx context.Context) (E, error)
+	GRPC func(ctx context.Context) (E, error)
+}
+
+// NewOTLPExporter creates the exporter for the exporter type with funcs. It
+// returns ErrNoExporter for "none" and an error for any other type, so
+// signals with additional exporters handle those before calling it.
+func NewOTLPExporter[T ~string, E any](ctx context.Context, exporter T, funcs OTLPExporterFuncs[E]) (E, error) {
+	var none E
+	switch ExporterType(exporter) {
+	case ExporterOTLPHTTP:
+		return funcs.HTTP(ctx)
+	case ExporterOTLPGRPC:
+		return funcs.GRPC(ctx)
+	case ExporterNone:
+		return none, ErrNoExporter
+	default:
+		return none, fmt.Errorf("unsupported exporter type: %s", exporter)
+	}
+}
+
+// SignalExporter returns the exporter type another signal uses when it is
+// not configured: the OTLP protocol of cfg, or none when cfg exports nothing.
+func SignalExporter[T ~string](cfg *Config) T {
+	switch cfg.Exporter {
+	case ExporterOTLPGRPC, ExporterNone:
+		return T(cfg.Exporter)
+	default:
+		return T(ExporterOTLPHTTP)
+	}
+}
+
+// SignalURLPath returns the OTLP HTTP path of another signal, such as
+// "/v1/metrics", under the same prefix as the traces path of cfg. A collector
+// served at "/otlp/v1/traces" thus receives metrics at "/otlp/v1/metrics".
+// A custom traces path not ending in "/v1/traces" leaves path unchanged.
+func SignalURLPath(cfg *Config, path string) string {
+	if prefix, ok := strings.CutSuffix(cfg.URLPath, defaultTracesURLPath); ok {
+		return prefix + path
+	}
+	return path
+}
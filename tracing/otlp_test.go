@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"crypto/tls"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// recordingOptionFuncs returns option constructors describing each option as a string.
+func recordingOptionFuncs() OTLPOptionFuncs[string] {
+	return OTLPOptionFuncs[string]{
+		Endpoint: func(endpoint string) string { return "endpoint=" + endpoint },
+		Insecure: func() string { return "insecure" },
+		Headers:  func(headers map[string]string) string { return fmt.Sprintf("headers=%d", len(headers)) },
+		TLS:      func(*tls.Config) string { return "tls" },
+		Gzip:     func() string { return "gzip" },
+		Timeout:  func(timeout time.Duration) string { return "timeout=" + timeout.String() },
+		Retry: func(retry RetryConfig) string {
+			return fmt.Sprintf("retry=%t/%s/%s/%s", !retry.Disabled, retry.InitialInterval, retry.MaxInterval, retry.MaxElapsedTime)
+		},
+	}
+}
+
+func TestOTLPOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want []string
+	}{
+		{
+			name: "defaults",
+			cfg:  Config{Endpoint: "collector:4318"},
+			want: []string{"endpoint=collector:4318"},
+		},
+		{
+			name: "all settings",
+			cfg: Config{
+				Endpoint:    "collector:4317",
+				Insecure:    true,
+				Headers:     map[string]string{"api-key": "secret"},
+				TLS:         TLSConfig{ServerName: "collector"},
+				Compression: CompressionGzip,
+				Timeout:     5 * time.Second,
+				Retry:       RetryConfig{InitialInterval: time.Second},
+			},
+			want: []string{"endpoint=collector:4317", "insecure", "headers=1", "tls", "gzip", "timeout=5s", "retry=true/1s/30s/1m0s"},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := OTLPOptions(&tt.cfg, recordingOptionFuncs())
+		if err != nil {
+			t.Fatalf("%s: OTLPOptions() error = %v", tt.name, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: OTLPOptions() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOTLPOptions_TLSError(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{Endpoint: "collector:4317", TLS: TLSConfig{CAFile: "/nonexistent/ca.pem"}}
+	if _, err := OTLPOptions(&cfg, recordingOptionFuncs()); err == nil {
+		t.Error("OTLPOptions() should fail for an unreadable CA file")
+	}
+}
+
+func TestSignalURLPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tracesPath string
+		want       string
+	}{
+		{"", "/v1/metrics"},
+		{"/v1/traces", "/v1/metrics"},
+		{"/otlp/v1/traces", "/otlp/v1/metrics"},
+		{"/custom/traces", "/v1/metrics"},
+	}
+
+	for _, tt := range tests {
+		cfg := Config{URLPath: tt.tracesPath}
+		if got := SignalURLPath(&cfg, "/v1/metrics"); got != tt.want {
+			t.Errorf("SignalURLPath(%q) = %q, want %q", tt.tracesPath, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// BaggageSpanProcessor copies selected baggage members onto every span when it
+// starts, so values such as city or service.type set once at the edge appear
+// on all child spans without handlers copying them.
+type BaggageSpanProcessor struct {
+	keys []string
+}
+
+// NewBaggageSpanProcessor creates a span processor that records the baggage
+// members with the given keys as span attributes of the same name.
+func NewBaggageSpanProcessor(keys ...string) *BaggageSpanProcessor {
+	return &BaggageSpanProcessor{keys: keys}
+}
+
+// OnStart copies the configured baggage members from the parent context.
+// Attributes already set on the span are not overwritten.
+func (p *BaggageSpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	bag := baggage.FromContext(parent)
+	if bag.Len() == 0 {
+		return
+	}
+
+	existing := attribute.NewSet(s.Attributes()...)
+	for _, key := range p.keys {
+		value := bag.Member(key).Value()
+		if value == "" || existing.HasValue(attribute.Key(key)) {
+			continue
+		}
+		s.SetAttributes(attribute.String(key, value))
+	}
+}
+
+// OnEnd implements sdktrace.SpanProcessor.
+func (p *BaggageSpanProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+// Shutdown implements sdktrace.SpanProcessor.
+func (p *BaggageSpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush implements sdktrace.SpanProcessor.
+func (p *BaggageSpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
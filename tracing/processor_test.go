@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// contextWithBaggage returns a context carrying the given baggage members.
+func contextWithBaggage(t *testing.T, members map[string]string) context.Context {
+	t.Helper()
+
+	var list []baggage.Member
+	for k, v := range members {
+		m, err := baggage.NewMemberRaw(k, v)
+		if err != nil {
+			t.Fatalf("NewMemberRaw() error = %v", err)
+		}
+		list = append(list, m)
+	}
+	bag, err := baggage.New(list...)
+	if err != nil {
+		t.Fatalf("baggage.New() error = %v", err)
+	}
+	return baggage.ContextWithBaggage(context.Background(), bag)
+}
+
+func TestBaggageSpanProcessor(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewBaggageSpanProcessor(AttrCity, AttrServiceType)),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	defer provider.Shutdown(context.Background())
+
+	ctx := contextWithBaggage(t, map[string]string{
+		AttrCity:        "maputo",
+		AttrServiceType: "standard",
+		"device.id":     "device-1",
+	})
+
+	tracer := provider.Tracer("test")
+	ctx, parent := tracer.Start(ctx, "parent")
+	_, child := tracer.Start(ctx, "db.query", trace.WithAttributes(ServiceType("premium")))
+	child.End()
+	parent.End()
+
+	for _, span := range recorder.Ended() {
+		attrs := attribute.NewSet(span.Attributes()...)
+
+		if v, _ := attrs.Value(AttrCity); v.AsString() != "maputo" {
+			t.Errorf("%s: city = %q, want maputo", span.Name(), v.AsString())
+		}
+		if attrs.HasValue("device.id") {
+			t.Errorf("%s: device.id should not be copied", span.Name())
+		}
+
+		wantServiceType := "standard"
+		if span.Name() == "db.query" {
+			// Explicit attributes take precedence over baggage.
+			wantServiceType = "premium"
+		}
+		if v, _ := attrs.Value(AttrServiceType); v.AsString() != wantServiceType {
+			t.Errorf("%s: service.type = %q, want %q", span.Name(), v.AsString(), wantServiceType)
+		}
+	}
+}
+
+func TestBaggageSpanProcessor_NoBaggage(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewBaggageSpanProcessor(AttrCity)),
+		sdktrace.WithSpanProcessor(recorder),
+	)
+	defer provider.Shutdown(context.Background())
+
+	_, span := provider.Tracer("test").Start(context.Background(), "op")
+	span.End()
+
+	if attrs := recorder.Ended()[0].Attributes(); len(attrs) != 0 {
+		t.Errorf("Attributes() = %v, want none", attrs)
+	}
+}
+
+func TestNew_BaggageSpanAttributes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := testConfig("test-service")
+	cfg.SampleRate = 1.0
+	cfg.WithBaggageSpanAttributes(AttrCity)
+
+	tracer, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer.Provider().RegisterSpanProcessor(recorder)
+
+	_, span := tracer.Start(contextWithBaggage(t, map[string]string{AttrCity: "beira"}), "kafka.publish")
+	span.End()
+
+	attrs := attribute.NewSet(recorder.Ended()[0].Attributes()...)
+	if v, _ := attrs.Value(AttrCity); v.AsString() != "beira" {
+		t.Errorf("city = %q, want beira", v.AsString())
+	}
+}
+
+func TestConfig_Validate_BaggageSpanAttributes(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.WithServiceName("test-service").WithBaggageSpanAttributes(AttrCity, "")
+
+	if err := cfg.Validate(); err == nil || !contains(err.Error(), "baggage span attribute") {
+		t.Errorf("Validate() error = %v, want empty key error", err)
+	}
+}
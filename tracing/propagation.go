@@ -16,6 +16,9 @@ const (
 	// HeaderTraceState is the W3C trace state header.
 	HeaderTraceState = "tracestate"
 
+	// HeaderBaggage is the W3C baggage header.
+	HeaderBaggage = "baggage"
+
 	// HeaderRequestID is the correlation ID header.
 	HeaderRequestID = "X-Request-ID"
 )
@@ -64,28 +67,90 @@ func (c KafkaCarrier) Keys() []string {
 	return keys
 }
 
-// Extract extracts trace context from HTTP headers.
+// Extract extracts trace context from HTTP headers using the global
+// propagator. Use Tracer.Extract when DisableGlobalPropagator is set.
 func Extract(ctx context.Context, headers http.Header) context.Context {
-	return otel.GetTextMapPropagator().Extract(ctx, HTTPCarrier(headers))
+	return extract(ctx, otel.GetTextMapPropagator(), headers)
 }
 
-// Inject injects trace context into HTTP headers.
+// Inject injects trace context into HTTP headers using the global
+// propagator. Use Tracer.Inject when DisableGlobalPropagator is set.
 func Inject(ctx context.Context, headers http.Header) {
-	otel.GetTextMapPropagator().Inject(ctx, HTTPCarrier(headers))
+	inject(ctx, otel.GetTextMapPropagator(), headers)
 }
 
-// ExtractFromKafka extracts trace context from Kafka headers.
+// ExtractFromKafka extracts trace context and the request ID from Kafka
+// headers using the global propagator. An invalid request ID is replaced
+// with a NewRequestID. Use Tracer.ExtractFromKafka when
+// DisableGlobalPropagator is set.
 func ExtractFromKafka(ctx context.Context, headers map[string]string) context.Context {
-	return otel.GetTextMapPropagator().Extract(ctx, KafkaCarrier(headers))
+	return extractFromKafka(ctx, otel.GetTextMapPropagator(), headers, NewRequestID)
 }
 
-// InjectToKafka injects trace context into Kafka headers.
-// If headers is nil, returns early to prevent panic.
+// InjectToKafka injects trace context and the context's request ID into Kafka
+// headers using the global propagator. Use Tracer.InjectToKafka when
+// DisableGlobalPropagator is set. If headers is nil, returns early to prevent panic.
 func InjectToKafka(ctx context.Context, headers map[string]string) {
+	injectToKafka(ctx, otel.GetTextMapPropagator(), headers)
+}
+
+// Extract extracts trace context from HTTP headers using the tracer's propagator.
+func (t *Tracer) Extract(ctx context.Context, headers http.Header) context.Context {
+	return extract(ctx, t.propagator, headers)
+}
+
+// Inject injects trace context into HTTP headers using the tracer's propagator.
+func (t *Tracer) Inject(ctx context.Context, headers http.Header) {
+	inject(ctx, t.propagator, headers)
+}
+
+// ExtractFromKafka extracts trace context and the request ID from Kafka
+// headers using the tracer's propagator. An invalid request ID is replaced
+// with one from the configured RequestIDGenerator.
+func (t *Tracer) ExtractFromKafka(ctx context.Context, headers map[string]string) context.Context {
+	return extractFromKafka(ctx, t.propagator, headers, t.config.RequestIDGenerator)
+}
+
+// InjectToKafka injects trace context and the context's request ID into Kafka
+// headers using the tracer's propagator. If headers is nil, returns early.
+func (t *Tracer) InjectToKafka(ctx context.Context, headers map[string]string) {
+	injectToKafka(ctx, t.propagator, headers)
+}
+
+// extract extracts trace context from HTTP headers with propagator.
+func extract(ctx context.Context, propagator propagation.TextMapPropagator, headers http.Header) context.Context {
+	return propagator.Extract(ctx, HTTPCarrier(headers))
+}
+
+// inject injects trace context into HTTP headers with propagator.
+func inject(ctx context.Context, propagator propagation.TextMapPropagator, headers http.Header) {
+	propagator.Inject(ctx, HTTPCarrier(headers))
+}
+
+// extractFromKafka extracts trace context and the request ID from Kafka
+// headers with propagator. A malformed or oversized request ID is replaced
+// with one created by generate.
+func extractFromKafka(ctx context.Context, propagator propagation.TextMapPropagator, headers map[string]string, generate RequestIDGenerator) context.Context {
+	ctx = propagator.Extract(ctx, KafkaCarrier(headers))
+	if requestID, ok := headers[HeaderRequestID]; ok {
+		if !validRequestID(requestID) {
+			requestID = generate(ctx)
+		}
+		ctx = ContextWithRequestID(ctx, requestID)
+	}
+	return ctx
+}
+
+// injectToKafka injects trace context and the request ID into Kafka headers
+// with propagator.
+func injectToKafka(ctx context.Context, propagator propagation.TextMapPropagator, headers map[string]string) {
 	if headers == nil {
 		return
 	}
-	otel.GetTextMapPropagator().Inject(ctx, KafkaCarrier(headers))
+	propagator.Inject(ctx, KafkaCarrier(headers))
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		headers[HeaderRequestID] = requestID
+	}
 }
 
 // ExtractRequestID extracts the X-Request-ID from HTTP headers.
@@ -111,24 +176,41 @@ func InjectBaggage(ctx context.Context, b baggage.Baggage) context.Context {
 	return baggage.ContextWithBaggage(ctx, b)
 }
 
-// SpanContextFromContext returns the span context from the given context.
+// SpanContextFromContext returns the W3C span context, baggage and request ID
+// from the given context, regardless of the configured propagation formats.
+// Store it with queued work and resume with Tracer.StartLinked.
 func SpanContextFromContext(ctx context.Context) SpanContext {
-	prop := otel.GetTextMapPropagator()
-
 	// Create a carrier to hold the extracted values.
 	carrier := make(propagation.MapCarrier)
-	prop.Inject(ctx, carrier)
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	propagation.Baggage{}.Inject(ctx, carrier)
 
 	return SpanContext{
 		TraceParent: carrier.Get(HeaderTraceParent),
 		TraceState:  carrier.Get(HeaderTraceState),
+		Baggage:     carrier.Get(HeaderBaggage),
+		RequestID:   RequestIDFromContext(ctx),
 	}
 }
 
-// SpanContext holds the W3C trace context values.
+// SpanContext holds the W3C trace context values, baggage and request ID
+// in serializable form.
 type SpanContext struct {
 	TraceParent string
 	TraceState  string
+	Baggage     string
+	RequestID   string
+}
+
+// extract returns ctx with the remote span context and baggage of sc.
+func (sc SpanContext) extract(ctx context.Context) context.Context {
+	carrier := propagation.MapCarrier{
+		HeaderTraceParent: sc.TraceParent,
+		HeaderTraceState:  sc.TraceState,
+		HeaderBaggage:     sc.Baggage,
+	}
+	ctx = propagation.TraceContext{}.Extract(ctx, carrier)
+	return propagation.Baggage{}.Extract(ctx, carrier)
 }
 
 // IsValid returns true if the span context has a valid trace parent.
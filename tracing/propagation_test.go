@@ -7,6 +7,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestHTTPCarrier_Get(t *testing.T) {
@@ -205,6 +206,42 @@ func TestInjectToKafka(t *testing.T) {
 	}
 }
 
+func TestTracer_KafkaPropagation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := testConfig("test-service")
+	cfg.Propagation = []PropagationType{PropagationB3Single}
+	cfg.DisableGlobalPropagator = true
+
+	tracer, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	spanCtx, span := tracer.Start(ctx, "produce")
+	defer span.End()
+
+	headers := make(map[string]string)
+	tracer.InjectToKafka(spanCtx, headers)
+	if headers["b3"] == "" {
+		t.Fatalf("InjectToKafka() headers = %v, want b3 header", headers)
+	}
+
+	got := trace.SpanContextFromContext(tracer.ExtractFromKafka(ctx, headers))
+	if got.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("ExtractFromKafka() trace ID = %v, want %v", got.TraceID(), span.SpanContext().TraceID())
+	}
+
+	httpHeaders := http.Header{}
+	tracer.Inject(spanCtx, httpHeaders)
+	got = trace.SpanContextFromContext(tracer.Extract(ctx, httpHeaders))
+	if got.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("Extract() trace ID = %v, want %v", got.TraceID(), span.SpanContext().TraceID())
+	}
+}
+
 func TestExtractRequestID(t *testing.T) {
 	t.Parallel()
 
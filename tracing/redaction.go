@@ -0,0 +1,353 @@
+package tracing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Redaction rule names reported to MetricsRecorder.RecordRedaction.
+// Pattern rules are reported under their RedactionPattern.Name.
+const (
+	RedactionRuleDenyKey    = "deny_key"
+	RedactionRuleHash       = "hash"
+	RedactionRuleCoordinate = "coordinate"
+)
+
+// DefaultRedactionReplacement replaces denied values and pattern matches.
+const DefaultRedactionReplacement = "[REDACTED]"
+
+// DefaultCoordinatePrecision is the number of decimal places coordinates are
+// rounded to (about 1.1 km) when RedactionConfig.CoordinatePrecision is zero.
+const DefaultCoordinatePrecision = 2
+
+// CoordinatePrecisionDegrees rounds coordinates to whole degrees (about
+// 111 km). Zero cannot be used for this, as it selects the default.
+const CoordinatePrecisionDegrees = -1
+
+var (
+	// PhonePatternMZ matches Mozambican mobile numbers (82-87 prefixes), with
+	// or without the +258 country code, e.g. "+258 84 123 4567" or "841234567".
+	// Numbers must start at a word boundary, so the trailing digits of longer
+	// numeric IDs are not matched.
+	PhonePatternMZ = regexp.MustCompile(`(?:^|\b|\+)(?:258[\s-]?)?8[2-7][\s-]?\d{3}[\s-]?\d{4}\b`)
+
+	// EmailPattern matches email addresses.
+	EmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+	// decimalPattern matches decimal numbers in coordinate strings.
+	decimalPattern = regexp.MustCompile(`-?\d+\.\d+`)
+)
+
+// MetricsRecorder receives self-metrics from the tracing pipeline.
+// *metrics.TracingCollector implements it.
+type MetricsRecorder interface {
+	// RecordRedaction records a value redacted by the named rule.
+	RecordRedaction(rule string)
+
+	// RecordError records an error passed to RecordError, by error.type.
+	RecordError(errorType string, expected bool)
+}
+
+// RedactionPattern replaces matches of Pattern in string values.
+type RedactionPattern struct {
+	// Name identifies the rule in the redaction metric (e.g., "phone").
+	Name string
+
+	// Pattern is the expression whose matches are replaced.
+	Pattern *regexp.Regexp
+}
+
+// RedactionConfig holds the rules applied to span attributes, event and link
+// attributes and status descriptions before export.
+type RedactionConfig struct {
+	// DenyKeys lists attribute keys whose values are replaced entirely.
+	DenyKeys []string
+
+	// Patterns are applied to every other string value, including error messages.
+	Patterns []RedactionPattern
+
+	// CoordinateKeys lists attribute keys holding coordinates that are rounded
+	// to CoordinatePrecision decimal places.
+	CoordinateKeys []string
+
+	// CoordinatePrecision is the number of decimal places kept for coordinates.
+	// Zero uses DefaultCoordinatePrecision; use CoordinatePrecisionDegrees to
+	// keep no decimal places.
+	CoordinatePrecision int
+
+	// HashKeys lists attribute keys whose values are replaced by a keyed
+	// HMAC-SHA256 hash, keeping them correlatable without exposing the ID.
+	HashKeys []string
+
+	// HashKey is the secret for HashKeys. Required when HashKeys is set.
+	HashKey []byte
+
+	// Replacement replaces denied values and pattern matches.
+	// Empty uses DefaultRedactionReplacement.
+	Replacement string
+}
+
+// DefaultRedactionConfig returns rules for the PII common in Txova spans:
+// phone numbers and emails, exact GPS coordinates and user and rider IDs,
+// which are hashed with hashKey.
+func DefaultRedactionConfig(hashKey []byte) RedactionConfig {
+	return RedactionConfig{
+		Patterns: []RedactionPattern{
+			{Name: "phone", Pattern: PhonePatternMZ},
+			{Name: "email", Pattern: EmailPattern},
+		},
+		CoordinateKeys: []string{string(semconv.GeoLocationLatKey), string(semconv.GeoLocationLonKey)},
+		HashKeys:       []string{AttrUserID, AttrRiderID},
+		HashKey:        hashKey,
+	}
+}
+
+// IsSet returns true if any redaction rule is configured.
+func (r RedactionConfig) IsSet() bool {
+	return len(r.DenyKeys) > 0 || len(r.Patterns) > 0 || len(r.CoordinateKeys) > 0 || len(r.HashKeys) > 0
+}
+
+// validate checks the redaction rules.
+func (r RedactionConfig) validate() error {
+	for _, p := range r.Patterns {
+		if p.Name == "" || p.Pattern == nil {
+			return fmt.Errorf("redaction patterns require a name and a pattern")
+		}
+	}
+	if r.CoordinatePrecision < CoordinatePrecisionDegrees {
+		return fmt.Errorf("coordinate precision must be non-negative or CoordinatePrecisionDegrees, got %d", r.CoordinatePrecision)
+	}
+	if len(r.HashKeys) > 0 && len(r.HashKey) == 0 {
+		return fmt.Errorf("hash key is required when hash keys are configured")
+	}
+	return nil
+}
+
+// redactor applies compiled redaction rules.
+type redactor struct {
+	deny        map[string]bool
+	hash        map[string]bool
+	coordinates map[string]bool
+	patterns    []RedactionPattern
+	hashKey     []byte
+	replacement string
+	scale       float64
+	recorder    MetricsRecorder
+}
+
+// newRedactor compiles the redaction rules. recorder may be nil.
+func newRedactor(cfg RedactionConfig, recorder MetricsRecorder) *redactor { //nolint:gocritic // cfg passed by value for API simplicity
+	precision := cfg.CoordinatePrecision
+	switch precision {
+	case 0:
+		precision = DefaultCoordinatePrecision
+	case CoordinatePrecisionDegrees:
+		precision = 0
+	}
+	replacement := cfg.Replacement
+	if replacement == "" {
+		replacement = DefaultRedactionReplacement
+	}
+	return &redactor{
+		deny:        keySet(cfg.DenyKeys),
+		hash:        keySet(cfg.HashKeys),
+		coordinates: keySet(cfg.CoordinateKeys),
+		patterns:    cfg.Patterns,
+		hashKey:     cfg.HashKey,
+		replacement: replacement,
+		scale:       math.Pow10(precision),
+		recorder:    recorder,
+	}
+}
+
+// keySet converts a list of keys to a lookup set.
+func keySet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
+
+// record reports a redaction to the recorder, if any.
+func (r *redactor) record(rule string) {
+	if r.recorder != nil {
+		r.recorder.RecordRedaction(rule)
+	}
+}
+
+// attributes returns a redacted copy of attrs.
+func (r *redactor) attributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if len(attrs) == 0 {
+		return attrs
+	}
+	out := make([]attribute.KeyValue, len(attrs))
+	for i, kv := range attrs {
+		out[i] = r.attribute(kv)
+	}
+	return out
+}
+
+// attribute applies the first matching rule for the attribute key, or the
+// patterns to string values.
+func (r *redactor) attribute(kv attribute.KeyValue) attribute.KeyValue {
+	key := string(kv.Key)
+	switch {
+	case r.deny[key]:
+		r.record(RedactionRuleDenyKey)
+		return kv.Key.String(r.replacement)
+	case r.hash[key]:
+		r.record(RedactionRuleHash)
+		return kv.Key.String(r.hashValue(kv.Value.Emit()))
+	case r.coordinates[key]:
+		return r.coordinate(kv)
+	}
+
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		return kv.Key.String(r.text(kv.Value.AsString()))
+	case attribute.STRINGSLICE:
+		values := kv.Value.AsStringSlice()
+		for i, v := range values {
+			values[i] = r.text(v)
+		}
+		return kv.Key.StringSlice(values)
+	default:
+		return kv
+	}
+}
+
+// text replaces pattern matches in s.
+func (r *redactor) text(s string) string {
+	for _, p := range r.patterns {
+		s = p.Pattern.ReplaceAllStringFunc(s, func(string) string {
+			r.record(p.Name)
+			return r.replacement
+		})
+	}
+	return s
+}
+
+// hashValue returns the hex-encoded keyed hash of value.
+func (r *redactor) hashValue(value string) string {
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// coordinate rounds a float or string coordinate attribute.
+func (r *redactor) coordinate(kv attribute.KeyValue) attribute.KeyValue {
+	switch kv.Value.Type() {
+	case attribute.FLOAT64:
+		r.record(RedactionRuleCoordinate)
+		return kv.Key.Float64(r.round(kv.Value.AsFloat64()))
+	case attribute.STRING:
+		rounded := decimalPattern.ReplaceAllStringFunc(kv.Value.AsString(), func(m string) string {
+			v, err := strconv.ParseFloat(m, 64)
+			if err != nil {
+				return m
+			}
+			r.record(RedactionRuleCoordinate)
+			return strconv.FormatFloat(r.round(v), 'f', -1, 64)
+		})
+		return kv.Key.String(rounded)
+	default:
+		return kv
+	}
+}
+
+// round rounds v to the configured precision.
+func (r *redactor) round(v float64) float64 {
+	return math.Round(v*r.scale) / r.scale
+}
+
+// span returns a redacted view of s.
+func (r *redactor) span(s sdktrace.ReadOnlySpan) sdktrace.ReadOnlySpan {
+	events := s.Events()
+	redactedEvents := make([]sdktrace.Event, len(events))
+	for i, e := range events {
+		e.Name = r.text(e.Name)
+		e.Attributes = r.attributes(e.Attributes)
+		redactedEvents[i] = e
+	}
+
+	links := s.Links()
+	redactedLinks := make([]sdktrace.Link, len(links))
+	for i, l := range links {
+		l.Attributes = r.attributes(l.Attributes)
+		redactedLinks[i] = l
+	}
+
+	status := s.Status()
+	status.Description = r.text(status.Description)
+
+	return redactedSpan{
+		ReadOnlySpan: s,
+		name:         r.text(s.Name()),
+		attributes:   r.attributes(s.Attributes()),
+		events:       redactedEvents,
+		links:        redactedLinks,
+		status:       status,
+	}
+}
+
+// redactedSpan overrides the name, attributes, events, links and status of a span.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+	name       string
+	attributes []attribute.KeyValue
+	events     []sdktrace.Event
+	links      []sdktrace.Link
+	status     sdktrace.Status
+}
+
+// Name returns the redacted span name, which includes the URL path of
+// server spans by default.
+func (s redactedSpan) Name() string {
+	return s.name
+}
+
+// Attributes returns the redacted span attributes.
+func (s redactedSpan) Attributes() []attribute.KeyValue {
+	return s.attributes
+}
+
+// Events returns the span events with redacted names and attributes.
+func (s redactedSpan) Events() []sdktrace.Event {
+	return s.events
+}
+
+// Links returns the span links with redacted attributes.
+func (s redactedSpan) Links() []sdktrace.Link {
+	return s.links
+}
+
+// Status returns the span status with a redacted description.
+func (s redactedSpan) Status() sdktrace.Status {
+	return s.status
+}
+
+// redactingExporter redacts spans before passing them to the wrapped exporter.
+type redactingExporter struct {
+	sdktrace.SpanExporter
+	redactor *redactor
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e redactingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	redacted := make([]sdktrace.ReadOnlySpan, len(spans))
+	for i, s := range spans {
+		redacted[i] = e.redactor.span(s)
+	}
+	return e.SpanExporter.ExportSpans(ctx, redacted)
+}
@@ -0,0 +1,286 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// countingRecorder counts redactions per rule and errors per "error:<type>".
+type countingRecorder map[string]int
+
+func (r countingRecorder) RecordRedaction(rule string) {
+	r[rule]++
+}
+
+func (r countingRecorder) RecordError(errorType string, expected bool) {
+	key := "error:" + errorType
+	if expected {
+		key += ":expected"
+	}
+	r[key]++
+}
+
+// exportRedacted ends a span through a redacting exporter and returns the exported span.
+func exportRedacted(t *testing.T, cfg RedactionConfig, recorder MetricsRecorder, fn func(ctx context.Context)) tracetest.SpanStub {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(redactingExporter{SpanExporter: exporter, redactor: newRedactor(cfg, recorder)}),
+	)
+	defer provider.Shutdown(context.Background())
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "op")
+	fn(ctx)
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(spans))
+	}
+	return spans[0]
+}
+
+func TestPhonePatternMZ(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		match bool
+	}{
+		{"+258 84 123 4567", true},
+		{"258841234567", true},
+		{"841234567", true},
+		{"82-123-4567", true},
+		{"tel:+258841234567", true},
+		{"811234567", false},
+		{"order 12345", false},
+		{"order 9912841234567", false},
+		{"card 4111841234567", false},
+	}
+
+	for _, tt := range tests {
+		if got := PhonePatternMZ.MatchString(tt.input); got != tt.match {
+			t.Errorf("PhonePatternMZ.MatchString(%q) = %v, want %v", tt.input, got, tt.match)
+		}
+	}
+}
+
+func TestRedactingExporter(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultRedactionConfig([]byte("secret"))
+	cfg.DenyKeys = []string{"http.request.header.authorization"}
+	recorder := countingRecorder{}
+
+	stub := exportRedacted(t, cfg, recorder, func(ctx context.Context) {
+		AddSpanAttributes(ctx,
+			UserID("user-1"),
+			DBStatement("SELECT * FROM riders WHERE phone = '+258 84 123 4567'"),
+			HTTPUserAgent("app contact: rider@example.com"),
+			attribute.String("http.request.header.authorization", "Bearer token"),
+			semconv.GeoLocationLat(-25.969248),
+			attribute.String(string(semconv.GeoLocationLonKey), "32.573174"),
+			RideID("ride-1"),
+		)
+		RecordError(ctx, errors.New("failed to notify 841234567"))
+	})
+
+	attrs := attribute.NewSet(stub.Attributes...)
+	want := map[string]string{
+		AttrDBStatement:                     "SELECT * FROM riders WHERE phone = '[REDACTED]'",
+		AttrHTTPUserAgent:                   "app contact: [REDACTED]",
+		"http.request.header.authorization": "[REDACTED]",
+		string(semconv.GeoLocationLonKey):   "32.57",
+		AttrRideID:                          "ride-1",
+	}
+	for key, value := range want {
+		if v, _ := attrs.Value(attribute.Key(key)); v.Emit() != value {
+			t.Errorf("%s = %q, want %q", key, v.Emit(), value)
+		}
+	}
+
+	if v, _ := attrs.Value(semconv.GeoLocationLatKey); v.AsFloat64() != -25.97 {
+		t.Errorf("geo.location.lat = %v, want -25.97", v.AsFloat64())
+	}
+
+	userID, _ := attrs.Value(AttrUserID)
+	if userID.AsString() == "user-1" || len(userID.AsString()) != 64 {
+		t.Errorf("user.id = %q, want a hex HMAC", userID.AsString())
+	}
+	if other := newRedactor(cfg, nil).hashValue("user-1"); other != userID.AsString() {
+		t.Error("hashing should be deterministic for the same key")
+	}
+
+	if stub.Status.Description != "failed to notify [REDACTED]" {
+		t.Errorf("status description = %q", stub.Status.Description)
+	}
+	for _, e := range stub.Events {
+		for _, kv := range e.Attributes {
+			if kv.Value.Type() == attribute.STRING && PhonePatternMZ.MatchString(kv.Value.AsString()) {
+				t.Errorf("event attribute %s still contains a phone number", kv.Key)
+			}
+		}
+	}
+
+	wantCounts := map[string]int{
+		"phone":                 3, // statement, status and exception.message event
+		"email":                 1,
+		RedactionRuleDenyKey:    1,
+		RedactionRuleHash:       1,
+		RedactionRuleCoordinate: 2,
+	}
+	for rule, count := range wantCounts {
+		if recorder[rule] != count {
+			t.Errorf("redactions[%s] = %d, want %d", rule, recorder[rule], count)
+		}
+	}
+}
+
+func TestRedactingExporter_CustomReplacementAndPrecision(t *testing.T) {
+	t.Parallel()
+
+	cfg := RedactionConfig{
+		Patterns:            []RedactionPattern{{Name: "plate", Pattern: regexp.MustCompile(`A[A-Z]{2}-\d{3}-MC`)}},
+		CoordinateKeys:      []string{"pickup.lat"},
+		CoordinatePrecision: 1,
+		Replacement:         "***",
+	}
+
+	stub := exportRedacted(t, cfg, nil, func(ctx context.Context) {
+		AddSpanAttributes(ctx,
+			attribute.StringSlice("vehicle.plates", []string{"AAB-123-MC", "none"}),
+			attribute.Float64("pickup.lat", -25.969248),
+		)
+	})
+
+	attrs := attribute.NewSet(stub.Attributes...)
+	if v, _ := attrs.Value("vehicle.plates"); v.Emit() != `["***","none"]` {
+		t.Errorf("vehicle.plates = %s", v.Emit())
+	}
+	if v, _ := attrs.Value("pickup.lat"); v.AsFloat64() != -26.0 {
+		t.Errorf("pickup.lat = %v, want -26", v.AsFloat64())
+	}
+}
+
+func TestRedactingExporter_WholeDegrees(t *testing.T) {
+	t.Parallel()
+
+	cfg := RedactionConfig{
+		CoordinateKeys:      []string{"pickup.lat"},
+		CoordinatePrecision: CoordinatePrecisionDegrees,
+	}
+
+	stub := exportRedacted(t, cfg, nil, func(ctx context.Context) {
+		AddSpanAttributes(ctx, attribute.Float64("pickup.lat", -25.969248))
+	})
+
+	attrs := attribute.NewSet(stub.Attributes...)
+	if v, _ := attrs.Value("pickup.lat"); v.AsFloat64() != -26 {
+		t.Errorf("pickup.lat = %v, want -26", v.AsFloat64())
+	}
+}
+
+func TestRedactingExporter_Links(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultRedactionConfig([]byte("secret"))
+
+	stub := exportRedacted(t, cfg, nil, func(ctx context.Context) {
+		span := trace.SpanFromContext(ctx)
+		span.AddLink(trace.Link{
+			SpanContext: span.SpanContext(),
+			Attributes:  []attribute.KeyValue{attribute.String("notify.to", "841234567"), UserID("user-1")},
+		})
+	})
+
+	if len(stub.Links) != 1 {
+		t.Fatalf("exported %d links, want 1", len(stub.Links))
+	}
+	attrs := attribute.NewSet(stub.Links[0].Attributes...)
+	if v, _ := attrs.Value("notify.to"); v.AsString() != DefaultRedactionReplacement {
+		t.Errorf("link notify.to = %q, want %q", v.AsString(), DefaultRedactionReplacement)
+	}
+	if v, _ := attrs.Value(AttrUserID); v.AsString() == "user-1" {
+		t.Error("link user.id was not hashed")
+	}
+}
+
+func TestRedactingExporter_Names(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultRedactionConfig([]byte("secret"))
+
+	stub := exportRedacted(t, cfg, nil, func(ctx context.Context) {
+		span := trace.SpanFromContext(ctx)
+		span.SetName("GET /riders/+258841234567")
+		span.AddEvent("sms sent to 841234567")
+	})
+
+	if want := "GET /riders/" + DefaultRedactionReplacement; stub.Name != want {
+		t.Errorf("span name = %q, want %q", stub.Name, want)
+	}
+	if len(stub.Events) != 1 {
+		t.Fatalf("exported %d events, want 1", len(stub.Events))
+	}
+	if want := "sms sent to " + DefaultRedactionReplacement; stub.Events[0].Name != want {
+		t.Errorf("event name = %q, want %q", stub.Events[0].Name, want)
+	}
+}
+
+func TestRedactionConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     RedactionConfig
+		wantErr string
+	}{
+		{"default", DefaultRedactionConfig([]byte("secret")), ""},
+		{"empty", RedactionConfig{}, ""},
+		{"hash without key", DefaultRedactionConfig(nil), "hash key is required"},
+		{"unnamed pattern", RedactionConfig{Patterns: []RedactionPattern{{Pattern: EmailPattern}}}, "require a name"},
+		{"nil pattern", RedactionConfig{Patterns: []RedactionPattern{{Name: "email"}}}, "require a name"},
+		{"whole degrees", RedactionConfig{CoordinatePrecision: CoordinatePrecisionDegrees}, ""},
+		{"negative precision", RedactionConfig{CoordinatePrecision: -2}, "coordinate precision"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := DefaultConfig()
+			cfg.WithServiceName("test-service").WithRedaction(tt.cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedactionConfig_IsSet(t *testing.T) {
+	t.Parallel()
+
+	if (RedactionConfig{}).IsSet() {
+		t.Error("empty config should not be set")
+	}
+	if !(RedactionConfig{DenyKeys: []string{"k"}}).IsSet() {
+		t.Error("config with deny keys should be set")
+	}
+}
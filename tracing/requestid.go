@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxRequestIDLength bounds incoming request IDs accepted from clients.
+// Longer or malformed values are replaced with a generated ID.
+const maxRequestIDLength = 128
+
+// requestIDKey is the context key for the request ID.
+type requestIDKey struct{}
+
+// RequestIDGenerator creates a request ID for a request that did not carry one.
+// The context contains the request's span when called from Middleware.
+type RequestIDGenerator func(ctx context.Context) string
+
+// NewRequestID returns a new UUIDv7 request ID. UUIDv7 values are time-ordered,
+// which keeps them sortable in logs.
+func NewRequestID(_ context.Context) string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return id.String()
+}
+
+// TraceRequestID returns the trace ID of the span in ctx as the request ID,
+// so the correlation ID can be used directly to look up the trace.
+// Falls back to NewRequestID when ctx has no valid span context.
+func TraceRequestID(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.TraceID().IsValid() {
+		return sc.TraceID().String()
+	}
+	return NewRequestID(ctx)
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestIDMiddleware returns an HTTP middleware that ensures every request has
+// a request ID. The incoming X-Request-ID is used when valid, otherwise one is
+// created with generate (NewRequestID if nil). The ID is stored in the request
+// context and echoed in the X-Request-ID response header.
+//
+// Middleware already does this; use RequestIDMiddleware for handlers that are
+// not traced, or to assign the ID before other middleware runs.
+func RequestIDMiddleware(generate RequestIDGenerator) func(http.Handler) http.Handler {
+	if generate == nil {
+		generate = NewRequestID
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := ensureRequestID(r.Context(), w, r.Header, generate)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ensureRequestID resolves the request ID from ctx, the incoming headers or
+// generate, in that order, stores it in ctx and echoes it to the response.
+func ensureRequestID(ctx context.Context, w http.ResponseWriter, headers http.Header, generate RequestIDGenerator) context.Context {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = ExtractRequestID(headers)
+		if !validRequestID(requestID) {
+			requestID = generate(ctx)
+		}
+		ctx = ContextWithRequestID(ctx, requestID)
+	}
+	w.Header().Set(HeaderRequestID, requestID)
+	return ctx
+}
+
+// validRequestID reports whether an incoming request ID is non-empty, bounded
+// in length and made of printable ASCII, so it is safe to log and echo.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewRequestID(t *testing.T) {
+	t.Parallel()
+
+	id := NewRequestID(context.Background())
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		t.Fatalf("NewRequestID() = %q, not a UUID: %v", id, err)
+	}
+	if parsed.Version() != 7 {
+		t.Errorf("UUID version = %d, want 7", parsed.Version())
+	}
+	if NewRequestID(context.Background()) == id {
+		t.Error("NewRequestID() should return unique IDs")
+	}
+}
+
+func TestTraceRequestID(t *testing.T) {
+	t.Parallel()
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	if got := TraceRequestID(ctx); got != traceID.String() {
+		t.Errorf("TraceRequestID() = %q, want %q", got, traceID.String())
+	}
+
+	if _, err := uuid.Parse(TraceRequestID(context.Background())); err != nil {
+		t.Errorf("TraceRequestID() without span should fall back to a UUID: %v", err)
+	}
+}
+
+func TestRequestIDContext(t *testing.T) {
+	t.Parallel()
+
+	if got := RequestIDFromContext(context.Background()); got != "" {
+		t.Errorf("RequestIDFromContext() = %q, want empty", got)
+	}
+
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+	if got := RequestIDFromContext(ctx); got != "req-123" {
+		t.Errorf("RequestIDFromContext() = %q, want req-123", got)
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		incoming string
+		want     string
+	}{
+		{"uses incoming", "req-123", "req-123"},
+		{"generates when missing", "", "generated"},
+		{"replaces too long", strings.Repeat("a", maxRequestIDLength+1), "generated"},
+		{"replaces non-printable", "req 123", "generated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			handler := RequestIDMiddleware(func(context.Context) string { return "generated" })(
+				http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					got = RequestIDFromContext(r.Context())
+				}),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(HeaderRequestID, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got != tt.want {
+				t.Errorf("context request ID = %q, want %q", got, tt.want)
+			}
+			if echoed := rec.Header().Get(HeaderRequestID); echoed != tt.want {
+				t.Errorf("response %s = %q, want %q", HeaderRequestID, echoed, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestIDMiddleware_DefaultGenerator(t *testing.T) {
+	t.Parallel()
+
+	handler := RequestIDMiddleware(nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if _, err := uuid.Parse(rec.Header().Get(HeaderRequestID)); err != nil {
+		t.Errorf("generated request ID is not a UUID: %v", err)
+	}
+}
+
+func TestMiddleware_RequestID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := testConfig("test-service")
+	cfg.WithRequestIDGenerator(TraceRequestID)
+
+	tracer, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	var requestID, traceID string
+	handler := Middleware(tracer)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		requestID = RequestIDFromContext(r.Context())
+		traceID = trace.SpanContextFromContext(r.Context()).TraceID().String()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/rides", nil))
+
+	if requestID != traceID {
+		t.Errorf("request ID = %q, want trace ID %q", requestID, traceID)
+	}
+	if got := rec.Header().Get(HeaderRequestID); got != requestID {
+		t.Errorf("response %s = %q, want %q", HeaderRequestID, got, requestID)
+	}
+}
+
+func TestRoundTripper_ForwardsRequestID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tracer, err := New(ctx, testConfig("test-service"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(HeaderRequestID)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: RoundTripper(tracer, nil)}
+
+	req, _ := http.NewRequestWithContext(ContextWithRequestID(ctx, "req-123"), http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if received != "req-123" {
+		t.Errorf("forwarded %s = %q, want req-123", HeaderRequestID, received)
+	}
+}
+
+func TestKafka_RequestIDRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	headers := make(map[string]string)
+	InjectToKafka(ContextWithRequestID(context.Background(), "req-123"), headers)
+
+	if headers[HeaderRequestID] != "req-123" {
+		t.Fatalf("InjectToKafka() %s = %q, want req-123", HeaderRequestID, headers[HeaderRequestID])
+	}
+
+	ctx := ExtractFromKafka(context.Background(), headers)
+	if got := RequestIDFromContext(ctx); got != "req-123" {
+		t.Errorf("ExtractFromKafka() request ID = %q, want req-123", got)
+	}
+}
+
+func TestExtractFromKafka_InvalidRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		requestID string
+	}{
+		{"oversized", strings.Repeat("a", maxRequestIDLength+1)},
+		{"control characters", "req\n123"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		ctx := ExtractFromKafka(context.Background(), map[string]string{HeaderRequestID: tt.requestID})
+		got := RequestIDFromContext(ctx)
+		if got == tt.requestID || !validRequestID(got) {
+			t.Errorf("%s: ExtractFromKafka() request ID = %q, want a generated ID", tt.name, got)
+		}
+	}
+
+	ctx := ExtractFromKafka(context.Background(), map[string]string{})
+	if got := RequestIDFromContext(ctx); got != "" {
+		t.Errorf("ExtractFromKafka() without header request ID = %q, want empty", got)
+	}
+}
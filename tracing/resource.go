@@ -0,0 +1,164 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Kubernetes downward-API environment variables read during resource detection.
+// Expose them in the pod spec via fieldRef (metadata.name, metadata.namespace,
+// metadata.uid, spec.nodeName).
+const (
+	EnvPodName      = "POD_NAME"
+	EnvPodNamespace = "POD_NAMESPACE"
+	EnvPodUID       = "POD_UID"
+	EnvNodeName     = "NODE_NAME"
+)
+
+// Default cgroup files inspected for the container ID.
+const (
+	defaultCgroupPath    = "/proc/self/cgroup"
+	defaultMountInfoPath = "/proc/self/mountinfo"
+)
+
+var (
+	// cgroupContainerIDPattern matches a container ID at the end of a cgroup v1 path,
+	// e.g. "/kubepods/burstable/pod.../cri-containerd-<id>.scope" or "/docker/<id>".
+	cgroupContainerIDPattern = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+
+	// mountInfoContainerIDPattern matches the container ID in cgroup v2 mountinfo
+	// entries, e.g. "/var/lib/docker/containers/<id>/hostname".
+	mountInfoContainerIDPattern = regexp.MustCompile(`/containers/([0-9a-f]{64})/`)
+)
+
+// NewResource creates the OpenTelemetry resource describing this service.
+// Unless DisableResourceDetection is set, it includes host, process runtime,
+// container and Kubernetes attributes detected from the environment.
+func NewResource(ctx context.Context, cfg Config) (*resource.Resource, error) { //nolint:gocritic // cfg passed by value for API simplicity
+	instanceID := cfg.ServiceInstanceID
+	if instanceID == "" {
+		instanceID = uuid.NewString()
+	}
+
+	// Explicit service attributes come last so they take precedence over
+	// user-supplied and detected attributes.
+	attrs := resourceAttributesFromMap(cfg.ResourceAttributes)
+	attrs = append(attrs,
+		semconv.ServiceNameKey.String(cfg.ServiceName),
+		semconv.ServiceVersionKey.String(cfg.ServiceVersion),
+		semconv.ServiceInstanceID(instanceID),
+	)
+	if cfg.Environment != "" {
+		attrs = append(attrs, DeploymentEnvironment(cfg.Environment))
+	}
+
+	opts := []resource.Option{
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithTelemetrySDK(),
+	}
+	if !cfg.DisableResourceDetection {
+		opts = append(opts,
+			resource.WithHost(),
+			resource.WithProcessPID(),
+			resource.WithProcessRuntimeName(),
+			resource.WithProcessRuntimeVersion(),
+			resource.WithProcessRuntimeDescription(),
+			resource.WithDetectors(
+				containerDetector{cgroupPath: defaultCgroupPath, mountInfoPath: defaultMountInfoPath},
+				kubernetesDetector{},
+			),
+		)
+	}
+	opts = append(opts, resource.WithFromEnv(), resource.WithAttributes(attrs...))
+
+	res, err := resource.New(ctx, opts...)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+	return res, nil
+}
+
+// resourceAttributesFromMap converts user-supplied attributes in key order.
+func resourceAttributesFromMap(m map[string]string) []attribute.KeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, attribute.String(k, m[k]))
+	}
+	return attrs
+}
+
+// containerDetector detects the container ID from cgroup files.
+type containerDetector struct {
+	cgroupPath    string
+	mountInfoPath string
+}
+
+// Detect implements resource.Detector.
+func (d containerDetector) Detect(_ context.Context) (*resource.Resource, error) {
+	id := containerIDFromFile(d.cgroupPath, cgroupContainerIDPattern)
+	if id == "" {
+		id = containerIDFromFile(d.mountInfoPath, mountInfoContainerIDPattern)
+	}
+	if id == "" {
+		return resource.Empty(), nil
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, semconv.ContainerID(id)), nil
+}
+
+// containerIDFromFile returns the first container ID matched by pattern in the file.
+// A missing or unreadable file yields an empty string, as outside a container.
+func containerIDFromFile(path string, pattern *regexp.Regexp) string {
+	f, err := os.Open(path) //nolint:gosec // path is a fixed procfs location
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := pattern.FindStringSubmatch(scanner.Text()); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// kubernetesDetector detects pod, namespace and node from downward-API variables.
+type kubernetesDetector struct{}
+
+// Detect implements resource.Detector.
+func (kubernetesDetector) Detect(_ context.Context) (*resource.Resource, error) {
+	var attrs []attribute.KeyValue
+	if v := os.Getenv(EnvPodName); v != "" {
+		attrs = append(attrs, semconv.K8SPodName(v))
+	}
+	if v := os.Getenv(EnvPodNamespace); v != "" {
+		attrs = append(attrs, semconv.K8SNamespaceName(v))
+	}
+	if v := os.Getenv(EnvPodUID); v != "" {
+		attrs = append(attrs, semconv.K8SPodUID(v))
+	}
+	if v := os.Getenv(EnvNodeName); v != "" {
+		attrs = append(attrs, semconv.K8SNodeName(v))
+	}
+	if len(attrs) == 0 {
+		return resource.Empty(), nil
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+}
@@ -0,0 +1,203 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const testContainerID = "3f4b2c1d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b"
+
+// resourceValue returns the string value of key in res, or empty if absent.
+func resourceValue(res *resource.Resource, key string) string {
+	v, ok := res.Set().Value(attribute.Key(key))
+	if !ok {
+		return ""
+	}
+	return v.Emit()
+}
+
+func TestNewResource(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{
+		ServiceName:       "ride-service",
+		ServiceVersion:    "v1.2.3",
+		Environment:       "staging",
+		ServiceInstanceID: "instance-1",
+		ResourceAttributes: map[string]string{
+			"team":         "rides",
+			"service.name": "overridden",
+		},
+	}
+
+	res, err := NewResource(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewResource() error = %v", err)
+	}
+
+	want := map[string]string{
+		"service.name":            "ride-service",
+		"service.version":         "v1.2.3",
+		"service.instance.id":     "instance-1",
+		AttrDeploymentEnvironment: "staging",
+		"team":                    "rides",
+		"process.runtime.name":    "go",
+	}
+	for key, value := range want {
+		if got := resourceValue(res, key); got != value {
+			t.Errorf("resource[%s] = %q, want %q", key, got, value)
+		}
+	}
+	if resourceValue(res, "host.name") == "" {
+		t.Error("host.name should be detected")
+	}
+}
+
+func TestNewResource_GeneratesInstanceID(t *testing.T) {
+	t.Parallel()
+
+	res, err := NewResource(context.Background(), Config{ServiceName: "test"})
+	if err != nil {
+		t.Fatalf("NewResource() error = %v", err)
+	}
+	if resourceValue(res, "service.instance.id") == "" {
+		t.Error("service.instance.id should be generated")
+	}
+}
+
+func TestNewResource_DetectionDisabled(t *testing.T) {
+	t.Parallel()
+
+	res, err := NewResource(context.Background(), Config{
+		ServiceName:              "test",
+		DisableResourceDetection: true,
+	})
+	if err != nil {
+		t.Fatalf("NewResource() error = %v", err)
+	}
+	for _, key := range []string{"host.name", "process.pid", "process.runtime.name"} {
+		if got := resourceValue(res, key); got != "" {
+			t.Errorf("resource[%s] = %q, want absent", key, got)
+		}
+	}
+}
+
+func TestKubernetesDetector(t *testing.T) {
+	t.Setenv(EnvPodName, "ride-service-7d9f-abcde")
+	t.Setenv(EnvPodNamespace, "rides")
+	t.Setenv(EnvPodUID, "0b7c5e6a")
+	t.Setenv(EnvNodeName, "node-1")
+
+	res, err := kubernetesDetector{}.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	want := map[string]string{
+		"k8s.pod.name":       "ride-service-7d9f-abcde",
+		"k8s.namespace.name": "rides",
+		"k8s.pod.uid":        "0b7c5e6a",
+		"k8s.node.name":      "node-1",
+	}
+	for key, value := range want {
+		if got := resourceValue(res, key); got != value {
+			t.Errorf("resource[%s] = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestKubernetesDetector_NotInCluster(t *testing.T) {
+	t.Setenv(EnvPodName, "")
+	t.Setenv(EnvPodNamespace, "")
+	t.Setenv(EnvPodUID, "")
+	t.Setenv(EnvNodeName, "")
+
+	res, err := kubernetesDetector{}.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if res.Len() != 0 {
+		t.Errorf("resource length = %d, want 0", res.Len())
+	}
+}
+
+func TestContainerDetector(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		return path
+	}
+
+	cgroupV1 := write("cgroup-v1", strings.Join([]string{
+		"12:memory:/kubepods/burstable/pod1234/cri-containerd-" + testContainerID + ".scope",
+		"11:cpu:/kubepods/burstable/pod1234/cri-containerd-" + testContainerID + ".scope",
+	}, "\n"))
+	cgroupV2 := write("cgroup-v2", "0::/\n")
+	mountInfo := write("mountinfo", strings.Join([]string{
+		"700 690 0:40 / / rw,relatime master:1 - overlay overlay rw",
+		"712 700 259:1 /var/lib/docker/containers/" + testContainerID + "/hostname /etc/hostname rw - ext4 /dev/root rw",
+	}, "\n"))
+	empty := write("empty", "")
+
+	tests := []struct {
+		name      string
+		detector  containerDetector
+		wantID    string
+		wantEmpty bool
+	}{
+		{"cgroup v1", containerDetector{cgroupPath: cgroupV1, mountInfoPath: empty}, testContainerID, false},
+		{"cgroup v2 mountinfo", containerDetector{cgroupPath: cgroupV2, mountInfoPath: mountInfo}, testContainerID, false},
+		{"not in container", containerDetector{cgroupPath: cgroupV2, mountInfoPath: empty}, "", true},
+		{"missing files", containerDetector{cgroupPath: filepath.Join(dir, "missing"), mountInfoPath: filepath.Join(dir, "missing")}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := tt.detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if tt.wantEmpty {
+				if res.Len() != 0 {
+					t.Errorf("resource length = %d, want 0", res.Len())
+				}
+				return
+			}
+			if got := resourceValue(res, "container.id"); got != tt.wantID {
+				t.Errorf("container.id = %q, want %q", got, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestTracer_Resource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tracer, err := New(ctx, Config{
+		ServiceName: "test-service",
+		Exporter:    ExporterNone,
+		Environment: "production",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	if got := resourceValue(tracer.Resource(), AttrDeploymentEnvironment); got != "production" {
+		t.Errorf("deployment.environment = %q, want production", got)
+	}
+}
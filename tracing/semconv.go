@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SemConvMode selects the attribute keys emitted by the middlewares and the
+// mode-aware attribute helpers during migration to the stable semantic conventions.
+type SemConvMode string
+
+const (
+	// SemConvNew emits the stable keys (http.request.method, url.full, ...).
+	SemConvNew SemConvMode = "new"
+
+	// SemConvOld emits the legacy keys (http.method, http.url, ...).
+	SemConvOld SemConvMode = "old"
+
+	// SemConvBoth emits both, so dashboards can move over gradually.
+	SemConvBoth SemConvMode = "both"
+)
+
+// DefaultSemConvMode is used when Config.SemConvMode is empty. It keeps the
+// legacy keys, as OTEL_SEMCONV_STABILITY_OPT_IN does when unset, so existing
+// dashboards and alerts keep working until a service opts in.
+const DefaultSemConvMode = SemConvOld
+
+// emitOld reports whether legacy keys are emitted. An empty mode uses DefaultSemConvMode.
+func (m SemConvMode) emitOld() bool {
+	return m != SemConvNew
+}
+
+// emitNew reports whether stable keys are emitted.
+func (m SemConvMode) emitNew() bool {
+	return m == SemConvNew || m == SemConvBoth
+}
+
+// attrs returns the legacy and/or stable attributes according to the mode.
+func (m SemConvMode) attrs(legacy, stable attribute.KeyValue) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 2)
+	if m.emitOld() {
+		attrs = append(attrs, legacy)
+	}
+	if m.emitNew() {
+		attrs = append(attrs, stable)
+	}
+	return attrs
+}
+
+// HTTPMethod returns http.method and/or http.request.method.
+func (m SemConvMode) HTTPMethod(method string) []attribute.KeyValue {
+	return m.attrs(HTTPMethod(method), HTTPRequestMethod(method))
+}
+
+// HTTPStatusCode returns http.status_code and/or http.response.status_code.
+func (m SemConvMode) HTTPStatusCode(code int) []attribute.KeyValue {
+	return m.attrs(HTTPStatusCode(code), HTTPResponseStatusCode(code))
+}
+
+// HTTPURL returns http.url and/or url.full.
+func (m SemConvMode) HTTPURL(url string) []attribute.KeyValue {
+	return m.attrs(HTTPURL(url), URLFull(url))
+}
+
+// HTTPScheme returns http.scheme and/or url.scheme.
+func (m SemConvMode) HTTPScheme(scheme string) []attribute.KeyValue {
+	return m.attrs(HTTPScheme(scheme), URLScheme(scheme))
+}
+
+// HTTPHost returns http.host and/or server.address.
+func (m SemConvMode) HTTPHost(host string) []attribute.KeyValue {
+	return m.attrs(HTTPHost(host), ServerAddress(host))
+}
+
+// HTTPUserAgent returns http.user_agent and/or user_agent.original.
+func (m SemConvMode) HTTPUserAgent(userAgent string) []attribute.KeyValue {
+	return m.attrs(HTTPUserAgent(userAgent), UserAgentOriginal(userAgent))
+}
+
+// HTTPClientIP returns http.client_ip and/or client.address.
+func (m SemConvMode) HTTPClientIP(ip string) []attribute.KeyValue {
+	return m.attrs(HTTPClientIP(ip), ClientAddress(ip))
+}
+
+// MessagingDestination returns messaging.destination and/or messaging.destination.name.
+func (m SemConvMode) MessagingDestination(destination string) []attribute.KeyValue {
+	return m.attrs(MessagingDestination(destination), MessagingDestinationName(destination))
+}
+
+// MessagingMessageID returns messaging.message_id and/or messaging.message.id.
+func (m SemConvMode) MessagingMessageID(id string) []attribute.KeyValue {
+	return m.attrs(MessagingMessageID(id), MessagingMessageIDStable(id))
+}
+
+// validate checks the mode.
+func (m SemConvMode) validate() error {
+	switch m {
+	case SemConvNew, SemConvOld, SemConvBoth, "":
+		return nil
+	default:
+		return fmt.Errorf("invalid semconv mode: %s", m)
+	}
+}
@@ -0,0 +1,172 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSemConvMode_Attributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mode SemConvMode
+		want []string
+	}{
+		{SemConvOld, []string{AttrHTTPMethod}},
+		{SemConvNew, []string{AttrHTTPRequestMethod}},
+		{SemConvBoth, []string{AttrHTTPMethod, AttrHTTPRequestMethod}},
+		{"", []string{AttrHTTPMethod}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			t.Parallel()
+
+			attrs := tt.mode.HTTPMethod("GET")
+			if len(attrs) != len(tt.want) {
+				t.Fatalf("HTTPMethod() = %v, want keys %v", attrs, tt.want)
+			}
+			for i, key := range tt.want {
+				if string(attrs[i].Key) != key || attrs[i].Value.AsString() != "GET" {
+					t.Errorf("attrs[%d] = %v, want %s=GET", i, attrs[i], key)
+				}
+			}
+		})
+	}
+}
+
+func TestSemConvMode_Helpers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		attrs []attribute.KeyValue
+		keys  []string
+	}{
+		{"status code", SemConvBoth.HTTPStatusCode(200), []string{AttrHTTPStatusCode, AttrHTTPResponseStatusCode}},
+		{"url", SemConvBoth.HTTPURL("https://api/x"), []string{AttrHTTPURL, AttrURLFull}},
+		{"scheme", SemConvBoth.HTTPScheme("https"), []string{AttrHTTPScheme, AttrURLScheme}},
+		{"host", SemConvBoth.HTTPHost("api"), []string{AttrHTTPHost, AttrServerAddress}},
+		{"user agent", SemConvBoth.HTTPUserAgent("app"), []string{AttrHTTPUserAgent, AttrUserAgentOriginal}},
+		{"client ip", SemConvBoth.HTTPClientIP("203.0.113.7"), []string{AttrHTTPClientIP, AttrClientAddress}},
+		{"destination", SemConvBoth.MessagingDestination("rides"), []string{AttrMessagingDestination, AttrMessagingDestinationName}},
+		{"message id", SemConvBoth.MessagingMessageID("m-1"), []string{AttrMessagingMessageID, AttrMessagingMessageIDStable}},
+	}
+
+	for _, tt := range tests {
+		if len(tt.attrs) != len(tt.keys) {
+			t.Errorf("%s: got %d attributes, want %d", tt.name, len(tt.attrs), len(tt.keys))
+			continue
+		}
+		for i, key := range tt.keys {
+			if string(tt.attrs[i].Key) != key {
+				t.Errorf("%s: key[%d] = %s, want %s", tt.name, i, tt.attrs[i].Key, key)
+			}
+		}
+	}
+}
+
+func TestConfig_Validate_SemConvMode(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.WithServiceName("test-service").WithSemConvMode("latest")
+
+	if err := cfg.Validate(); err == nil || !contains(err.Error(), "invalid semconv mode") {
+		t.Errorf("Validate() error = %v, want invalid semconv mode", err)
+	}
+}
+
+func TestMiddleware_SemConvMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mode    SemConvMode
+		present []string
+		absent  []string
+	}{
+		{
+			mode:    SemConvNew,
+			present: []string{AttrHTTPRequestMethod, AttrHTTPResponseStatusCode, AttrURLPath, AttrURLScheme, AttrServerAddress, AttrUserAgentOriginal, AttrClientAddress, AttrHTTPRoute},
+			absent:  []string{AttrHTTPMethod, AttrHTTPStatusCode, AttrHTTPURL, AttrHTTPHost, AttrHTTPUserAgent, AttrHTTPClientIP},
+		},
+		{
+			mode:    SemConvOld,
+			present: []string{AttrHTTPMethod, AttrHTTPStatusCode, AttrHTTPURL, AttrHTTPHost, AttrHTTPUserAgent, AttrHTTPClientIP, AttrHTTPRoute},
+			absent:  []string{AttrHTTPRequestMethod, AttrHTTPResponseStatusCode, AttrURLPath, AttrServerAddress},
+		},
+		{
+			mode:    SemConvBoth,
+			present: []string{AttrHTTPMethod, AttrHTTPRequestMethod, AttrHTTPStatusCode, AttrHTTPResponseStatusCode},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			t.Parallel()
+
+			tracer, recorder := newRecordingTracer(t)
+			tracer.config.SemConvMode = tt.mode
+
+			handler := Middleware(tracer)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "http://api.txova.test:8080/api/v1/rides?phone=1", nil)
+			req.Header.Set("User-Agent", "TestAgent/1.0")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			attrs := attribute.NewSet(recorder.Ended()[0].Attributes()...)
+			for _, key := range tt.present {
+				if !attrs.HasValue(attribute.Key(key)) {
+					t.Errorf("attribute %s missing", key)
+				}
+			}
+			for _, key := range tt.absent {
+				if attrs.HasValue(attribute.Key(key)) {
+					t.Errorf("attribute %s should not be set", key)
+				}
+			}
+			if tt.mode == SemConvNew {
+				if v, _ := attrs.Value(AttrServerAddress); v.AsString() != "api.txova.test" {
+					t.Errorf("server.address = %q, want api.txova.test", v.AsString())
+				}
+				if v, _ := attrs.Value(AttrURLPath); v.AsString() != "/api/v1/rides" {
+					t.Errorf("url.path = %q, want /api/v1/rides", v.AsString())
+				}
+			}
+		})
+	}
+}
+
+func TestRoundTripper_SemConvMode(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	tracer.config.SemConvMode = SemConvNew
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: RoundTripper(tracer, nil)}
+	resp, err := client.Get(server.URL + "/drivers?token=secret")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	attrs := attribute.NewSet(recorder.Ended()[0].Attributes()...)
+	if v, _ := attrs.Value(AttrURLFull); v.AsString() != server.URL+"/drivers" {
+		t.Errorf("url.full = %q, want %q", v.AsString(), server.URL+"/drivers")
+	}
+	if v, _ := attrs.Value(AttrServerAddress); v.AsString() != "127.0.0.1" {
+		t.Errorf("server.address = %q, want 127.0.0.1", v.AsString())
+	}
+	if v, _ := attrs.Value(AttrHTTPResponseStatusCode); v.AsInt64() != http.StatusOK {
+		t.Errorf("http.response.status_code = %d, want 200", v.AsInt64())
+	}
+}
@@ -0,0 +1,201 @@
+package tracing
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Dorico-Dynamics/txova-go-observability/internal/slogattr"
+)
+
+// Log attribute keys added by LogHandler.
+const (
+	LogKeyTraceID    = "trace_id"
+	LogKeySpanID     = "span_id"
+	LogKeyTraceFlags = "trace_flags"
+	LogKeyRequestID  = "request_id"
+)
+
+// Span event name and attribute keys for records mirrored by LogHandler.
+const (
+	LogEventName    = "log"
+	AttrLogSeverity = "log.severity"
+	AttrLogMessage  = "log.message"
+)
+
+// DefaultSpanEventLevel is the minimum level mirrored as span events when
+// LogHandlerOptions.SpanEventLevel is nil.
+const DefaultSpanEventLevel = slog.LevelWarn
+
+// LogHandlerOptions configures LogHandler.
+type LogHandlerOptions struct {
+	// SpanEvents mirrors records at or above SpanEventLevel as events on the
+	// span in the record's context, so they show up in the trace view.
+	SpanEvents bool
+
+	// SpanEventLevel is the minimum level mirrored as span events.
+	// Nil uses DefaultSpanEventLevel.
+	SpanEventLevel slog.Leveler
+}
+
+// LogHandler is a slog.Handler that adds the trace context and request ID from
+// the record's context to every record before passing it to the wrapped
+// handler. Records must be logged with a context (e.g., slog.InfoContext) to
+// carry trace context.
+//
+// The attributes are always emitted at the top level, also after WithGroup,
+// so log backends can correlate records with traces by trace_id.
+type LogHandler struct {
+	next       slog.Handler
+	spanEvents bool
+	eventLevel slog.Leveler
+	groups     []string
+	attrs      []attribute.KeyValue
+
+	// base is next before the first group was opened, and groupAttrs holds
+	// the attributes added within each open group. Records with trace
+	// context are passed to base with the groups as attributes, so the trace
+	// attributes stay outside the groups without rebuilding the handler.
+	base       slog.Handler
+	groupAttrs [][]slog.Attr
+}
+
+// NewLogHandler wraps next with trace context enrichment. opts may be nil.
+func NewLogHandler(next slog.Handler, opts *LogHandlerOptions) *LogHandler {
+	h := &LogHandler{next: next, base: next, eventLevel: DefaultSpanEventLevel}
+	if opts != nil {
+		h.spanEvents = opts.SpanEvents
+		if opts.SpanEventLevel != nil {
+			h.eventLevel = opts.SpanEventLevel
+		}
+	}
+	return h
+}
+
+// Enabled implements slog.Handler.
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds trace_id, span_id, trace_flags and request_id when present,
+// mirrors the record as a span event if enabled, and passes it on.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.spanEvents && r.Level >= h.eventLevel.Level() {
+		h.addSpanEvent(ctx, r)
+	}
+
+	var attrs []slog.Attr
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs,
+			slog.String(LogKeyTraceID, sc.TraceID().String()),
+			slog.String(LogKeySpanID, sc.SpanID().String()),
+			slog.String(LogKeyTraceFlags, sc.TraceFlags().String()),
+		)
+	}
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		attrs = append(attrs, slog.String(LogKeyRequestID, requestID))
+	}
+	if len(attrs) == 0 {
+		return h.next.Handle(ctx, r)
+	}
+	if len(h.groups) == 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+		return h.next.Handle(ctx, r)
+	}
+
+	// Inside a group, nest the group attributes and the record attributes
+	// under the group names and add the trace attributes next to them.
+	inner := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		inner = append(inner, a)
+		return true
+	})
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		level := append(slices.Clip(h.groupAttrs[i]), inner...)
+		inner = []slog.Attr{{Key: h.groups[i], Value: slog.GroupValue(level...)}}
+	}
+	grouped := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	grouped.AddAttrs(attrs...)
+	grouped.AddAttrs(inner...)
+	return h.base.Handle(ctx, grouped)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	clone.attrs = append(h.attrs[:len(h.attrs):len(h.attrs)], logAttributes(slogattr.Prefix(h.groups), attrs)...)
+	if len(h.groups) == 0 {
+		clone.base = clone.next
+	} else {
+		last := len(h.groupAttrs) - 1
+		clone.groupAttrs = slices.Clone(h.groupAttrs)
+		clone.groupAttrs[last] = append(slices.Clip(h.groupAttrs[last]), attrs...)
+	}
+	return &clone
+}
+
+// WithGroup implements slog.Handler.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	clone.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	clone.groupAttrs = append(h.groupAttrs[:len(h.groupAttrs):len(h.groupAttrs)], nil)
+	return &clone
+}
+
+// addSpanEvent records r as an event on the recording span in ctx.
+func (h *LogHandler) addSpanEvent(ctx context.Context, r slog.Record) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(h.attrs)+r.NumAttrs()+2)
+	attrs = append(attrs,
+		attribute.String(AttrLogSeverity, r.Level.String()),
+		attribute.String(AttrLogMessage, r.Message),
+	)
+	attrs = append(attrs, h.attrs...)
+	prefix := slogattr.Prefix(h.groups)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendLogAttribute(attrs, prefix, a)
+		return true
+	})
+
+	span.AddEvent(LogEventName, trace.WithTimestamp(r.Time), trace.WithAttributes(attrs...))
+}
+
+// logAttributes converts slog attributes to span attributes, flattening
+// groups into dot-separated keys.
+func logAttributes(prefix string, attrs []slog.Attr) []attribute.KeyValue {
+	var out []attribute.KeyValue
+	for _, a := range attrs {
+		out = appendLogAttribute(out, prefix, a)
+	}
+	return out
+}
+
+// appendLogAttribute appends a slog attribute to out as span attributes.
+func appendLogAttribute(out []attribute.KeyValue, prefix string, a slog.Attr) []attribute.KeyValue {
+	slogattr.Walk(prefix, a, func(key string, v slog.Value) {
+		switch v.Kind() {
+		case slog.KindInt64:
+			out = append(out, attribute.Int64(key, v.Int64()))
+		case slog.KindFloat64:
+			out = append(out, attribute.Float64(key, v.Float64()))
+		case slog.KindBool:
+			out = append(out, attribute.Bool(key, v.Bool()))
+		default:
+			out = append(out, attribute.String(key, v.String()))
+		}
+	})
+	return out
+}
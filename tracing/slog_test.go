@@ -0,0 +1,287 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// newTestLogger returns a logger writing JSON through a LogHandler into buf.
+func newTestLogger(buf *bytes.Buffer, opts *LogHandlerOptions) *slog.Logger {
+	return slog.New(NewLogHandler(slog.NewJSONHandler(buf, nil), opts))
+}
+
+// decodeLogLine decodes the single JSON log line in buf.
+func decodeLogLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("invalid log line %q: %v", buf.String(), err)
+	}
+	return line
+}
+
+func TestLogHandler_TraceContext(t *testing.T) {
+	t.Parallel()
+
+	tracer, _ := newRecordingTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test")
+	defer span.End()
+	ctx = ContextWithRequestID(ctx, "req-123")
+
+	var buf bytes.Buffer
+	newTestLogger(&buf, nil).InfoContext(ctx, "ride accepted", "ride_id", "r-1")
+
+	line := decodeLogLine(t, &buf)
+	sc := span.SpanContext()
+	want := map[string]string{
+		LogKeyTraceID:    sc.TraceID().String(),
+		LogKeySpanID:     sc.SpanID().String(),
+		LogKeyTraceFlags: "01",
+		LogKeyRequestID:  "req-123",
+		"ride_id":        "r-1",
+	}
+	for key, value := range want {
+		if line[key] != value {
+			t.Errorf("%s = %v, want %q", key, line[key], value)
+		}
+	}
+}
+
+func TestLogHandler_NoTraceContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	newTestLogger(&buf, nil).InfoContext(context.Background(), "startup")
+
+	line := decodeLogLine(t, &buf)
+	for _, key := range []string{LogKeyTraceID, LogKeySpanID, LogKeyTraceFlags, LogKeyRequestID} {
+		if _, ok := line[key]; ok {
+			t.Errorf("%s present without trace context", key)
+		}
+	}
+}
+
+func TestLogHandler_WithAttrsAndGroup(t *testing.T) {
+	t.Parallel()
+
+	tracer, _ := newRecordingTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test")
+	defer span.End()
+
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf, nil).With("service", "pricing")
+	logger.InfoContext(ctx, "quote")
+
+	line := decodeLogLine(t, &buf)
+	if line["service"] != "pricing" {
+		t.Errorf("service = %v, want pricing", line["service"])
+	}
+	if line[LogKeyTraceID] != span.SpanContext().TraceID().String() {
+		t.Errorf("trace_id = %v, want %s", line[LogKeyTraceID], span.SpanContext().TraceID())
+	}
+}
+
+func TestLogHandler_GroupKeepsTraceContextTopLevel(t *testing.T) {
+	t.Parallel()
+
+	tracer, _ := newRecordingTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test")
+	defer span.End()
+	ctx = ContextWithRequestID(ctx, "req-123")
+
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf, nil).With("service", "pricing").WithGroup("http").With("method", "GET")
+	logger.InfoContext(ctx, "request", "status", 200)
+
+	line := decodeLogLine(t, &buf)
+	if line[LogKeyTraceID] != span.SpanContext().TraceID().String() {
+		t.Errorf("trace_id = %v, want %s", line[LogKeyTraceID], span.SpanContext().TraceID())
+	}
+	if line[LogKeyRequestID] != "req-123" {
+		t.Errorf("request_id = %v, want req-123", line[LogKeyRequestID])
+	}
+	if line["service"] != "pricing" {
+		t.Errorf("service = %v, want pricing", line["service"])
+	}
+
+	group, _ := line["http"].(map[string]any)
+	if group["method"] != "GET" || group["status"] != float64(200) {
+		t.Errorf("http = %v, want method and status", line["http"])
+	}
+	if _, ok := group[LogKeyTraceID]; ok {
+		t.Error("trace_id is qualified by the group")
+	}
+}
+
+func TestLogHandler_NestedGroupsKeepAttributes(t *testing.T) {
+	t.Parallel()
+
+	tracer, _ := newRecordingTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test")
+	defer span.End()
+
+	var buf bytes.Buffer
+	base := newTestLogger(&buf, nil).WithGroup("http").With("method", "GET")
+	logger := base.WithGroup("response").With("bytes", 12)
+	base.With("ignored", true) // Siblings do not share group attributes.
+	logger.InfoContext(ctx, "request", "status", 200)
+
+	line := decodeLogLine(t, &buf)
+	if line[LogKeyTraceID] != span.SpanContext().TraceID().String() {
+		t.Errorf("trace_id = %v, want %s", line[LogKeyTraceID], span.SpanContext().TraceID())
+	}
+	httpGroup, _ := line["http"].(map[string]any)
+	response, _ := httpGroup["response"].(map[string]any)
+	if httpGroup["method"] != "GET" || response["bytes"] != float64(12) || response["status"] != float64(200) {
+		t.Errorf("http = %v, want method, response.bytes and response.status", line["http"])
+	}
+	if _, ok := httpGroup["ignored"]; ok {
+		t.Error("attributes of a sibling logger leaked into the group")
+	}
+}
+
+func TestLogHandler_SpanEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		opts       *LogHandlerOptions
+		log        func(ctx context.Context, logger *slog.Logger)
+		wantEvents int
+	}{
+		{
+			name:       "disabled",
+			opts:       nil,
+			log:        func(ctx context.Context, l *slog.Logger) { l.ErrorContext(ctx, "failed") },
+			wantEvents: 0,
+		},
+		{
+			name: "warn and error mirrored",
+			opts: &LogHandlerOptions{SpanEvents: true},
+			log: func(ctx context.Context, l *slog.Logger) {
+				l.InfoContext(ctx, "info")
+				l.WarnContext(ctx, "warn")
+				l.ErrorContext(ctx, "error")
+			},
+			wantEvents: 2,
+		},
+		{
+			name: "custom level",
+			opts: &LogHandlerOptions{SpanEvents: true, SpanEventLevel: slog.LevelError},
+			log: func(ctx context.Context, l *slog.Logger) {
+				l.WarnContext(ctx, "warn")
+				l.ErrorContext(ctx, "error")
+			},
+			wantEvents: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracer, recorder := newRecordingTracer(t)
+			ctx, span := tracer.Start(context.Background(), "test")
+
+			var buf bytes.Buffer
+			tt.log(ctx, newTestLogger(&buf, tt.opts))
+			span.End()
+
+			events := recorder.Ended()[0].Events()
+			if len(events) != tt.wantEvents {
+				t.Errorf("events = %d, want %d", len(events), tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestLogHandler_SpanEventAttributes(t *testing.T) {
+	t.Parallel()
+
+	tracer, recorder := newRecordingTracer(t)
+	ctx, span := tracer.Start(context.Background(), "test")
+
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf, &LogHandlerOptions{SpanEvents: true}).
+		With("component", "dispatch").
+		WithGroup("driver")
+	logger.WarnContext(ctx, "no drivers nearby",
+		"count", 0,
+		"radius", 2.5,
+		"err", errors.New("timeout"),
+		slog.Group("search", "wait", 3*time.Second),
+	)
+	span.End()
+
+	events := recorder.Ended()[0].Events()
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1", len(events))
+	}
+	if events[0].Name != LogEventName {
+		t.Errorf("event name = %q, want %q", events[0].Name, LogEventName)
+	}
+
+	got := attribute.NewSet(events[0].Attributes...)
+	want := []attribute.KeyValue{
+		attribute.String(AttrLogSeverity, "WARN"),
+		attribute.String(AttrLogMessage, "no drivers nearby"),
+		attribute.String("component", "dispatch"),
+		attribute.Int64("driver.count", 0),
+		attribute.Float64("driver.radius", 2.5),
+		attribute.String("driver.err", "timeout"),
+		attribute.String("driver.search.wait", "3s"),
+	}
+	for _, kv := range want {
+		if v, ok := got.Value(kv.Key); !ok || v != kv.Value {
+			t.Errorf("%s = %v, want %v", kv.Key, v.Emit(), kv.Value.Emit())
+		}
+	}
+	if got.HasValue(LogKeyTraceID) {
+		t.Error("span event should not repeat trace_id")
+	}
+}
+
+func TestLogHandler_Enabled(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	inner := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+	h := NewLogHandler(inner, nil)
+
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Enabled(info) = true, want false")
+	}
+	if !h.Enabled(context.Background(), slog.LevelError) {
+		t.Error("Enabled(error) = false, want true")
+	}
+}
+
+func BenchmarkLogHandler_Group(b *testing.B) {
+	tracer, err := New(context.Background(), Config{ServiceName: "bench", Exporter: ExporterNone, SampleRate: 1})
+	if err != nil {
+		b.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(context.Background())
+	ctx, span := tracer.Start(context.Background(), "bench")
+	defer span.End()
+
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(io.Discard, nil), nil)).WithGroup("http")
+	for i := range 20 {
+		logger = logger.With(fmt.Sprintf("attr%d", i), i)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.InfoContext(ctx, "request", "status", 200)
+	}
+}
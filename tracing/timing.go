@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Response headers emitted by Middleware when enabled.
+const (
+	// HeaderTraceResponse is the W3C Trace Context Level 2 response header.
+	HeaderTraceResponse = "traceresponse"
+
+	// HeaderServerTiming is the Server-Timing response header.
+	HeaderServerTiming = "Server-Timing"
+)
+
+// serverTimingKey is the context key for the request's Server-Timing phases.
+type serverTimingKey struct{}
+
+// serverTiming accumulates named phase durations for a request.
+type serverTiming struct {
+	mu     sync.Mutex
+	names  []string
+	phases map[string]time.Duration
+}
+
+// add accumulates d under name, keeping first-seen order.
+func (t *serverTiming) add(name string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.phases[name]; !ok {
+		t.names = append(t.names, name)
+	}
+	t.phases[name] += d
+}
+
+// header formats the Server-Timing value with the total duration, the phases
+// and the trace parent of the request span.
+func (t *serverTiming) header(total time.Duration, sc trace.SpanContext) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries := []string{"total;dur=" + formatMillis(total)}
+	for _, name := range t.names {
+		entries = append(entries, name+";dur="+formatMillis(t.phases[name]))
+	}
+	if sc.IsValid() {
+		entries = append(entries, fmt.Sprintf("traceparent;desc=%q", traceParent(sc)))
+	}
+	return strings.Join(entries, ", ")
+}
+
+// formatMillis formats d in milliseconds with up to three decimals.
+func formatMillis(d time.Duration) string {
+	return strconv.FormatFloat(float64(d.Microseconds())/1000, 'f', -1, 64)
+}
+
+// traceParent formats a span context as a W3C traceparent value.
+func traceParent(sc trace.SpanContext) string {
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), sc.TraceFlags())
+}
+
+// contextWithServerTiming returns a copy of ctx collecting Server-Timing phases.
+func contextWithServerTiming(ctx context.Context) (context.Context, *serverTiming) {
+	t := &serverTiming{phases: make(map[string]time.Duration)}
+	return context.WithValue(ctx, serverTimingKey{}, t), t
+}
+
+// AddServerTiming adds a named phase (e.g., "db", "cache", "pricing") to the
+// Server-Timing header of the current request. Durations for the same name are
+// summed. It is a no-op unless Middleware runs with WithServerTiming, or for
+// names that are not valid tokens. Phases added after the response headers
+// were written are not reported.
+func AddServerTiming(ctx context.Context, name string, duration time.Duration) {
+	t, ok := ctx.Value(serverTimingKey{}).(*serverTiming)
+	if !ok || !isToken(name) {
+		return
+	}
+	t.add(name, duration)
+}
+
+// StartServerTiming starts timing a named phase and returns a function that
+// records it with AddServerTiming:
+//
+//	defer tracing.StartServerTiming(ctx, "db")()
+func StartServerTiming(ctx context.Context, name string) func() {
+	start := time.Now()
+	return func() {
+		AddServerTiming(ctx, name, time.Since(start))
+	}
+}
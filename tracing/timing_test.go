@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestAddServerTiming(t *testing.T) {
+	t.Parallel()
+
+	ctx, timing := contextWithServerTiming(context.Background())
+	AddServerTiming(ctx, "db", 4*time.Millisecond)
+	AddServerTiming(ctx, "cache", 1500*time.Microsecond)
+	AddServerTiming(ctx, "db", 2*time.Millisecond)
+	AddServerTiming(ctx, "bad name", time.Millisecond)
+
+	got := timing.header(10*time.Millisecond, trace.SpanContext{})
+	want := "total;dur=10, db;dur=6, cache;dur=1.5"
+	if got != want {
+		t.Errorf("header() = %q, want %q", got, want)
+	}
+}
+
+func TestAddServerTiming_NoCollector(t *testing.T) {
+	t.Parallel()
+
+	// Must not panic without Middleware.
+	AddServerTiming(context.Background(), "db", time.Millisecond)
+	StartServerTiming(context.Background(), "db")()
+}
+
+func TestStartServerTiming(t *testing.T) {
+	t.Parallel()
+
+	ctx, timing := contextWithServerTiming(context.Background())
+	stop := StartServerTiming(ctx, "pricing")
+	time.Sleep(time.Millisecond)
+	stop()
+
+	if got := timing.phases["pricing"]; got < time.Millisecond {
+		t.Errorf("pricing duration = %v, want >= 1ms", got)
+	}
+}
+
+func TestMiddleware_ResponseHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		opts              []MiddlewareOption
+		writeBody         bool
+		wantTraceResponse bool
+		wantServerTiming  bool
+	}{
+		{"disabled", nil, true, false, false},
+		{"traceresponse", []MiddlewareOption{WithTraceResponse()}, true, true, false},
+		{"server timing", []MiddlewareOption{WithServerTiming()}, true, false, true},
+		{"both", []MiddlewareOption{WithTraceResponse(), WithServerTiming()}, true, true, true},
+		{"empty response", []MiddlewareOption{WithTraceResponse(), WithServerTiming()}, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracer, _ := newRecordingTracer(t)
+
+			var sc trace.SpanContext
+			handler := Middleware(tracer, tt.opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sc = trace.SpanContextFromContext(r.Context())
+				AddServerTiming(r.Context(), "db", 3*time.Millisecond)
+				if tt.writeBody {
+					w.Write([]byte("ok"))
+				}
+				AddServerTiming(r.Context(), "late", time.Millisecond)
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/rides", nil))
+
+			traceResponse := rec.Header().Get(HeaderTraceResponse)
+			if tt.wantTraceResponse {
+				want := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+				if traceResponse != want {
+					t.Errorf("traceresponse = %q, want %q", traceResponse, want)
+				}
+			} else if traceResponse != "" {
+				t.Errorf("traceresponse = %q, want empty", traceResponse)
+			}
+
+			serverTiming := rec.Header().Get(HeaderServerTiming)
+			if !tt.wantServerTiming {
+				if serverTiming != "" {
+					t.Errorf("Server-Timing = %q, want empty", serverTiming)
+				}
+				return
+			}
+			for _, part := range []string{"total;dur=", "db;dur=3", `traceparent;desc="00-` + sc.TraceID().String()} {
+				if !strings.Contains(serverTiming, part) {
+					t.Errorf("Server-Timing = %q, want to contain %q", serverTiming, part)
+				}
+			}
+			if tt.writeBody && strings.Contains(serverTiming, "late") {
+				t.Errorf("Server-Timing = %q, want phases after the write omitted", serverTiming)
+			}
+		})
+	}
+}
+
+func TestMiddleware_ServerTimingStreaming(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		flush func(w http.ResponseWriter) error
+	}{
+		{"http.Flusher", func(w http.ResponseWriter) error {
+			w.(http.Flusher).Flush()
+			return nil
+		}},
+		{"http.ResponseController", func(w http.ResponseWriter) error {
+			return http.NewResponseController(w).Flush()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracer, _ := newRecordingTracer(t)
+			handler := Middleware(tracer, WithServerTiming())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				if err := tt.flush(w); err != nil {
+					t.Errorf("Flush() error = %v", err)
+				}
+				w.Write([]byte("data: ride accepted\n\n"))
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/rides/events", nil))
+
+			// The recorder snapshots the headers sent by the first flush.
+			res := rec.Result()
+			if !rec.Flushed || res.StatusCode != http.StatusOK {
+				t.Fatalf("flushed = %v, status = %d, want a flushed 200 response", rec.Flushed, res.StatusCode)
+			}
+			if !strings.Contains(res.Header.Get(HeaderServerTiming), "total;dur=") {
+				t.Errorf("Server-Timing = %q, want it sent with the flushed headers", res.Header.Get(HeaderServerTiming))
+			}
+		})
+	}
+}
@@ -2,25 +2,33 @@ package tracing
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
 )
 
 // Tracer wraps an OpenTelemetry tracer with configuration and lifecycle management.
 type Tracer struct {
-	provider *sdktrace.TracerProvider
-	tracer   trace.Tracer
-	config   Config
+	provider   *sdktrace.TracerProvider
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	resource   *resource.Resource
+	clientIP   *ClientIPResolver
+	errors     *errorRecorder
+	config     Config
 }
 
 // New creates a new Tracer with the given configuration.
@@ -31,84 +39,114 @@ func New(ctx context.Context, cfg Config) (*Tracer, error) { //nolint:gocritic /
 
 	applyConfigDefaults(&cfg)
 
-	res, err := createResource(&cfg)
+	res, err := NewResource(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	exporter, err := createExporter(ctx, &cfg)
-	if err != nil && !errors.Is(err, errNoExporter) {
+	if err != nil && !errors.Is(err, ErrNoExporter) {
 		return nil, err
 	}
-	// If errNoExporter, exporter is nil which is handled by createProvider
+	// If ErrNoExporter, exporter is nil which is handled by createProvider
+	if exporter != nil && cfg.Redaction.IsSet() {
+		exporter = redactingExporter{SpanExporter: exporter, redactor: newRedactor(cfg.Redaction, cfg.Metrics)}
+	}
+
+	sampler := createSampler(cfg.SampleRate, cfg.ParentBased)
+	batch := batchWithDefaults(cfg.Batch)
+	limits := createSpanLimits(cfg.SpanLimits)
+	var processors []sdktrace.SpanProcessor
+	if len(cfg.BaggageSpanAttributes) > 0 {
+		processors = append(processors, NewBaggageSpanProcessor(cfg.BaggageSpanAttributes...))
+	}
+	provider := createProvider(res, sampler, exporter, batch, limits, processors...)
 
-	sampler := createSampler(cfg.SampleRate)
-	provider := createProvider(res, sampler, exporter)
+	logSettings(&cfg, batch, limits)
+
+	var extra []propagation.TextMapPropagator
+	if len(cfg.CorrelationHeaders) > 0 {
+		extra = append(extra, correlationPropagator{headers: cfg.CorrelationHeaders})
+	}
+	propagator := createPropagator(cfg.Propagation, cfg.InjectPropagation, extra...)
 
 	otel.SetTracerProvider(provider)
-	otel.SetTextMapPropagator(createPropagator(cfg.Propagation))
+	if !cfg.DisableGlobalPropagator {
+		otel.SetTextMapPropagator(propagator)
+	}
+
+	// Trusted proxies were validated above.
+	clientIP, _ := NewClientIPResolver(cfg.TrustedProxies)
+
+	errs := newErrorRecorder(cfg.Errors, cfg.Metrics)
+	errorRecorders.Store(provider, errs)
 
 	return &Tracer{
-		provider: provider,
-		tracer:   provider.Tracer(cfg.ServiceName),
-		config:   cfg,
+		provider:   provider,
+		tracer:     provider.Tracer(cfg.ServiceName),
+		propagator: propagator,
+		resource:   res,
+		clientIP:   clientIP,
+		errors:     errs,
+		config:     cfg,
 	}, nil
 }
 
 // applyConfigDefaults sets default values for empty config fields.
 func applyConfigDefaults(cfg *Config) {
-	if cfg.Propagation == "" {
-		cfg.Propagation = PropagationW3C
+	if len(cfg.Propagation) == 0 {
+		cfg.Propagation = append([]PropagationType(nil), DefaultPropagation...)
 	}
 	if cfg.Exporter == "" {
 		cfg.Exporter = ExporterOTLPHTTP
 	}
-}
-
-// createResource creates an OpenTelemetry resource with service information.
-func createResource(cfg *Config) (*resource.Resource, error) {
-	res, err := resource.Merge(
-		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(cfg.ServiceName),
-			semconv.ServiceVersionKey.String(cfg.ServiceVersion),
-		),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create resource: %w", err)
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.RequestIDGenerator == nil {
+		cfg.RequestIDGenerator = NewRequestID
+	}
+	if cfg.TrustedProxies == nil {
+		cfg.TrustedProxies = DefaultTrustedProxies
+	}
+	if cfg.SemConvMode == "" {
+		cfg.SemConvMode = DefaultSemConvMode
 	}
-	return res, nil
 }
 
-// errNoExporter is a sentinel value indicating no exporter is configured.
-// This is not an error condition, just indicates ExporterNone was selected.
-var errNoExporter = fmt.Errorf("no exporter configured")
-
 // createExporter creates a span exporter based on configuration.
 func createExporter(ctx context.Context, cfg *Config) (sdktrace.SpanExporter, error) {
-	switch cfg.Exporter {
-	case ExporterOTLPHTTP:
-		return createHTTPExporter(ctx, cfg)
-	case ExporterOTLPGRPC:
-		return createGRPCExporter(ctx, cfg)
-	case ExporterNone:
-		return nil, errNoExporter
-	default:
-		return nil, fmt.Errorf("unsupported exporter type: %s", cfg.Exporter)
-	}
+	return NewOTLPExporter(ctx, cfg.Exporter, OTLPExporterFuncs[sdktrace.SpanExporter]{
+		HTTP: func(ctx context.Context) (sdktrace.SpanExporter, error) { return createHTTPExporter(ctx, cfg) },
+		GRPC: func(ctx context.Context) (sdktrace.SpanExporter, error) { return createGRPCExporter(ctx, cfg) },
+	})
 }
 
 // createHTTPExporter creates an OTLP HTTP exporter.
 func createHTTPExporter(ctx context.Context, cfg *Config) (sdktrace.SpanExporter, error) {
-	opts := []otlptracehttp.Option{
-		otlptracehttp.WithEndpoint(cfg.Endpoint),
-	}
-	if cfg.Insecure {
-		opts = append(opts, otlptracehttp.WithInsecure())
+	opts, err := OTLPOptions(cfg, OTLPOptionFuncs[otlptracehttp.Option]{
+		Endpoint: otlptracehttp.WithEndpoint,
+		Insecure: otlptracehttp.WithInsecure,
+		Headers:  otlptracehttp.WithHeaders,
+		TLS:      otlptracehttp.WithTLSClientConfig,
+		Gzip: func() otlptracehttp.Option {
+			return otlptracehttp.WithCompression(otlptracehttp.GzipCompression)
+		},
+		Timeout: otlptracehttp.WithTimeout,
+		Retry: func(retry RetryConfig) otlptracehttp.Option {
+			return otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+				Enabled:         !retry.Disabled,
+				InitialInterval: retry.InitialInterval,
+				MaxInterval:     retry.MaxInterval,
+				MaxElapsedTime:  retry.MaxElapsedTime,
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
-	if len(cfg.Headers) > 0 {
-		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	if cfg.URLPath != "" {
+		opts = append(opts, otlptracehttp.WithURLPath(cfg.URLPath))
 	}
 	exporter, err := otlptracehttp.New(ctx, opts...)
 	if err != nil {
@@ -119,14 +157,28 @@ func createHTTPExporter(ctx context.Context, cfg *Config) (sdktrace.SpanExporter
 
 // createGRPCExporter creates an OTLP gRPC exporter.
 func createGRPCExporter(ctx context.Context, cfg *Config) (sdktrace.SpanExporter, error) {
-	opts := []otlptracegrpc.Option{
-		otlptracegrpc.WithEndpoint(cfg.Endpoint),
-	}
-	if cfg.Insecure {
-		opts = append(opts, otlptracegrpc.WithInsecure())
-	}
-	if len(cfg.Headers) > 0 {
-		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	opts, err := OTLPOptions(cfg, OTLPOptionFuncs[otlptracegrpc.Option]{
+		Endpoint: otlptracegrpc.WithEndpoint,
+		Insecure: otlptracegrpc.WithInsecure,
+		Headers:  otlptracegrpc.WithHeaders,
+		TLS: func(tlsCfg *tls.Config) otlptracegrpc.Option {
+			return otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg))
+		},
+		Gzip: func() otlptracegrpc.Option {
+			return otlptracegrpc.WithCompressor(string(CompressionGzip))
+		},
+		Timeout: otlptracegrpc.WithTimeout,
+		Retry: func(retry RetryConfig) otlptracegrpc.Option {
+			return otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig{
+				Enabled:         !retry.Disabled,
+				InitialInterval: retry.InitialInterval,
+				MaxInterval:     retry.MaxInterval,
+				MaxElapsedTime:  retry.MaxElapsedTime,
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
 	exporter, err := otlptracegrpc.New(ctx, opts...)
 	if err != nil {
@@ -135,54 +187,197 @@ func createGRPCExporter(ctx context.Context, cfg *Config) (sdktrace.SpanExporter
 	return exporter, nil
 }
 
-// createSampler creates a sampler based on sample rate.
-func createSampler(sampleRate float64) sdktrace.Sampler {
+// createSampler creates a sampler based on sample rate, optionally
+// respecting the parent span's sampling decision.
+func createSampler(sampleRate float64, parentBased bool) sdktrace.Sampler {
+	var sampler sdktrace.Sampler
 	switch {
 	case sampleRate <= 0:
-		return sdktrace.NeverSample()
+		sampler = sdktrace.NeverSample()
 	case sampleRate >= 1:
-		return sdktrace.AlwaysSample()
+		sampler = sdktrace.AlwaysSample()
 	default:
-		return sdktrace.TraceIDRatioBased(sampleRate)
+		sampler = sdktrace.TraceIDRatioBased(sampleRate)
+	}
+	if parentBased {
+		return sdktrace.ParentBased(sampler)
+	}
+	return sampler
+}
+
+// Batch span processor defaults, matching the SDK.
+const (
+	defaultBatchMaxQueueSize       = sdktrace.DefaultMaxQueueSize
+	defaultBatchMaxExportBatchSize = sdktrace.DefaultMaxExportBatchSize
+	defaultBatchScheduleDelay      = sdktrace.DefaultScheduleDelay * time.Millisecond
+	defaultBatchExportTimeout      = sdktrace.DefaultExportTimeout * time.Millisecond
+)
+
+// batchWithDefaults returns the effective batch settings with zero values
+// replaced by the SDK defaults.
+func batchWithDefaults(b BatchConfig) BatchConfig {
+	if b.MaxQueueSize == 0 {
+		b.MaxQueueSize = defaultBatchMaxQueueSize
+	}
+	if b.MaxExportBatchSize == 0 {
+		b.MaxExportBatchSize = min(defaultBatchMaxExportBatchSize, b.MaxQueueSize)
 	}
+	if b.ScheduleDelay == 0 {
+		b.ScheduleDelay = defaultBatchScheduleDelay
+	}
+	if b.ExportTimeout == 0 {
+		b.ExportTimeout = defaultBatchExportTimeout
+	}
+	return b
+}
+
+// createSpanLimits returns the SDK span limits with configured overrides applied.
+func createSpanLimits(cfg SpanLimitsConfig) sdktrace.SpanLimits {
+	limits := sdktrace.NewSpanLimits()
+	if cfg.AttributeCountLimit > 0 {
+		limits.AttributeCountLimit = cfg.AttributeCountLimit
+	}
+	if cfg.AttributeValueLengthLimit > 0 {
+		limits.AttributeValueLengthLimit = cfg.AttributeValueLengthLimit
+	}
+	if cfg.EventCountLimit > 0 {
+		limits.EventCountLimit = cfg.EventCountLimit
+	}
+	if cfg.LinkCountLimit > 0 {
+		limits.LinkCountLimit = cfg.LinkCountLimit
+	}
+	return limits
 }
 
 // createProvider creates a tracer provider with the given configuration.
-func createProvider(res *resource.Resource, sampler sdktrace.Sampler, exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+func createProvider(
+	res *resource.Resource,
+	sampler sdktrace.Sampler,
+	exporter sdktrace.SpanExporter,
+	batch BatchConfig,
+	limits sdktrace.SpanLimits,
+	processors ...sdktrace.SpanProcessor,
+) *sdktrace.TracerProvider {
 	providerOpts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
 		sdktrace.WithSampler(sampler),
+		sdktrace.WithRawSpanLimits(limits),
+	}
+
+	// Processors that enrich spans are registered ahead of the exporter.
+	for _, p := range processors {
+		providerOpts = append(providerOpts, sdktrace.WithSpanProcessor(p))
 	}
 
 	if exporter != nil {
-		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter,
+			sdktrace.WithMaxQueueSize(batch.MaxQueueSize),
+			sdktrace.WithMaxExportBatchSize(batch.MaxExportBatchSize),
+			sdktrace.WithBatchTimeout(batch.ScheduleDelay),
+			sdktrace.WithExportTimeout(batch.ExportTimeout),
+		))
 	}
 
 	return sdktrace.NewTracerProvider(providerOpts...)
 }
 
-// createPropagator creates a trace context propagator based on the configured propagation type.
-func createPropagator(propagationType PropagationType) propagation.TextMapPropagator {
+// logSettings reports the effective tracing settings.
+func logSettings(cfg *Config, batch BatchConfig, limits sdktrace.SpanLimits) {
+	cfg.Logger.Info("tracing initialized",
+		"service", cfg.ServiceName,
+		"exporter", cfg.Exporter,
+		"endpoint", cfg.Endpoint,
+		"sample_rate", cfg.SampleRate,
+		"batch_max_queue_size", batch.MaxQueueSize,
+		"batch_max_export_batch_size", batch.MaxExportBatchSize,
+		"batch_schedule_delay", batch.ScheduleDelay,
+		"batch_export_timeout", batch.ExportTimeout,
+		"span_attribute_count_limit", limits.AttributeCountLimit,
+		"span_attribute_value_length_limit", limits.AttributeValueLengthLimit,
+		"span_event_count_limit", limits.EventCountLimit,
+		"span_link_count_limit", limits.LinkCountLimit,
+	)
+}
+
+// createPropagator creates a propagator that extracts every configured format
+// and injects the inject subset, or every configured format if it is empty.
+// Extra propagators, such as correlation headers, are always used for both.
+func createPropagator(types, injectTypes []PropagationType, extra ...propagation.TextMapPropagator) propagation.TextMapPropagator {
+	if len(injectTypes) == 0 {
+		injectTypes = types
+	}
+
+	// Composite extraction lets later propagators override earlier ones, so
+	// extract in reverse to give the first configured format precedence.
+	extractors := make([]propagation.TextMapPropagator, 0, len(types))
+	for i := len(types) - 1; i >= 0; i-- {
+		if p := propagatorFor(types[i]); p != nil {
+			extractors = append(extractors, p)
+		}
+	}
+
+	injectors := make([]propagation.TextMapPropagator, 0, len(injectTypes))
+	for _, t := range injectTypes {
+		if p := propagatorFor(t); p != nil {
+			injectors = append(injectors, p)
+		}
+	}
+
+	// Extra propagators run last so they see context from all formats.
+	extractors = append(extractors, extra...)
+	injectors = append(injectors, extra...)
+
+	return splitPropagator{
+		extractor: propagation.NewCompositeTextMapPropagator(extractors...),
+		injector:  propagation.NewCompositeTextMapPropagator(injectors...),
+	}
+}
+
+// propagatorFor returns the propagator for a single propagation type,
+// or nil for PropagationNone.
+func propagatorFor(propagationType PropagationType) propagation.TextMapPropagator {
 	switch propagationType {
-	case PropagationB3:
-		return propagation.NewCompositeTextMapPropagator(
-			b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)),
-			propagation.Baggage{},
-		)
-	case PropagationW3C:
-		return propagation.NewCompositeTextMapPropagator(
-			propagation.TraceContext{},
-			propagation.Baggage{},
-		)
+	case PropagationB3, PropagationB3Multi:
+		return b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader))
+	case PropagationB3Single:
+		return b3.New(b3.WithInjectEncoding(b3.B3SingleHeader))
+	case PropagationJaeger:
+		return jaeger.Jaeger{}
+	case PropagationBaggage:
+		return propagation.Baggage{}
+	case PropagationNone:
+		return nil
 	default:
-		// Default to W3C trace context
-		return propagation.NewCompositeTextMapPropagator(
-			propagation.TraceContext{},
-			propagation.Baggage{},
-		)
+		return propagation.TraceContext{}
 	}
 }
 
+// splitPropagator extracts and injects with different propagators.
+type splitPropagator struct {
+	extractor propagation.TextMapPropagator
+	injector  propagation.TextMapPropagator
+}
+
+// Inject implements propagation.TextMapPropagator.
+func (p splitPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	p.injector.Inject(ctx, carrier)
+}
+
+// Extract implements propagation.TextMapPropagator.
+func (p splitPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return p.extractor.Extract(ctx, carrier)
+}
+
+// Fields implements propagation.TextMapPropagator.
+func (p splitPropagator) Fields() []string {
+	return p.injector.Fields()
+}
+
+// ClientIPResolver returns the resolver used by Middleware to determine client IPs.
+func (t *Tracer) ClientIPResolver() *ClientIPResolver {
+	return t.clientIP
+}
+
 // Tracer returns the underlying OpenTelemetry tracer.
 func (t *Tracer) Tracer() trace.Tracer {
 	return t.tracer
@@ -193,6 +388,16 @@ func (t *Tracer) Provider() *sdktrace.TracerProvider {
 	return t.provider
 }
 
+// Propagator returns the trace context propagator configured for this tracer.
+func (t *Tracer) Propagator() propagation.TextMapPropagator {
+	return t.propagator
+}
+
+// Resource returns the resource describing this service.
+func (t *Tracer) Resource() *resource.Resource {
+	return t.resource
+}
+
 // Config returns the tracer configuration.
 func (t *Tracer) Config() Config {
 	return t.config
@@ -203,8 +408,15 @@ func (t *Tracer) Start(ctx context.Context, name string, opts ...trace.SpanStart
 	return t.tracer.Start(ctx, name, opts...)
 }
 
+// RecordError records an error on the current span in context using this
+// Tracer's error settings. See the package-level RecordError.
+func (t *Tracer) RecordError(ctx context.Context, err error, opts ...ErrorOption) {
+	t.errors.record(trace.SpanFromContext(ctx), err, opts, 1)
+}
+
 // Shutdown gracefully shuts down the tracer provider.
 func (t *Tracer) Shutdown(ctx context.Context) error {
+	errorRecorders.Delete(t.provider)
 	return t.provider.Shutdown(ctx)
 }
 
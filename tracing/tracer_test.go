@@ -1,8 +1,18 @@
 package tracing
 
 import (
+	"bytes"
 	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNew_ValidConfig(t *testing.T) {
@@ -168,11 +178,13 @@ func TestNew_PropagationConfigurations(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		propagation PropagationType
+		propagation []PropagationType
 	}{
-		{"w3c propagation", PropagationW3C},
-		{"b3 propagation", PropagationB3},
-		{"empty propagation defaults to w3c", ""},
+		{"w3c propagation", []PropagationType{PropagationW3C}},
+		{"b3 propagation", []PropagationType{PropagationB3}},
+		{"mixed propagation", []PropagationType{PropagationW3C, PropagationB3Single, PropagationJaeger, PropagationBaggage}},
+		{"no propagation", []PropagationType{PropagationNone}},
+		{"empty propagation defaults to w3c", nil},
 	}
 
 	for _, tt := range tests {
@@ -270,3 +282,298 @@ func TestNew_WithHeaders(t *testing.T) {
 		t.Error("Headers not preserved in config")
 	}
 }
+
+func TestNew_ExporterOptions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+	tlsCfg := TLSConfig{CAFile: certFile, CertFile: certFile, KeyFile: keyFile}
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{
+			name: "http with mTLS and tuning",
+			cfg: Config{
+				ServiceName: "test-service",
+				Endpoint:    "localhost:4318",
+				Exporter:    ExporterOTLPHTTP,
+				TLS:         tlsCfg,
+				Compression: CompressionGzip,
+				Timeout:     2 * time.Second,
+				Retry:       RetryConfig{InitialInterval: time.Second},
+				URLPath:     "/custom/v1/traces",
+			},
+		},
+		{
+			name: "grpc with mTLS and tuning",
+			cfg: Config{
+				ServiceName: "test-service",
+				Endpoint:    "localhost:4317",
+				Exporter:    ExporterOTLPGRPC,
+				TLS:         tlsCfg,
+				Compression: CompressionGzip,
+				Timeout:     2 * time.Second,
+				Retry:       RetryConfig{Disabled: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			tracer, err := New(ctx, tt.cfg)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+			tracer.Shutdown(shutdownCtx)
+		})
+	}
+}
+
+func TestNew_ExporterTLSError(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{
+		ServiceName: "test-service",
+		Exporter:    ExporterOTLPHTTP,
+		TLS:         TLSConfig{CAFile: "/nonexistent/ca.pem"},
+	}
+
+	tracer, err := New(context.Background(), cfg)
+	if err == nil {
+		tracer.Shutdown(context.Background())
+		t.Fatal("New() error = nil, want error")
+	}
+}
+
+func TestBatchWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	batch := batchWithDefaults(BatchConfig{MaxQueueSize: 100, ScheduleDelay: time.Second})
+
+	if batch.MaxQueueSize != 100 {
+		t.Errorf("MaxQueueSize = %d, want 100", batch.MaxQueueSize)
+	}
+	if batch.MaxExportBatchSize != 100 {
+		t.Errorf("MaxExportBatchSize = %d, want 100 (capped at queue size)", batch.MaxExportBatchSize)
+	}
+	if batch.ScheduleDelay != time.Second {
+		t.Errorf("ScheduleDelay = %v, want 1s", batch.ScheduleDelay)
+	}
+	if batch.ExportTimeout != defaultBatchExportTimeout {
+		t.Errorf("ExportTimeout = %v, want %v", batch.ExportTimeout, defaultBatchExportTimeout)
+	}
+
+	defaults := batchWithDefaults(BatchConfig{})
+	if defaults.MaxQueueSize != defaultBatchMaxQueueSize {
+		t.Errorf("MaxQueueSize = %d, want %d", defaults.MaxQueueSize, defaultBatchMaxQueueSize)
+	}
+	if defaults.MaxExportBatchSize != defaultBatchMaxExportBatchSize {
+		t.Errorf("MaxExportBatchSize = %d, want %d", defaults.MaxExportBatchSize, defaultBatchMaxExportBatchSize)
+	}
+	if defaults.ScheduleDelay != 5*time.Second {
+		t.Errorf("ScheduleDelay = %v, want 5s", defaults.ScheduleDelay)
+	}
+}
+
+func TestCreateSpanLimits(t *testing.T) {
+	t.Parallel()
+
+	limits := createSpanLimits(SpanLimitsConfig{
+		AttributeCountLimit:       10,
+		AttributeValueLengthLimit: 64,
+		EventCountLimit:           5,
+		LinkCountLimit:            2,
+	})
+
+	if limits.AttributeCountLimit != 10 {
+		t.Errorf("AttributeCountLimit = %d, want 10", limits.AttributeCountLimit)
+	}
+	if limits.AttributeValueLengthLimit != 64 {
+		t.Errorf("AttributeValueLengthLimit = %d, want 64", limits.AttributeValueLengthLimit)
+	}
+	if limits.EventCountLimit != 5 {
+		t.Errorf("EventCountLimit = %d, want 5", limits.EventCountLimit)
+	}
+	if limits.LinkCountLimit != 2 {
+		t.Errorf("LinkCountLimit = %d, want 2", limits.LinkCountLimit)
+	}
+}
+
+func TestNew_SpanLimitsApplied(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var buf bytes.Buffer
+	cfg := Config{
+		ServiceName: "test-service",
+		Exporter:    ExporterNone,
+		SampleRate:  1.0,
+		SpanLimits:  SpanLimitsConfig{AttributeCountLimit: 2},
+		Logger:      slog.New(slog.NewTextHandler(&buf, nil)),
+	}
+
+	tracer, err := New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	_, span := tracer.Start(ctx, "limited-span")
+	span.SetAttributes(RideID("r1"), DriverID("d1"), RiderID("u1"))
+	span.End()
+
+	ro, ok := span.(sdktrace.ReadOnlySpan)
+	if !ok {
+		t.Fatal("span does not expose DroppedAttributes")
+	}
+	if ro.DroppedAttributes() != 1 {
+		t.Errorf("DroppedAttributes() = %d, want 1", ro.DroppedAttributes())
+	}
+
+	logged := buf.String()
+	for _, want := range []string{"tracing initialized", "batch_max_queue_size=2048", "span_attribute_count_limit=2"} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("startup log %q does not contain %q", logged, want)
+		}
+	}
+}
+
+func TestCreateSampler_ParentBased(t *testing.T) {
+	t.Parallel()
+
+	sampler := createSampler(0.5, true)
+	if !strings.HasPrefix(sampler.Description(), "ParentBased") {
+		t.Errorf("Description() = %q, want ParentBased sampler", sampler.Description())
+	}
+
+	sampler = createSampler(0.5, false)
+	if strings.HasPrefix(sampler.Description(), "ParentBased") {
+		t.Errorf("Description() = %q, want root sampler", sampler.Description())
+	}
+}
+
+func TestCreatePropagator_ExtractAllInjectSubset(t *testing.T) {
+	t.Parallel()
+
+	prop := createPropagator(
+		[]PropagationType{PropagationW3C, PropagationB3Multi, PropagationBaggage},
+		[]PropagationType{PropagationB3Multi},
+	)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"from w3c", map[string]string{"traceparent": "00-" + traceID + "-00f067aa0ba902b7-01"}},
+		{"from b3 multi", map[string]string{"X-B3-TraceId": traceID, "X-B3-SpanId": "00f067aa0ba902b7", "X-B3-Sampled": "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			headers := http.Header{}
+			for k, v := range tt.headers {
+				headers.Set(k, v)
+			}
+			ctx := prop.Extract(context.Background(), HTTPCarrier(headers))
+
+			sc := trace.SpanContextFromContext(ctx)
+			if sc.TraceID().String() != traceID {
+				t.Fatalf("extracted TraceID = %v, want %v", sc.TraceID(), traceID)
+			}
+
+			out := http.Header{}
+			prop.Inject(ctx, HTTPCarrier(out))
+			if out.Get("X-B3-TraceId") != traceID {
+				t.Errorf("X-B3-TraceId = %q, want %q", out.Get("X-B3-TraceId"), traceID)
+			}
+			if out.Get("traceparent") != "" {
+				t.Errorf("traceparent = %q, want empty (not in inject subset)", out.Get("traceparent"))
+			}
+		})
+	}
+
+	if fields := prop.Fields(); slices.Contains(fields, "traceparent") {
+		t.Errorf("Fields() = %v, should only list injected fields", fields)
+	}
+}
+
+func TestCreatePropagator_FirstFormatWins(t *testing.T) {
+	t.Parallel()
+
+	prop := createPropagator([]PropagationType{PropagationB3Single, PropagationW3C}, nil)
+
+	headers := http.Header{}
+	headers.Set("traceparent", "00-11111111111111111111111111111111-1111111111111111-01")
+	headers.Set("b3", "22222222222222222222222222222222-2222222222222222-1")
+
+	sc := trace.SpanContextFromContext(prop.Extract(context.Background(), HTTPCarrier(headers)))
+	if sc.TraceID().String() != "22222222222222222222222222222222" {
+		t.Errorf("TraceID = %v, want the b3 trace ID", sc.TraceID())
+	}
+}
+
+func TestCreatePropagator_Jaeger(t *testing.T) {
+	t.Parallel()
+
+	prop := createPropagator([]PropagationType{PropagationJaeger}, nil)
+
+	headers := http.Header{}
+	headers.Set("uber-trace-id", "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1")
+
+	sc := trace.SpanContextFromContext(prop.Extract(context.Background(), HTTPCarrier(headers)))
+	if !sc.IsValid() {
+		t.Error("span context should be extracted from uber-trace-id")
+	}
+}
+
+func TestCreatePropagator_None(t *testing.T) {
+	t.Parallel()
+
+	prop := createPropagator([]PropagationType{PropagationNone}, nil)
+
+	headers := http.Header{}
+	headers.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	if sc := trace.SpanContextFromContext(prop.Extract(context.Background(), HTTPCarrier(headers))); sc.IsValid() {
+		t.Error("no span context should be extracted")
+	}
+	if len(prop.Fields()) != 0 {
+		t.Errorf("Fields() = %v, want empty", prop.Fields())
+	}
+}
+
+func TestNew_DisableGlobalPropagator(t *testing.T) {
+	ctx := context.Background()
+	before := otel.GetTextMapPropagator()
+
+	tracer, err := New(ctx, Config{
+		ServiceName:             "test-service",
+		Exporter:                ExporterNone,
+		Propagation:             []PropagationType{PropagationJaeger},
+		DisableGlobalPropagator: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tracer.Shutdown(ctx)
+
+	if otel.GetTextMapPropagator() != before {
+		t.Error("global propagator should not be replaced")
+	}
+	if fields := tracer.Propagator().Fields(); !slices.Equal(fields, []string{"uber-trace-id"}) {
+		t.Errorf("Propagator().Fields() = %v, want [uber-trace-id]", fields)
+	}
+}